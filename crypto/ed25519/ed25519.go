@@ -0,0 +1,86 @@
+package ed25519
+
+import (
+	ed25519Lib "crypto/ed25519"
+	"fmt"
+
+	"github.com/multiversx/mx-chain-core-go/crypto"
+)
+
+var _ crypto.Signer = (*signer)(nil)
+var _ crypto.Verifier = (*verifier)(nil)
+
+// signer is an ed25519 implementation of the signer interface
+type signer struct {
+	privateKey ed25519Lib.PrivateKey
+	publicKey  ed25519Lib.PublicKey
+}
+
+// NewSigner creates a new ed25519 signer. The private key can be provided either as a 32 bytes seed or
+// as a 64 bytes seed and public key concatenation
+func NewSigner(privateKey []byte) (*signer, error) {
+	var sk ed25519Lib.PrivateKey
+	switch len(privateKey) {
+	case ed25519Lib.SeedSize:
+		sk = ed25519Lib.NewKeyFromSeed(privateKey)
+	case ed25519Lib.PrivateKeySize:
+		sk = ed25519Lib.NewKeyFromSeed(privateKey[:ed25519Lib.SeedSize])
+		if !sk.Equal(ed25519Lib.PrivateKey(privateKey)) {
+			return nil, fmt.Errorf("%w, public key part does not match the seed", crypto.ErrInvalidPrivateKey)
+		}
+	default:
+		return nil, fmt.Errorf("%w, length %d", crypto.ErrInvalidPrivateKey, len(privateKey))
+	}
+
+	return &signer{
+		privateKey: sk,
+		publicKey:  sk.Public().(ed25519Lib.PublicKey),
+	}, nil
+}
+
+// Sign returns the ed25519 signature of the provided message
+func (s *signer) Sign(message []byte) ([]byte, error) {
+	return ed25519Lib.Sign(s.privateKey, message), nil
+}
+
+// PublicKey returns the public key corresponding to the signer's private key
+func (s *signer) PublicKey() []byte {
+	pk := make([]byte, len(s.publicKey))
+	copy(pk, s.publicKey)
+
+	return pk
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (s *signer) IsInterfaceNil() bool {
+	return s == nil
+}
+
+// verifier is an ed25519 implementation of the verifier interface
+type verifier struct {
+}
+
+// NewVerifier creates a new ed25519 verifier
+func NewVerifier() *verifier {
+	return &verifier{}
+}
+
+// Verify checks the ed25519 signature of the provided message against the provided public key
+func (v *verifier) Verify(publicKey []byte, message []byte, signature []byte) error {
+	if len(publicKey) != ed25519Lib.PublicKeySize {
+		return fmt.Errorf("%w, length %d", crypto.ErrInvalidPublicKey, len(publicKey))
+	}
+	if len(signature) != ed25519Lib.SignatureSize {
+		return fmt.Errorf("%w, length %d", crypto.ErrInvalidSignature, len(signature))
+	}
+	if !ed25519Lib.Verify(publicKey, message, signature) {
+		return crypto.ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (v *verifier) IsInterfaceNil() bool {
+	return v == nil
+}
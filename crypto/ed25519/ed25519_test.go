@@ -0,0 +1,89 @@
+package ed25519
+
+import (
+	ed25519Lib "crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// test vector 2 from RFC 8032
+const (
+	testSeed      = "4ccd089b28ff96da9db6c346ec114e0f5b8a319f35aba624da8cf6ed4fb8a6fb"
+	testPublicKey = "3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c"
+	testMessage   = "72"
+	testSignature = "92a009a9f0d4cab8720e820b5f642540a2b27b5416503f8fb3762223ebdb69da" +
+		"085ac1e43e15996e458f3613d0f11d8c387b2eaeb4302aeeb00d291612bb0c00"
+)
+
+func decodeHex(t *testing.T, str string) []byte {
+	buff, err := hex.DecodeString(str)
+	require.Nil(t, err)
+
+	return buff
+}
+
+func TestNewSigner(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid length should error", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := NewSigner([]byte("invalid"))
+		assert.True(t, check.IfNil(s))
+		assert.True(t, errors.Is(err, crypto.ErrInvalidPrivateKey))
+	})
+	t.Run("mismatched public key should error", func(t *testing.T) {
+		t.Parallel()
+
+		sk := append(decodeHex(t, testSeed), make([]byte, ed25519Lib.PublicKeySize)...)
+		s, err := NewSigner(sk)
+		assert.True(t, check.IfNil(s))
+		assert.True(t, errors.Is(err, crypto.ErrInvalidPrivateKey))
+	})
+	t.Run("from seed should work", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := NewSigner(decodeHex(t, testSeed))
+		require.Nil(t, err)
+		assert.False(t, check.IfNil(s))
+		assert.Equal(t, decodeHex(t, testPublicKey), s.PublicKey())
+	})
+	t.Run("from full private key should work", func(t *testing.T) {
+		t.Parallel()
+
+		sk := append(decodeHex(t, testSeed), decodeHex(t, testPublicKey)...)
+		s, err := NewSigner(sk)
+		require.Nil(t, err)
+		assert.Equal(t, decodeHex(t, testPublicKey), s.PublicKey())
+	})
+}
+
+func TestSigner_SignAndVerify(t *testing.T) {
+	t.Parallel()
+
+	s, _ := NewSigner(decodeHex(t, testSeed))
+	v := NewVerifier()
+	assert.False(t, check.IfNil(v))
+
+	signature, err := s.Sign(decodeHex(t, testMessage))
+	require.Nil(t, err)
+	assert.Equal(t, decodeHex(t, testSignature), signature)
+
+	err = v.Verify(s.PublicKey(), decodeHex(t, testMessage), signature)
+	assert.Nil(t, err)
+
+	err = v.Verify(s.PublicKey(), []byte("other message"), signature)
+	assert.Equal(t, crypto.ErrInvalidSignature, err)
+
+	err = v.Verify([]byte("short"), decodeHex(t, testMessage), signature)
+	assert.True(t, errors.Is(err, crypto.ErrInvalidPublicKey))
+
+	err = v.Verify(s.PublicKey(), decodeHex(t, testMessage), signature[1:])
+	assert.True(t, errors.Is(err, crypto.ErrInvalidSignature))
+}
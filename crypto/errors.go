@@ -0,0 +1,12 @@
+package crypto
+
+import "errors"
+
+// ErrInvalidPrivateKey signals that an invalid private key has been provided
+var ErrInvalidPrivateKey = errors.New("invalid private key")
+
+// ErrInvalidPublicKey signals that an invalid public key has been provided
+var ErrInvalidPublicKey = errors.New("invalid public key")
+
+// ErrInvalidSignature signals that the signature is not valid for the provided public key and message
+var ErrInvalidSignature = errors.New("invalid signature")
@@ -0,0 +1,21 @@
+package crypto
+
+// Signer defines the behavior of a component able to sign messages using its private key
+type Signer interface {
+	Sign(message []byte) ([]byte, error)
+	PublicKey() []byte
+	IsInterfaceNil() bool
+}
+
+// Verifier defines the behavior of a component able to verify a signature against a public key and a message
+type Verifier interface {
+	Verify(publicKey []byte, message []byte, signature []byte) error
+	IsInterfaceNil() bool
+}
+
+// AggregateSignatureVerifier defines the behavior of a component able to verify an aggregated signature (e.g. BLS)
+// against the public keys of the signers and the signed message
+type AggregateSignatureVerifier interface {
+	VerifyAggregatedSignature(publicKeys [][]byte, message []byte, aggregatedSignature []byte) error
+	IsInterfaceNil() bool
+}
@@ -0,0 +1,229 @@
+package alteredAccount
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// TokenChangeType defines the kind of change of an account token
+type TokenChangeType string
+
+const (
+	// TokenAdded signals that the account received a token it did not hold before
+	TokenAdded TokenChangeType = "added"
+	// TokenCreated signals that the token was created, through an NFT create, in the account
+	TokenCreated TokenChangeType = "created"
+	// TokenRemoved signals that the token balance of the account dropped to zero
+	TokenRemoved TokenChangeType = "removed"
+	// TokenUpdated signals that the balance, the properties or the metadata of a token held by the account changed
+	TokenUpdated TokenChangeType = "updated"
+)
+
+// TokenChange holds the change of an account token, identified by its identifier and nonce
+type TokenChange struct {
+	Identifier        string
+	Nonce             uint64
+	Type              TokenChangeType
+	BalanceBefore     *big.Int
+	BalanceAfter      *big.Int
+	BalanceDelta      *big.Int
+	PropertiesChanged bool
+	MetaDataChanged   bool
+}
+
+// FieldChange holds the previous and the current value of an account field. Byte fields are hex encoded
+type FieldChange struct {
+	Field  string
+	Before string
+	After  string
+}
+
+// AccountDelta holds the changes of an account between two snapshots. IsFirstSnapshot is set when there was no
+// previous snapshot to compare with, so the current snapshot is compared with an empty account. It does not mean that
+// the account was created, as a consumer starting in the middle of the chain sees the existing accounts for the
+// first time as well
+type AccountDelta struct {
+	Address               string
+	IsFirstSnapshot       bool
+	NonceBefore           uint64
+	NonceAfter            uint64
+	BalanceBefore         *big.Int
+	BalanceAfter          *big.Int
+	BalanceDelta          *big.Int
+	TokenChanges          []*TokenChange
+	AdditionalDataChanges []*FieldChange
+}
+
+// HasChanges returns true if this is the first snapshot of the account or if any of its fields changed
+func (ad *AccountDelta) HasChanges() bool {
+	return ad.IsFirstSnapshot ||
+		ad.NonceBefore != ad.NonceAfter ||
+		ad.BalanceDelta.Sign() != 0 ||
+		len(ad.TokenChanges) > 0 ||
+		len(ad.AdditionalDataChanges) > 0
+}
+
+// ComputeAccountDelta computes the changes between the previous and the current snapshot of an account. A nil
+// previous snapshot marks the first snapshot, whose whole balance and tokens are reported as changes. As the altered accounts only hold the tokens altered in a block, a token
+// missing from the current snapshot is considered unchanged, while a token with a zero balance is considered removed.
+// The additional data is compared only if present in the current snapshot
+func ComputeAccountDelta(previous *AlteredAccount, current *AlteredAccount) (*AccountDelta, error) {
+	if current == nil {
+		return nil, ErrNilAlteredAccount
+	}
+	if previous != nil && previous.Address != current.Address {
+		return nil, fmt.Errorf("%w, previous %s, current %s", ErrAddressMismatch, previous.Address, current.Address)
+	}
+
+	balanceBefore, err := parseBalance(previous.GetBalance())
+	if err != nil {
+		return nil, err
+	}
+	balanceAfter, err := parseBalance(current.Balance)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenChanges, err := computeTokenChanges(previous.GetTokens(), current.Tokens)
+	if err != nil {
+		return nil, fmt.Errorf("%w for address %s", err, current.Address)
+	}
+
+	return &AccountDelta{
+		Address:               current.Address,
+		IsFirstSnapshot:       previous == nil,
+		NonceBefore:           previous.GetNonce(),
+		NonceAfter:            current.Nonce,
+		BalanceBefore:         balanceBefore,
+		BalanceAfter:          balanceAfter,
+		BalanceDelta:          big.NewInt(0).Sub(balanceAfter, balanceBefore),
+		TokenChanges:          tokenChanges,
+		AdditionalDataChanges: computeAdditionalDataChanges(previous.GetAdditionalData(), current.AdditionalData),
+	}, nil
+}
+
+func computeTokenChanges(previousTokens []*AccountTokenData, currentTokens []*AccountTokenData) ([]*TokenChange, error) {
+	previousByKey := make(map[tokenKey]*AccountTokenData, len(previousTokens))
+	for _, token := range previousTokens {
+		if token != nil {
+			previousByKey[getTokenKey(token)] = token
+		}
+	}
+
+	tokenChanges := make([]*TokenChange, 0)
+	for _, current := range currentTokens {
+		if current == nil {
+			continue
+		}
+
+		tokenChange, err := computeTokenChange(previousByKey[getTokenKey(current)], current)
+		if err != nil {
+			return nil, err
+		}
+		if tokenChange != nil {
+			tokenChanges = append(tokenChanges, tokenChange)
+		}
+	}
+
+	sort.Slice(tokenChanges, func(i, j int) bool {
+		if tokenChanges[i].Identifier != tokenChanges[j].Identifier {
+			return tokenChanges[i].Identifier < tokenChanges[j].Identifier
+		}
+		return tokenChanges[i].Nonce < tokenChanges[j].Nonce
+	})
+
+	return tokenChanges, nil
+}
+
+// computeTokenChange returns nil if the token did not change
+func computeTokenChange(previous *AccountTokenData, current *AccountTokenData) (*TokenChange, error) {
+	balanceBefore, err := parseBalance(previous.GetBalance())
+	if err != nil {
+		return nil, fmt.Errorf("%w, token %s", err, current.Identifier)
+	}
+	balanceAfter, err := parseBalance(current.Balance)
+	if err != nil {
+		return nil, fmt.Errorf("%w, token %s", err, current.Identifier)
+	}
+
+	tokenChange := &TokenChange{
+		Identifier:    current.Identifier,
+		Nonce:         current.Nonce,
+		BalanceBefore: balanceBefore,
+		BalanceAfter:  balanceAfter,
+		BalanceDelta:  big.NewInt(0).Sub(balanceAfter, balanceBefore),
+	}
+
+	hadToken := balanceBefore.Sign() > 0
+	hasToken := balanceAfter.Sign() > 0
+	switch {
+	case current.GetAdditionalData().GetIsNFTCreate():
+		tokenChange.Type = TokenCreated
+	case !hadToken && hasToken:
+		tokenChange.Type = TokenAdded
+	case hadToken && !hasToken:
+		tokenChange.Type = TokenRemoved
+	default:
+		tokenChange.Type = TokenUpdated
+	}
+
+	if previous != nil && hasToken {
+		tokenChange.PropertiesChanged = previous.Properties != current.Properties
+		tokenChange.MetaDataChanged = current.MetaData != nil && !current.MetaData.Equal(previous.MetaData)
+	}
+
+	isUnchanged := tokenChange.Type == TokenUpdated &&
+		tokenChange.BalanceDelta.Sign() == 0 &&
+		!tokenChange.PropertiesChanged &&
+		!tokenChange.MetaDataChanged
+	if isUnchanged {
+		return nil, nil
+	}
+
+	return tokenChange, nil
+}
+
+func computeAdditionalDataChanges(previous *AdditionalAccountData, current *AdditionalAccountData) []*FieldChange {
+	changes := make([]*FieldChange, 0)
+	if current == nil {
+		return changes
+	}
+
+	addChange := func(field string, before string, after string) {
+		if before != after {
+			changes = append(changes, &FieldChange{Field: field, Before: before, After: after})
+		}
+	}
+	addChange("CurrentOwner", previous.GetCurrentOwner(), current.CurrentOwner)
+	addChange("UserName", previous.GetUserName(), current.UserName)
+	addChange("DeveloperRewards", previous.GetDeveloperRewards(), current.DeveloperRewards)
+	addChange("CodeHash", hex.EncodeToString(previous.GetCodeHash()), hex.EncodeToString(current.CodeHash))
+	addChange("RootHash", hex.EncodeToString(previous.GetRootHash()), hex.EncodeToString(current.RootHash))
+	addChange("CodeMetadata", hex.EncodeToString(previous.GetCodeMetadata()), hex.EncodeToString(current.CodeMetadata))
+
+	return changes
+}
+
+type tokenKey struct {
+	identifier string
+	nonce      uint64
+}
+
+func getTokenKey(token *AccountTokenData) tokenKey {
+	return tokenKey{identifier: token.Identifier, nonce: token.Nonce}
+}
+
+func parseBalance(balance string) (*big.Int, error) {
+	if len(balance) == 0 {
+		return big.NewInt(0), nil
+	}
+
+	value, ok := big.NewInt(0).SetString(balance, 10)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidBalance, balance)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,145 @@
+package alteredAccount
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func describeTokenChanges(tokenChanges []*TokenChange) []string {
+	descriptions := make([]string, 0, len(tokenChanges))
+	for _, tc := range tokenChanges {
+		descriptions = append(descriptions, fmt.Sprintf("%s/%d %s %s->%s (%s) properties:%v metadata:%v",
+			tc.Identifier, tc.Nonce, tc.Type, tc.BalanceBefore, tc.BalanceAfter, tc.BalanceDelta, tc.PropertiesChanged, tc.MetaDataChanged))
+	}
+
+	return descriptions
+}
+
+func TestComputeAccountDelta(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid snapshots should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ComputeAccountDelta(nil, nil)
+		assert.Equal(t, ErrNilAlteredAccount, err)
+
+		_, err = ComputeAccountDelta(&AlteredAccount{Address: "alice"}, &AlteredAccount{Address: "bob"})
+		assert.True(t, errors.Is(err, ErrAddressMismatch))
+
+		_, err = ComputeAccountDelta(nil, &AlteredAccount{Address: "alice", Balance: "ten"})
+		assert.True(t, errors.Is(err, ErrInvalidBalance))
+
+		_, err = ComputeAccountDelta(nil, &AlteredAccount{
+			Address: "alice",
+			Tokens:  []*AccountTokenData{{Identifier: "TKN-abcdef", Balance: "1.5"}},
+		})
+		assert.True(t, errors.Is(err, ErrInvalidBalance))
+	})
+	t.Run("new account", func(t *testing.T) {
+		t.Parallel()
+
+		delta, err := ComputeAccountDelta(nil, &AlteredAccount{
+			Address: "alice",
+			Nonce:   1,
+			Balance: "100",
+			Tokens:  []*AccountTokenData{{Identifier: "TKN-abcdef", Balance: "5"}},
+		})
+		require.Nil(t, err)
+		assert.True(t, delta.IsFirstSnapshot)
+		assert.True(t, delta.HasChanges())
+		assert.Equal(t, big.NewInt(100), delta.BalanceDelta)
+		require.Len(t, delta.TokenChanges, 1)
+		assert.Equal(t, TokenAdded, delta.TokenChanges[0].Type)
+		assert.Equal(t, big.NewInt(5), delta.TokenChanges[0].BalanceDelta)
+	})
+	t.Run("balance and nonce changes", func(t *testing.T) {
+		t.Parallel()
+
+		previous := &AlteredAccount{Address: "alice", Nonce: 4, Balance: "100"}
+		delta, err := ComputeAccountDelta(previous, &AlteredAccount{Address: "alice", Nonce: 5, Balance: "40"})
+		require.Nil(t, err)
+		assert.False(t, delta.IsFirstSnapshot)
+		assert.Equal(t, uint64(4), delta.NonceBefore)
+		assert.Equal(t, uint64(5), delta.NonceAfter)
+		assert.Equal(t, big.NewInt(-60), delta.BalanceDelta)
+		assert.Empty(t, delta.TokenChanges)
+
+		delta, _ = ComputeAccountDelta(previous, &AlteredAccount{Address: "alice", Nonce: 4, Balance: "100"})
+		assert.False(t, delta.HasChanges())
+	})
+	t.Run("token changes", func(t *testing.T) {
+		t.Parallel()
+
+		previous := &AlteredAccount{
+			Address: "alice",
+			Balance: "1",
+			Tokens: []*AccountTokenData{
+				{Identifier: "TKN-abcdef", Balance: "10"},
+				{Identifier: "OLD-abcdef", Balance: "3"},
+				{Identifier: "NFT-abcdef", Nonce: 2, Balance: "1", MetaData: &TokenMetaData{Nonce: 2, Name: "nft"}},
+				{Identifier: "UNCHANGED-abcdef", Balance: "7"},
+			},
+		}
+		current := &AlteredAccount{
+			Address: "alice",
+			Balance: "1",
+			Tokens: []*AccountTokenData{
+				{Identifier: "TKN-abcdef", Balance: "15"},
+				{Identifier: "OLD-abcdef", Balance: "0"},
+				{Identifier: "NFT-abcdef", Nonce: 2, Balance: "1", MetaData: &TokenMetaData{Nonce: 2, Name: "renamed"}},
+				{Identifier: "NFT-abcdef", Nonce: 3, Balance: "1", AdditionalData: &AdditionalAccountTokenData{IsNFTCreate: true}},
+				{Identifier: "NEW-abcdef", Balance: "2", Properties: "frozen"},
+				{Identifier: "UNCHANGED-abcdef", Balance: "7"},
+			},
+		}
+
+		delta, err := ComputeAccountDelta(previous, current)
+		require.Nil(t, err)
+		assert.True(t, delta.HasChanges())
+		assert.Zero(t, delta.BalanceDelta.Sign())
+
+		expectedChanges := []string{
+			"NEW-abcdef/0 added 0->2 (2) properties:false metadata:false",
+			"NFT-abcdef/2 updated 1->1 (0) properties:false metadata:true",
+			"NFT-abcdef/3 created 0->1 (1) properties:false metadata:false",
+			"OLD-abcdef/0 removed 3->0 (-3) properties:false metadata:false",
+			"TKN-abcdef/0 updated 10->15 (5) properties:false metadata:false",
+		}
+		assert.Equal(t, expectedChanges, describeTokenChanges(delta.TokenChanges))
+	})
+	t.Run("properties change", func(t *testing.T) {
+		t.Parallel()
+
+		previous := &AlteredAccount{Address: "alice", Tokens: []*AccountTokenData{{Identifier: "TKN-abcdef", Balance: "1"}}}
+		current := &AlteredAccount{Address: "alice", Tokens: []*AccountTokenData{{Identifier: "TKN-abcdef", Balance: "1", Properties: "frozen"}}}
+
+		delta, _ := ComputeAccountDelta(previous, current)
+		require.Len(t, delta.TokenChanges, 1)
+		assert.Equal(t, TokenUpdated, delta.TokenChanges[0].Type)
+		assert.True(t, delta.TokenChanges[0].PropertiesChanged)
+	})
+	t.Run("additional data changes", func(t *testing.T) {
+		t.Parallel()
+
+		previous := &AlteredAccount{Address: "sc", AdditionalData: &AdditionalAccountData{CurrentOwner: "alice", CodeHash: []byte{1}}}
+		current := &AlteredAccount{Address: "sc", AdditionalData: &AdditionalAccountData{CurrentOwner: "bob", CodeHash: []byte{2}, UserName: "sc.elrond"}}
+
+		delta, _ := ComputeAccountDelta(previous, current)
+		expectedChanges := []*FieldChange{
+			{Field: "CurrentOwner", Before: "alice", After: "bob"},
+			{Field: "UserName", Before: "", After: "sc.elrond"},
+			{Field: "CodeHash", Before: "01", After: "02"},
+		}
+		assert.Equal(t, expectedChanges, delta.AdditionalDataChanges)
+
+		delta, _ = ComputeAccountDelta(previous, &AlteredAccount{Address: "sc"})
+		assert.Empty(t, delta.AdditionalDataChanges)
+		assert.False(t, delta.HasChanges())
+	})
+}
@@ -0,0 +1,134 @@
+package alteredAccount
+
+import (
+	"sort"
+	"sync"
+)
+
+// accountsDeltaTracker keeps the last known snapshot of each account and computes the deltas of the newer snapshots
+// against it. The stored snapshot of an account accumulates the tokens of all its snapshots, dropping the tokens
+// whose balance reached zero. The first snapshot of an address is compared with an empty account, so a consumer
+// starting in the middle of the chain should seed the tracker with the known accounts, otherwise their whole balances
+// are reported as deltas. The tracker stores copies of the provided snapshots
+type accountsDeltaTracker struct {
+	mut      sync.RWMutex
+	accounts map[string]*AlteredAccount
+}
+
+// NewAccountsDeltaTracker creates a new accounts delta tracker
+func NewAccountsDeltaTracker() *accountsDeltaTracker {
+	return &accountsDeltaTracker{
+		accounts: make(map[string]*AlteredAccount),
+	}
+}
+
+// SeedAccounts stores the provided snapshots as the known state of the accounts, without computing any delta
+func (adt *accountsDeltaTracker) SeedAccounts(accounts map[string]*AlteredAccount) {
+	adt.mut.Lock()
+	defer adt.mut.Unlock()
+
+	for _, account := range accounts {
+		if account == nil {
+			continue
+		}
+
+		adt.accounts[account.Address] = mergeSnapshots(nil, account)
+	}
+}
+
+// ProcessAccounts computes the deltas of the provided snapshots against the stored ones, and then stores them. Only
+// the deltas of the changed accounts are returned, sorted by address. Nothing is stored if any delta cannot be computed
+func (adt *accountsDeltaTracker) ProcessAccounts(accounts map[string]*AlteredAccount) ([]*AccountDelta, error) {
+	adt.mut.Lock()
+	defer adt.mut.Unlock()
+
+	deltas := make([]*AccountDelta, 0, len(accounts))
+	updatedAccounts := make(map[string]*AlteredAccount, len(accounts))
+	for _, account := range accounts {
+		if account == nil {
+			continue
+		}
+
+		previous := adt.accounts[account.Address]
+		delta, err := ComputeAccountDelta(previous, account)
+		if err != nil {
+			return nil, err
+		}
+
+		updatedAccounts[account.Address] = mergeSnapshots(previous, account)
+		if delta.HasChanges() {
+			deltas = append(deltas, delta)
+		}
+	}
+
+	for address, account := range updatedAccounts {
+		adt.accounts[address] = account
+	}
+
+	sort.Slice(deltas, func(i, j int) bool {
+		return deltas[i].Address < deltas[j].Address
+	})
+
+	return deltas, nil
+}
+
+// GetAccount returns a copy of the stored snapshot of the provided address
+func (adt *accountsDeltaTracker) GetAccount(address string) (*AlteredAccount, bool) {
+	adt.mut.RLock()
+	defer adt.mut.RUnlock()
+
+	account, found := adt.accounts[address]
+	return account.Clone(), found
+}
+
+// mergeSnapshots returns a new snapshot, not sharing any pointer with the provided ones
+func mergeSnapshots(previous *AlteredAccount, current *AlteredAccount) *AlteredAccount {
+	merged := &AlteredAccount{
+		Address:        current.Address,
+		Nonce:          current.Nonce,
+		Balance:        current.Balance,
+		AdditionalData: current.AdditionalData.Clone(),
+	}
+	if merged.AdditionalData == nil {
+		merged.AdditionalData = previous.GetAdditionalData().Clone()
+	}
+
+	tokens := make(map[tokenKey]*AccountTokenData)
+	addTokens(tokens, previous.GetTokens())
+	addTokens(tokens, current.Tokens)
+
+	merged.Tokens = make([]*AccountTokenData, 0, len(tokens))
+	for _, token := range tokens {
+		merged.Tokens = append(merged.Tokens, token)
+	}
+	sort.Slice(merged.Tokens, func(i, j int) bool {
+		if merged.Tokens[i].Identifier != merged.Tokens[j].Identifier {
+			return merged.Tokens[i].Identifier < merged.Tokens[j].Identifier
+		}
+		return merged.Tokens[i].Nonce < merged.Tokens[j].Nonce
+	})
+
+	return merged
+}
+
+// addTokens overrides the tokens with the same identifier and nonce, dropping the ones with a zero balance
+func addTokens(tokens map[tokenKey]*AccountTokenData, newTokens []*AccountTokenData) {
+	for _, token := range newTokens {
+		if token == nil {
+			continue
+		}
+
+		key := getTokenKey(token)
+		balance, err := parseBalance(token.Balance)
+		if err == nil && balance.Sign() == 0 {
+			delete(tokens, key)
+			continue
+		}
+		tokens[key] = token.Clone()
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (adt *accountsDeltaTracker) IsInterfaceNil() bool {
+	return adt == nil
+}
@@ -0,0 +1,136 @@
+package alteredAccount
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAccountsDeltaTracker(t *testing.T) {
+	t.Parallel()
+
+	adt := NewAccountsDeltaTracker()
+	assert.False(t, check.IfNil(adt))
+}
+
+func TestAccountsDeltaTracker_ProcessAccounts(t *testing.T) {
+	t.Parallel()
+
+	t.Run("deltas should be computed against the stored snapshots", func(t *testing.T) {
+		t.Parallel()
+
+		adt := NewAccountsDeltaTracker()
+		deltas, err := adt.ProcessAccounts(map[string]*AlteredAccount{
+			"bob":   {Address: "bob", Balance: "5"},
+			"alice": {Address: "alice", Balance: "10", Tokens: []*AccountTokenData{{Identifier: "TKN-abcdef", Balance: "3"}}},
+		})
+		require.Nil(t, err)
+		require.Len(t, deltas, 2)
+		assert.Equal(t, "alice", deltas[0].Address)
+		assert.Equal(t, "bob", deltas[1].Address)
+		assert.True(t, deltas[0].IsFirstSnapshot)
+
+		deltas, err = adt.ProcessAccounts(map[string]*AlteredAccount{
+			"alice": {Address: "alice", Balance: "10", Tokens: []*AccountTokenData{{Identifier: "OTHER-abcdef", Balance: "1"}}},
+			"bob":   {Address: "bob", Balance: "5"},
+		})
+		require.Nil(t, err)
+		require.Len(t, deltas, 1)
+		assert.False(t, deltas[0].IsFirstSnapshot)
+		require.Len(t, deltas[0].TokenChanges, 1)
+		assert.Equal(t, TokenAdded, deltas[0].TokenChanges[0].Type)
+
+		account, found := adt.GetAccount("alice")
+		require.True(t, found)
+		assert.Len(t, account.Tokens, 2)
+
+		deltas, _ = adt.ProcessAccounts(map[string]*AlteredAccount{
+			"alice": {Address: "alice", Balance: "10", Tokens: []*AccountTokenData{{Identifier: "TKN-abcdef", Balance: "0"}}},
+		})
+		require.Len(t, deltas, 1)
+		require.Len(t, deltas[0].TokenChanges, 1)
+		assert.Equal(t, TokenRemoved, deltas[0].TokenChanges[0].Type)
+		assert.Equal(t, big.NewInt(-3), deltas[0].TokenChanges[0].BalanceDelta)
+
+		account, _ = adt.GetAccount("alice")
+		require.Len(t, account.Tokens, 1)
+		assert.Equal(t, "OTHER-abcdef", account.Tokens[0].Identifier)
+	})
+	t.Run("missing additional data should keep the stored one", func(t *testing.T) {
+		t.Parallel()
+
+		adt := NewAccountsDeltaTracker()
+		_, _ = adt.ProcessAccounts(map[string]*AlteredAccount{
+			"sc": {Address: "sc", AdditionalData: &AdditionalAccountData{CurrentOwner: "alice"}},
+		})
+		_, _ = adt.ProcessAccounts(map[string]*AlteredAccount{
+			"sc": {Address: "sc", Balance: "1"},
+		})
+
+		account, _ := adt.GetAccount("sc")
+		assert.Equal(t, "alice", account.AdditionalData.CurrentOwner)
+		assert.Equal(t, "1", account.Balance)
+	})
+	t.Run("seeded accounts should not be reported as first snapshots", func(t *testing.T) {
+		t.Parallel()
+
+		adt := NewAccountsDeltaTracker()
+		adt.SeedAccounts(map[string]*AlteredAccount{
+			"alice": {Address: "alice", Nonce: 7, Balance: "1000", Tokens: []*AccountTokenData{{Identifier: "TKN-abcdef", Balance: "3"}}},
+			"nil":   nil,
+		})
+
+		deltas, err := adt.ProcessAccounts(map[string]*AlteredAccount{
+			"alice": {Address: "alice", Nonce: 8, Balance: "990"},
+		})
+		require.Nil(t, err)
+		require.Len(t, deltas, 1)
+		assert.False(t, deltas[0].IsFirstSnapshot)
+		assert.Equal(t, big.NewInt(-10), deltas[0].BalanceDelta)
+		assert.Empty(t, deltas[0].TokenChanges)
+
+		account, _ := adt.GetAccount("alice")
+		require.Len(t, account.Tokens, 1)
+		assert.Equal(t, "3", account.Tokens[0].Balance)
+	})
+	t.Run("stored snapshots should not share pointers with the callers", func(t *testing.T) {
+		t.Parallel()
+
+		adt := NewAccountsDeltaTracker()
+		snapshot := &AlteredAccount{
+			Address:        "sc",
+			Balance:        "1",
+			Tokens:         []*AccountTokenData{{Identifier: "TKN-abcdef", Balance: "3"}},
+			AdditionalData: &AdditionalAccountData{CurrentOwner: "alice"},
+		}
+		_, _ = adt.ProcessAccounts(map[string]*AlteredAccount{"sc": snapshot})
+		snapshot.Tokens[0].Balance = "100"
+		snapshot.AdditionalData.CurrentOwner = "bob"
+
+		account, _ := adt.GetAccount("sc")
+		assert.Equal(t, "3", account.Tokens[0].Balance)
+		assert.Equal(t, "alice", account.AdditionalData.CurrentOwner)
+
+		account.Tokens[0].Balance = "200"
+		account, _ = adt.GetAccount("sc")
+		assert.Equal(t, "3", account.Tokens[0].Balance)
+	})
+	t.Run("invalid snapshot should not store anything", func(t *testing.T) {
+		t.Parallel()
+
+		adt := NewAccountsDeltaTracker()
+		deltas, err := adt.ProcessAccounts(map[string]*AlteredAccount{
+			"alice": {Address: "alice", Balance: "10"},
+			"bob":   {Address: "bob", Balance: "invalid"},
+		})
+		assert.True(t, errors.Is(err, ErrInvalidBalance))
+		assert.Nil(t, deltas)
+
+		_, found := adt.GetAccount("alice")
+		assert.False(t, found)
+	})
+}
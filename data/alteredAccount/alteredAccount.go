@@ -1,2 +1,84 @@
 //go:generate protoc -I=. -I=$GOPATH/src -I=$GOPATH/src/github.com/multiversx/protobuf/protobuf  --gogoslick_out=$GOPATH/src alteredAccount.proto
 package alteredAccount
+
+// Clone returns a deep copy of the altered account
+func (m *AlteredAccount) Clone() *AlteredAccount {
+	if m == nil {
+		return nil
+	}
+
+	account := &AlteredAccount{
+		Address:        m.Address,
+		Nonce:          m.Nonce,
+		Balance:        m.Balance,
+		AdditionalData: m.AdditionalData.Clone(),
+	}
+	if m.Tokens != nil {
+		account.Tokens = make([]*AccountTokenData, 0, len(m.Tokens))
+		for _, token := range m.Tokens {
+			account.Tokens = append(account.Tokens, token.Clone())
+		}
+	}
+
+	return account
+}
+
+// Clone returns a deep copy of the account token data
+func (m *AccountTokenData) Clone() *AccountTokenData {
+	if m == nil {
+		return nil
+	}
+
+	token := *m
+	token.MetaData = m.MetaData.Clone()
+	if m.AdditionalData != nil {
+		additionalData := *m.AdditionalData
+		token.AdditionalData = &additionalData
+	}
+
+	return &token
+}
+
+// Clone returns a deep copy of the token metadata
+func (m *TokenMetaData) Clone() *TokenMetaData {
+	if m == nil {
+		return nil
+	}
+
+	metaData := *m
+	metaData.Hash = cloneBytes(m.Hash)
+	metaData.Attributes = cloneBytes(m.Attributes)
+	if m.URIs != nil {
+		metaData.URIs = make([][]byte, 0, len(m.URIs))
+		for _, uri := range m.URIs {
+			metaData.URIs = append(metaData.URIs, cloneBytes(uri))
+		}
+	}
+
+	return &metaData
+}
+
+// Clone returns a deep copy of the additional account data
+func (m *AdditionalAccountData) Clone() *AdditionalAccountData {
+	if m == nil {
+		return nil
+	}
+
+	additionalData := *m
+	additionalData.CodeHash = cloneBytes(m.CodeHash)
+	additionalData.RootHash = cloneBytes(m.RootHash)
+	additionalData.CodeMetadata = cloneBytes(m.CodeMetadata)
+
+	return &additionalData
+}
+
+func cloneBytes(buff []byte) []byte {
+	if buff == nil {
+		return nil
+	}
+
+	clone := make([]byte, len(buff))
+	copy(clone, buff)
+
+	return clone
+}
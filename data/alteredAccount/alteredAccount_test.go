@@ -0,0 +1,45 @@
+package alteredAccount
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlteredAccount_Clone(t *testing.T) {
+	t.Parallel()
+
+	var nilAccount *AlteredAccount
+	assert.Nil(t, nilAccount.Clone())
+
+	account := &AlteredAccount{
+		Address: "alice",
+		Nonce:   1,
+		Balance: "10",
+		Tokens: []*AccountTokenData{
+			nil,
+			{
+				Identifier:     "NFT-abcdef",
+				Nonce:          2,
+				Balance:        "1",
+				MetaData:       &TokenMetaData{Name: "nft", Hash: []byte("hash"), URIs: [][]byte{[]byte("uri")}, Attributes: []byte("attr")},
+				AdditionalData: &AdditionalAccountTokenData{IsNFTCreate: true},
+			},
+		},
+		AdditionalData: &AdditionalAccountData{CurrentOwner: "bob", CodeHash: []byte("code"), RootHash: []byte("root")},
+	}
+
+	clone := account.Clone()
+	assert.Equal(t, account, clone)
+
+	clone.Tokens[1].Balance = "2"
+	clone.Tokens[1].MetaData.Hash[0] = 'H'
+	clone.Tokens[1].MetaData.URIs[0][0] = 'U'
+	clone.Tokens[1].AdditionalData.IsNFTCreate = false
+	clone.AdditionalData.CodeHash[0] = 'C'
+	assert.Equal(t, "1", account.Tokens[1].Balance)
+	assert.Equal(t, []byte("hash"), account.Tokens[1].MetaData.Hash)
+	assert.Equal(t, []byte("uri"), account.Tokens[1].MetaData.URIs[0])
+	assert.True(t, account.Tokens[1].AdditionalData.IsNFTCreate)
+	assert.Equal(t, []byte("code"), account.AdditionalData.CodeHash)
+}
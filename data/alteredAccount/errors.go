@@ -0,0 +1,12 @@
+package alteredAccount
+
+import "errors"
+
+// ErrNilAlteredAccount signals that a nil altered account has been provided
+var ErrNilAlteredAccount = errors.New("nil altered account")
+
+// ErrAddressMismatch signals that the compared snapshots belong to different accounts
+var ErrAddressMismatch = errors.New("address mismatch")
+
+// ErrInvalidBalance signals that a balance is not a base 10 integer
+var ErrInvalidBalance = errors.New("invalid balance")
@@ -0,0 +1,392 @@
+package converters
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data"
+	"github.com/multiversx/mx-chain-core-go/data/api"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-core-go/hashing"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+)
+
+// ArgsAPIBlockConverter holds the components needed to create a new api block converter
+type ArgsAPIBlockConverter struct {
+	PubkeyConverter core.PubkeyConverter
+	Marshaller      marshal.Marshalizer
+	Hasher          hashing.Hasher
+	// TransactionsProvider is optional, being required only when the transactions are requested
+	TransactionsProvider TransactionsProvider
+}
+
+type apiBlockConverter struct {
+	txConverter          *apiTransactionConverter
+	marshaller           marshal.Marshalizer
+	hasher               hashing.Hasher
+	transactionsProvider TransactionsProvider
+}
+
+// NewAPIBlockConverter creates a new converter from protocol blocks to api blocks and hyperblocks
+func NewAPIBlockConverter(args ArgsAPIBlockConverter) (*apiBlockConverter, error) {
+	if check.IfNil(args.PubkeyConverter) {
+		return nil, ErrNilPubkeyConverter
+	}
+	if check.IfNil(args.Marshaller) {
+		return nil, ErrNilMarshaller
+	}
+	if check.IfNil(args.Hasher) {
+		return nil, ErrNilHasher
+	}
+
+	txConverter, err := NewAPITransactionConverter(ArgsAPITransactionConverter{
+		PubkeyConverter: args.PubkeyConverter,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiBlockConverter{
+		txConverter:          txConverter,
+		marshaller:           args.Marshaller,
+		hasher:               args.Hasher,
+		transactionsProvider: args.TransactionsProvider,
+	}, nil
+}
+
+// ConvertBlock converts the provided header, along with its body and its optional proof, into an api block.
+// The body is needed only when the transactions are requested. For hyperblocks, only the miniBlocks fully
+// executed in the header's shard are kept
+func (abc *apiBlockConverter) ConvertBlock(
+	header data.HeaderHandler,
+	body *block.Body,
+	proof data.HeaderProofHandler,
+	options api.BlockQueryOptions,
+) (*api.Block, error) {
+	if check.IfNil(header) {
+		return nil, ErrNilHeaderHandler
+	}
+
+	headerHash, err := core.CalculateHash(abc.marshaller, abc.hasher, header)
+	if err != nil {
+		return nil, err
+	}
+
+	apiBlock := &api.Block{
+		Nonce:           header.GetNonce(),
+		Round:           header.GetRound(),
+		Epoch:           header.GetEpoch(),
+		Shard:           header.GetShardID(),
+		NumTxs:          header.GetTxCount(),
+		Hash:            hex.EncodeToString(headerHash),
+		PrevBlockHash:   hex.EncodeToString(header.GetPrevHash()),
+		StateRootHash:   hex.EncodeToString(header.GetRootHash()),
+		AccumulatedFees: bigIntToString(header.GetAccumulatedFees()),
+		DeveloperFees:   bigIntToString(header.GetDeveloperFees()),
+		RandSeed:        hex.EncodeToString(header.GetRandSeed()),
+		PrevRandSeed:    hex.EncodeToString(header.GetPrevRandSeed()),
+		PubKeyBitmap:    hex.EncodeToString(header.GetPubKeysBitmap()),
+		Signature:       hex.EncodeToString(header.GetSignature()),
+		LeaderSignature: hex.EncodeToString(header.GetLeaderSignature()),
+		ChainID:         string(header.GetChainID()),
+		SoftwareVersion: hex.EncodeToString(header.GetSoftwareVersion()),
+		ReceiptsHash:    hex.EncodeToString(header.GetReceiptsHash()),
+		Reserved:        header.GetReserved(),
+		Timestamp:       int64(header.GetTimeStamp()),
+		TimestampMs:     int64(header.GetTimeStamp()) * 1000,
+		ScheduledData:   getScheduledData(header),
+		Proof:           getAPIHeaderProof(proof),
+	}
+
+	apiBlock.MiniBlocks, err = abc.convertMiniBlocks(header, headerHash, body, options)
+	if err != nil {
+		return nil, err
+	}
+
+	metaHeader, isMetaBlock := header.(data.MetaHeaderHandler)
+	if !isMetaBlock {
+		return apiBlock, nil
+	}
+
+	feesInEpochHandler, ok := metaHeader.(interface{ GetAccumulatedFeesInEpoch() *big.Int })
+	if ok {
+		apiBlock.AccumulatedFeesInEpoch = bigIntToString(feesInEpochHandler.GetAccumulatedFeesInEpoch())
+	}
+	apiBlock.DeveloperFeesInEpoch = bigIntToString(metaHeader.GetDevFeesInEpoch())
+	apiBlock.NotarizedBlocks = getNotarizedBlocks(metaHeader)
+	if metaHeader.IsStartOfEpochBlock() {
+		apiBlock.EpochStartInfo, apiBlock.EpochStartShardsData, err = ConvertEpochStart(metaHeader)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return apiBlock, nil
+}
+
+func (abc *apiBlockConverter) convertMiniBlocks(
+	header data.HeaderHandler,
+	headerHash []byte,
+	body *block.Body,
+	options api.BlockQueryOptions,
+) ([]*api.MiniBlock, error) {
+	miniBlocksByHash, err := abc.getMiniBlocksByHash(body, options)
+	if err != nil {
+		return nil, err
+	}
+
+	apiMiniBlocks := make([]*api.MiniBlock, 0, len(header.GetMiniBlockHeaderHandlers()))
+	for _, mbHeader := range header.GetMiniBlockHeaderHandlers() {
+		if options.ForHyperblock && !isExecutedInShard(mbHeader.GetReceiverShardID(), header.GetShardID()) {
+			continue
+		}
+
+		apiMiniBlock := &api.MiniBlock{
+			Hash:                    hex.EncodeToString(mbHeader.GetHash()),
+			Type:                    block.Type(mbHeader.GetTypeInt32()).String(),
+			ProcessingType:          block.ProcessingType(mbHeader.GetProcessingType()).String(),
+			ConstructionState:       block.MiniBlockState(mbHeader.GetConstructionState()).String(),
+			SourceShard:             mbHeader.GetSenderShardID(),
+			DestinationShard:        mbHeader.GetReceiverShardID(),
+			IndexOfFirstTxProcessed: mbHeader.GetIndexOfFirstTxProcessed(),
+			IndexOfLastTxProcessed:  mbHeader.GetIndexOfLastTxProcessed(),
+		}
+
+		if options.WithTransactions {
+			miniBlock, found := miniBlocksByHash[string(mbHeader.GetHash())]
+			if !found {
+				return nil, fmt.Errorf("%w, hash %s", ErrMiniBlockNotFoundInBody, apiMiniBlock.Hash)
+			}
+
+			apiMiniBlock.Transactions, err = abc.convertTransactions(header, headerHash, mbHeader, miniBlock)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		apiMiniBlocks = append(apiMiniBlocks, apiMiniBlock)
+	}
+
+	return apiMiniBlocks, nil
+}
+
+func (abc *apiBlockConverter) getMiniBlocksByHash(body *block.Body, options api.BlockQueryOptions) (map[string]*block.MiniBlock, error) {
+	miniBlocksByHash := make(map[string]*block.MiniBlock)
+	if !options.WithTransactions {
+		return miniBlocksByHash, nil
+	}
+	if check.IfNil(abc.transactionsProvider) {
+		return nil, ErrNilTransactionsProvider
+	}
+	if body == nil {
+		return nil, block.ErrNilBody
+	}
+
+	for _, miniBlock := range body.MiniBlocks {
+		if miniBlock == nil {
+			continue
+		}
+
+		mbHash, err := core.CalculateHash(abc.marshaller, abc.hasher, miniBlock)
+		if err != nil {
+			return nil, err
+		}
+		miniBlocksByHash[string(mbHash)] = miniBlock
+	}
+
+	return miniBlocksByHash, nil
+}
+
+func (abc *apiBlockConverter) convertTransactions(
+	header data.HeaderHandler,
+	headerHash []byte,
+	mbHeader data.MiniBlockHeaderHandler,
+	miniBlock *block.MiniBlock,
+) ([]*transaction.ApiTransactionResult, error) {
+	firstIndex := int(mbHeader.GetIndexOfFirstTxProcessed())
+	lastIndex := int(mbHeader.GetIndexOfLastTxProcessed())
+	if firstIndex < 0 || lastIndex >= len(miniBlock.TxHashes) {
+		return nil, fmt.Errorf("%w, processed txs range [%d, %d] for %d txs",
+			block.ErrInvalidMiniBlockHeader, firstIndex, lastIndex, len(miniBlock.TxHashes))
+	}
+
+	txs := make([]*transaction.ApiTransactionResult, 0, lastIndex-firstIndex+1)
+	for i := firstIndex; i <= lastIndex; i++ {
+		txHash := miniBlock.TxHashes[i]
+		txHandler, err := abc.transactionsProvider.GetTransaction(txHash, miniBlock.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		apiTx, err := abc.txConverter.ConvertTransaction(txHandler, txHash)
+		if err != nil {
+			return nil, err
+		}
+
+		apiTx.Type = string(getTxTypeFromMiniBlockType(miniBlock.Type))
+		apiTx.Epoch = header.GetEpoch()
+		apiTx.Round = header.GetRound()
+		apiTx.BlockNonce = header.GetNonce()
+		apiTx.BlockHash = hex.EncodeToString(headerHash)
+		apiTx.MiniBlockType = miniBlock.Type.String()
+		apiTx.MiniBlockHash = hex.EncodeToString(mbHeader.GetHash())
+		apiTx.SourceShard = miniBlock.SenderShardID
+		apiTx.DestinationShard = miniBlock.ReceiverShardID
+		apiTx.Timestamp = int64(header.GetTimeStamp())
+		apiTx.TimestampMs = int64(header.GetTimeStamp()) * 1000
+		apiTx.Status = ResolveTransactionStatus(apiTx, TransactionStatusInfo{
+			IsExecutedOnDestination: isExecutedInShard(miniBlock.ReceiverShardID, header.GetShardID()),
+		})
+
+		txs = append(txs, apiTx)
+	}
+
+	return txs, nil
+}
+
+// ConvertHyperblock builds the hyperblock of the provided api meta block, collecting the transactions of the meta
+// block and of the provided notarized shard blocks, which should have been converted with the ForHyperblock option
+func (abc *apiBlockConverter) ConvertHyperblock(metaBlock *api.Block, notarizedShardBlocks []*api.Block) (*api.Hyperblock, error) {
+	if metaBlock == nil {
+		return nil, ErrNilAPIBlock
+	}
+	if metaBlock.Shard != core.MetachainShardId {
+		return nil, ErrNotMetaBlock
+	}
+
+	notarizedHashes := make(map[string]struct{}, len(metaBlock.NotarizedBlocks))
+	for _, notarizedBlock := range metaBlock.NotarizedBlocks {
+		notarizedHashes[notarizedBlock.Hash] = struct{}{}
+	}
+
+	transactions := getTransactionsFromMiniBlocks(metaBlock.MiniBlocks)
+	for _, shardBlock := range notarizedShardBlocks {
+		if shardBlock == nil {
+			return nil, ErrNilAPIBlock
+		}
+		_, isNotarized := notarizedHashes[shardBlock.Hash]
+		if !isNotarized {
+			return nil, fmt.Errorf("%w, hash %s", ErrShardBlockNotNotarized, shardBlock.Hash)
+		}
+
+		transactions = append(transactions, getTransactionsFromMiniBlocks(shardBlock.MiniBlocks)...)
+	}
+
+	for _, tx := range transactions {
+		tx.HyperblockNonce = metaBlock.Nonce
+		tx.HyperblockHash = metaBlock.Hash
+	}
+
+	return &api.Hyperblock{
+		Hash:                   metaBlock.Hash,
+		PrevBlockHash:          metaBlock.PrevBlockHash,
+		StateRootHash:          metaBlock.StateRootHash,
+		Nonce:                  metaBlock.Nonce,
+		Round:                  metaBlock.Round,
+		Epoch:                  metaBlock.Epoch,
+		NumTxs:                 uint32(len(transactions)),
+		AccumulatedFees:        metaBlock.AccumulatedFees,
+		DeveloperFees:          metaBlock.DeveloperFees,
+		AccumulatedFeesInEpoch: metaBlock.AccumulatedFeesInEpoch,
+		DeveloperFeesInEpoch:   metaBlock.DeveloperFeesInEpoch,
+		Timestamp:              metaBlock.Timestamp,
+		TimestampMs:            metaBlock.TimestampMs,
+		EpochStartInfo:         metaBlock.EpochStartInfo,
+		EpochStartShardsData:   metaBlock.EpochStartShardsData,
+		ShardBlocks:            metaBlock.NotarizedBlocks,
+		Transactions:           transactions,
+		Status:                 metaBlock.Status,
+	}, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (abc *apiBlockConverter) IsInterfaceNil() bool {
+	return abc == nil
+}
+
+func isExecutedInShard(receiverShardID uint32, shardID uint32) bool {
+	return receiverShardID == shardID || receiverShardID == core.AllShardId
+}
+
+func getTransactionsFromMiniBlocks(miniBlocks []*api.MiniBlock) []*transaction.ApiTransactionResult {
+	transactions := make([]*transaction.ApiTransactionResult, 0)
+	for _, miniBlock := range miniBlocks {
+		transactions = append(transactions, miniBlock.Transactions...)
+	}
+
+	return transactions
+}
+
+func getNotarizedBlocks(metaHeader data.MetaHeaderHandler) []*api.NotarizedBlock {
+	notarizedBlocks := make([]*api.NotarizedBlock, 0, len(metaHeader.GetShardInfoHandlers()))
+	for _, shardData := range metaHeader.GetShardInfoHandlers() {
+		miniBlockHashes := make([]string, 0, len(shardData.GetShardMiniBlockHeaderHandlers()))
+		for _, mbHeader := range shardData.GetShardMiniBlockHeaderHandlers() {
+			miniBlockHashes = append(miniBlockHashes, hex.EncodeToString(mbHeader.GetHash()))
+		}
+
+		notarizedBlocks = append(notarizedBlocks, &api.NotarizedBlock{
+			Hash:            hex.EncodeToString(shardData.GetHeaderHash()),
+			Nonce:           shardData.GetNonce(),
+			Round:           shardData.GetRound(),
+			Shard:           shardData.GetShardID(),
+			MiniBlockHashes: miniBlockHashes,
+		})
+	}
+
+	return notarizedBlocks
+}
+
+func getScheduledData(header data.HeaderHandler) *api.ScheduledData {
+	if !header.HasScheduledSupport() {
+		return nil
+	}
+
+	additionalData := header.GetAdditionalData()
+	if check.IfNil(additionalData) {
+		return nil
+	}
+
+	return &api.ScheduledData{
+		ScheduledRootHash:        hex.EncodeToString(additionalData.GetScheduledRootHash()),
+		ScheduledAccumulatedFees: bigIntToString(additionalData.GetScheduledAccumulatedFees()),
+		ScheduledDeveloperFees:   bigIntToString(additionalData.GetScheduledDeveloperFees()),
+		ScheduledGasProvided:     additionalData.GetScheduledGasProvided(),
+		ScheduledGasPenalized:    additionalData.GetScheduledGasPenalized(),
+		ScheduledGasRefunded:     additionalData.GetScheduledGasRefunded(),
+	}
+}
+
+func getAPIHeaderProof(proof data.HeaderProofHandler) *api.HeaderProof {
+	if check.IfNil(proof) {
+		return nil
+	}
+
+	return &api.HeaderProof{
+		PubKeysBitmap:       hex.EncodeToString(proof.GetPubKeysBitmap()),
+		AggregatedSignature: hex.EncodeToString(proof.GetAggregatedSignature()),
+		HeaderHash:          hex.EncodeToString(proof.GetHeaderHash()),
+		HeaderEpoch:         proof.GetHeaderEpoch(),
+		HeaderNonce:         proof.GetHeaderNonce(),
+		HeaderShardId:       proof.GetHeaderShardId(),
+		HeaderRound:         proof.GetHeaderRound(),
+		IsStartOfEpoch:      proof.GetIsStartOfEpoch(),
+	}
+}
+
+func getTxTypeFromMiniBlockType(miniBlockType block.Type) transaction.TxType {
+	switch miniBlockType {
+	case block.SmartContractResultBlock:
+		return transaction.TxTypeUnsigned
+	case block.RewardsBlock:
+		return transaction.TxTypeReward
+	case block.InvalidBlock:
+		return transaction.TxTypeInvalid
+	default:
+		return transaction.TxTypeNormal
+	}
+}
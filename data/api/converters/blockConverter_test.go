@@ -0,0 +1,399 @@
+package converters
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data"
+	"github.com/multiversx/mx-chain-core-go/data/api"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-core-go/data/mock"
+	"github.com/multiversx/mx-chain-core-go/data/smartContractResult"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-core-go/marshal/factory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type transactionsProviderStub struct {
+	GetTransactionCalled func(txHash []byte, miniBlockType block.Type) (data.TransactionHandler, error)
+}
+
+func (tps *transactionsProviderStub) GetTransaction(txHash []byte, miniBlockType block.Type) (data.TransactionHandler, error) {
+	if tps.GetTransactionCalled != nil {
+		return tps.GetTransactionCalled(txHash, miniBlockType)
+	}
+
+	return &transaction.Transaction{}, nil
+}
+
+func (tps *transactionsProviderStub) IsInterfaceNil() bool {
+	return tps == nil
+}
+
+func createMockArgsAPIBlockConverter() ArgsAPIBlockConverter {
+	marshaller, _ := factory.NewMarshalizer(factory.GogoProtobuf)
+
+	return ArgsAPIBlockConverter{
+		PubkeyConverter: &mock.PubkeyConverterStub{
+			EncodeCalled: func(pkBytes []byte) (string, error) {
+				return "erd_" + string(pkBytes), nil
+			},
+		},
+		Marshaller: marshaller,
+		Hasher:     &mock.HasherMock{},
+		TransactionsProvider: &transactionsProviderStub{
+			GetTransactionCalled: func(txHash []byte, miniBlockType block.Type) (data.TransactionHandler, error) {
+				if miniBlockType == block.SmartContractResultBlock {
+					return &smartContractResult.SmartContractResult{
+						Nonce:   2,
+						Value:   big.NewInt(5),
+						RcvAddr: []byte("receiver"),
+						SndAddr: []byte("sender"),
+					}, nil
+				}
+
+				return &transaction.Transaction{
+					Nonce:    1,
+					Value:    big.NewInt(10),
+					RcvAddr:  []byte("receiver"),
+					SndAddr:  []byte("sender"),
+					GasPrice: 1000,
+					GasLimit: 50000,
+					Data:     txHash,
+				}, nil
+			},
+		},
+	}
+}
+
+func createShardHeaderAndBody(t *testing.T, args ArgsAPIBlockConverter) (*block.HeaderV2, *block.Body) {
+	body := &block.Body{
+		MiniBlocks: []*block.MiniBlock{
+			{TxHashes: [][]byte{[]byte("tx1"), []byte("tx2")}, SenderShardID: 1, ReceiverShardID: 1, Type: block.TxBlock},
+			{TxHashes: [][]byte{[]byte("tx3")}, SenderShardID: 1, ReceiverShardID: 0, Type: block.TxBlock},
+			{TxHashes: [][]byte{[]byte("scr1"), []byte("scr2")}, SenderShardID: 0, ReceiverShardID: 1, Type: block.SmartContractResultBlock},
+		},
+	}
+
+	header := &block.HeaderV2{
+		Header: &block.Header{
+			Nonce:           10,
+			Round:           12,
+			Epoch:           2,
+			ShardID:         1,
+			TimeStamp:       1000,
+			PrevHash:        []byte{0x01},
+			RootHash:        []byte{0x02},
+			RandSeed:        []byte{0x03},
+			PrevRandSeed:    []byte{0x04},
+			ChainID:         []byte("chain"),
+			SoftwareVersion: []byte{0x05},
+			AccumulatedFees: big.NewInt(100),
+			DeveloperFees:   big.NewInt(10),
+			TxCount:         5,
+		},
+		ScheduledRootHash:        []byte{0x06},
+		ScheduledAccumulatedFees: big.NewInt(7),
+		ScheduledDeveloperFees:   big.NewInt(1),
+		ScheduledGasProvided:     3,
+		ScheduledGasPenalized:    2,
+		ScheduledGasRefunded:     1,
+	}
+
+	for _, miniBlock := range body.MiniBlocks {
+		mbHash, err := core.CalculateHash(args.Marshaller, args.Hasher, miniBlock)
+		require.Nil(t, err)
+
+		mbHeader := block.MiniBlockHeader{
+			Hash:            mbHash,
+			SenderShardID:   miniBlock.SenderShardID,
+			ReceiverShardID: miniBlock.ReceiverShardID,
+			TxCount:         uint32(len(miniBlock.TxHashes)),
+			Type:            miniBlock.Type,
+		}
+		_ = mbHeader.SetConstructionState(int32(block.Final))
+		_ = mbHeader.SetIndexOfLastTxProcessed(int32(len(miniBlock.TxHashes) - 1))
+		header.Header.MiniBlockHeaders = append(header.Header.MiniBlockHeaders, mbHeader)
+	}
+
+	return header, body
+}
+
+func TestNewAPIBlockConverter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil pubkey converter should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsAPIBlockConverter()
+		args.PubkeyConverter = nil
+		converter, err := NewAPIBlockConverter(args)
+		assert.Equal(t, ErrNilPubkeyConverter, err)
+		assert.True(t, check.IfNil(converter))
+	})
+	t.Run("nil marshaller should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsAPIBlockConverter()
+		args.Marshaller = nil
+		converter, err := NewAPIBlockConverter(args)
+		assert.Equal(t, ErrNilMarshaller, err)
+		assert.True(t, check.IfNil(converter))
+	})
+	t.Run("nil hasher should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsAPIBlockConverter()
+		args.Hasher = nil
+		converter, err := NewAPIBlockConverter(args)
+		assert.Equal(t, ErrNilHasher, err)
+		assert.True(t, check.IfNil(converter))
+	})
+	t.Run("nil transactions provider should work", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsAPIBlockConverter()
+		args.TransactionsProvider = nil
+		converter, err := NewAPIBlockConverter(args)
+		assert.Nil(t, err)
+		assert.False(t, check.IfNil(converter))
+	})
+}
+
+func TestApiBlockConverter_ConvertBlock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil header should error", func(t *testing.T) {
+		t.Parallel()
+
+		converter, _ := NewAPIBlockConverter(createMockArgsAPIBlockConverter())
+		apiBlock, err := converter.ConvertBlock(nil, nil, nil, api.BlockQueryOptions{})
+		assert.Nil(t, apiBlock)
+		assert.Equal(t, ErrNilHeaderHandler, err)
+	})
+	t.Run("transactions requested without provider or body should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsAPIBlockConverter()
+		header, body := createShardHeaderAndBody(t, args)
+		options := api.BlockQueryOptions{WithTransactions: true}
+
+		converter, _ := NewAPIBlockConverter(args)
+		_, err := converter.ConvertBlock(header, nil, nil, options)
+		assert.Equal(t, block.ErrNilBody, err)
+
+		args.TransactionsProvider = nil
+		converter, _ = NewAPIBlockConverter(args)
+		_, err = converter.ConvertBlock(header, body, nil, options)
+		assert.Equal(t, ErrNilTransactionsProvider, err)
+	})
+	t.Run("miniBlock missing from body should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsAPIBlockConverter()
+		header, body := createShardHeaderAndBody(t, args)
+		body.MiniBlocks = body.MiniBlocks[:2]
+
+		converter, _ := NewAPIBlockConverter(args)
+		_, err := converter.ConvertBlock(header, body, nil, api.BlockQueryOptions{WithTransactions: true})
+		assert.True(t, errors.Is(err, ErrMiniBlockNotFoundInBody))
+	})
+	t.Run("transactions provider error should be returned", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		args := createMockArgsAPIBlockConverter()
+		args.TransactionsProvider = &transactionsProviderStub{
+			GetTransactionCalled: func(txHash []byte, miniBlockType block.Type) (data.TransactionHandler, error) {
+				return nil, expectedErr
+			},
+		}
+		header, body := createShardHeaderAndBody(t, args)
+
+		converter, _ := NewAPIBlockConverter(args)
+		_, err := converter.ConvertBlock(header, body, nil, api.BlockQueryOptions{WithTransactions: true})
+		assert.Equal(t, expectedErr, err)
+	})
+	t.Run("shard header v2 should work", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsAPIBlockConverter()
+		header, body := createShardHeaderAndBody(t, args)
+		headerHash, _ := core.CalculateHash(args.Marshaller, args.Hasher, header)
+		proof := &block.HeaderProof{
+			PubKeysBitmap:       []byte{0x07},
+			AggregatedSignature: []byte{0x08},
+			HeaderHash:          headerHash,
+			HeaderEpoch:         2,
+			HeaderNonce:         10,
+			HeaderShardId:       1,
+			HeaderRound:         12,
+		}
+
+		converter, _ := NewAPIBlockConverter(args)
+		apiBlock, err := converter.ConvertBlock(header, body, proof, api.BlockQueryOptions{WithTransactions: true})
+		require.Nil(t, err)
+
+		assert.Equal(t, uint64(10), apiBlock.Nonce)
+		assert.Equal(t, uint32(1), apiBlock.Shard)
+		assert.Equal(t, uint32(5), apiBlock.NumTxs)
+		assert.Equal(t, hex.EncodeToString(headerHash), apiBlock.Hash)
+		assert.Equal(t, "01", apiBlock.PrevBlockHash)
+		assert.Equal(t, "02", apiBlock.StateRootHash)
+		assert.Equal(t, "chain", apiBlock.ChainID)
+		assert.Equal(t, "100", apiBlock.AccumulatedFees)
+		assert.Equal(t, "10", apiBlock.DeveloperFees)
+		assert.Equal(t, int64(1000000), apiBlock.TimestampMs)
+		assert.Empty(t, apiBlock.NotarizedBlocks)
+		assert.Equal(t, &api.ScheduledData{
+			ScheduledRootHash:        "06",
+			ScheduledAccumulatedFees: "7",
+			ScheduledDeveloperFees:   "1",
+			ScheduledGasProvided:     3,
+			ScheduledGasPenalized:    2,
+			ScheduledGasRefunded:     1,
+		}, apiBlock.ScheduledData)
+		assert.Equal(t, &api.HeaderProof{
+			PubKeysBitmap:       "07",
+			AggregatedSignature: "08",
+			HeaderHash:          hex.EncodeToString(headerHash),
+			HeaderEpoch:         2,
+			HeaderNonce:         10,
+			HeaderShardId:       1,
+			HeaderRound:         12,
+		}, apiBlock.Proof)
+
+		require.Equal(t, 3, len(apiBlock.MiniBlocks))
+		assert.Equal(t, block.TxBlock.String(), apiBlock.MiniBlocks[0].Type)
+		assert.Equal(t, block.Final.String(), apiBlock.MiniBlocks[0].ConstructionState)
+		assert.Equal(t, int32(1), apiBlock.MiniBlocks[0].IndexOfLastTxProcessed)
+		require.Equal(t, 2, len(apiBlock.MiniBlocks[0].Transactions))
+
+		tx := apiBlock.MiniBlocks[0].Transactions[1]
+		assert.Equal(t, hex.EncodeToString([]byte("tx2")), tx.Hash)
+		assert.Equal(t, string(transaction.TxTypeNormal), tx.Type)
+		assert.Equal(t, "erd_sender", tx.Sender)
+		assert.Equal(t, "erd_receiver", tx.Receiver)
+		assert.Equal(t, "10", tx.Value)
+		assert.Equal(t, uint64(50000), tx.GasLimit)
+		assert.Equal(t, []byte("tx2"), tx.Data)
+		assert.Equal(t, uint64(10), tx.BlockNonce)
+		assert.Equal(t, apiBlock.Hash, tx.BlockHash)
+		assert.Equal(t, apiBlock.MiniBlocks[0].Hash, tx.MiniBlockHash)
+		assert.Equal(t, uint32(1), tx.SourceShard)
+		assert.Equal(t, transaction.TxStatusSuccess, tx.Status)
+		assert.Equal(t, transaction.TxStatusPending, apiBlock.MiniBlocks[1].Transactions[0].Status)
+
+		scr := apiBlock.MiniBlocks[2].Transactions[0]
+		assert.Equal(t, string(transaction.TxTypeUnsigned), scr.Type)
+		assert.Equal(t, uint32(0), scr.SourceShard)
+		assert.Equal(t, uint32(1), scr.DestinationShard)
+	})
+	t.Run("hyperblock option should keep only the miniBlocks executed in shard", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsAPIBlockConverter()
+		header, body := createShardHeaderAndBody(t, args)
+
+		converter, _ := NewAPIBlockConverter(args)
+		apiBlock, err := converter.ConvertBlock(header, body, nil, api.BlockQueryOptions{WithTransactions: true, ForHyperblock: true})
+		require.Nil(t, err)
+		require.Equal(t, 2, len(apiBlock.MiniBlocks))
+		assert.Equal(t, uint32(1), apiBlock.MiniBlocks[0].DestinationShard)
+		assert.Equal(t, uint32(1), apiBlock.MiniBlocks[1].DestinationShard)
+		assert.Nil(t, apiBlock.Proof)
+	})
+	t.Run("partially executed miniBlock should convert only the processed transactions", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsAPIBlockConverter()
+		header, body := createShardHeaderAndBody(t, args)
+		_ = header.Header.MiniBlockHeaders[2].SetConstructionState(int32(block.PartialExecuted))
+		_ = header.Header.MiniBlockHeaders[2].SetIndexOfLastTxProcessed(0)
+
+		converter, _ := NewAPIBlockConverter(args)
+		apiBlock, err := converter.ConvertBlock(header, body, nil, api.BlockQueryOptions{WithTransactions: true})
+		require.Nil(t, err)
+		require.Equal(t, 1, len(apiBlock.MiniBlocks[2].Transactions))
+		assert.Equal(t, hex.EncodeToString([]byte("scr1")), apiBlock.MiniBlocks[2].Transactions[0].Hash)
+	})
+	t.Run("epoch start meta block should work", func(t *testing.T) {
+		t.Parallel()
+
+		metaBlock := createEpochStartMetaBlock()
+		metaBlock.AccumulatedFees = big.NewInt(4)
+		metaBlock.AccumulatedFeesInEpoch = big.NewInt(40)
+		metaBlock.DevFeesInEpoch = big.NewInt(20)
+		metaBlock.ShardInfo = []block.ShardData{
+			{
+				HeaderHash:            []byte{0x0A},
+				ShardID:               0,
+				Nonce:                 101,
+				Round:                 111,
+				ShardMiniBlockHeaders: []block.MiniBlockHeader{{Hash: []byte{0x0B}}},
+			},
+		}
+
+		converter, _ := NewAPIBlockConverter(createMockArgsAPIBlockConverter())
+		apiBlock, err := converter.ConvertBlock(metaBlock, nil, nil, api.BlockQueryOptions{})
+		require.Nil(t, err)
+		assert.Equal(t, "4", apiBlock.AccumulatedFees)
+		assert.Equal(t, "0", apiBlock.DeveloperFees)
+		assert.Equal(t, "40", apiBlock.AccumulatedFeesInEpoch)
+		assert.Equal(t, "20", apiBlock.DeveloperFeesInEpoch)
+		assert.Nil(t, apiBlock.ScheduledData)
+		assert.Equal(t, []*api.NotarizedBlock{
+			{Hash: "0a", Nonce: 101, Round: 111, Shard: 0, MiniBlockHashes: []string{"0b"}},
+		}, apiBlock.NotarizedBlocks)
+		require.NotNil(t, apiBlock.EpochStartInfo)
+		assert.Equal(t, "1000", apiBlock.EpochStartInfo.TotalSupply)
+		require.Equal(t, 1, len(apiBlock.EpochStartShardsData))
+	})
+}
+
+func TestApiBlockConverter_ConvertHyperblock(t *testing.T) {
+	t.Parallel()
+
+	converter, _ := NewAPIBlockConverter(createMockArgsAPIBlockConverter())
+
+	hyperblock, err := converter.ConvertHyperblock(nil, nil)
+	assert.Nil(t, hyperblock)
+	assert.Equal(t, ErrNilAPIBlock, err)
+
+	_, err = converter.ConvertHyperblock(&api.Block{Shard: 0}, nil)
+	assert.Equal(t, ErrNotMetaBlock, err)
+
+	metaTx := &transaction.ApiTransactionResult{Hash: "meta tx"}
+	shardTx := &transaction.ApiTransactionResult{Hash: "shard tx"}
+	metaBlock := &api.Block{
+		Nonce:           7,
+		Hash:            "meta hash",
+		Shard:           core.MetachainShardId,
+		AccumulatedFees: "5",
+		NotarizedBlocks: []*api.NotarizedBlock{{Hash: "shard hash", Shard: 0}},
+		MiniBlocks:      []*api.MiniBlock{{Transactions: []*transaction.ApiTransactionResult{metaTx}}},
+	}
+	shardBlock := &api.Block{
+		Hash:       "shard hash",
+		MiniBlocks: []*api.MiniBlock{{Transactions: []*transaction.ApiTransactionResult{shardTx}}},
+	}
+
+	_, err = converter.ConvertHyperblock(metaBlock, []*api.Block{{Hash: "unknown"}})
+	assert.True(t, errors.Is(err, ErrShardBlockNotNotarized))
+
+	hyperblock, err = converter.ConvertHyperblock(metaBlock, []*api.Block{shardBlock})
+	require.Nil(t, err)
+	assert.Equal(t, "meta hash", hyperblock.Hash)
+	assert.Equal(t, uint64(7), hyperblock.Nonce)
+	assert.Equal(t, "5", hyperblock.AccumulatedFees)
+	assert.Equal(t, uint32(2), hyperblock.NumTxs)
+	assert.Equal(t, metaBlock.NotarizedBlocks, hyperblock.ShardBlocks)
+	assert.Equal(t, []*transaction.ApiTransactionResult{metaTx, shardTx}, hyperblock.Transactions)
+	assert.Equal(t, uint64(7), shardTx.HyperblockNonce)
+	assert.Equal(t, "meta hash", shardTx.HyperblockHash)
+}
@@ -0,0 +1,83 @@
+package converters
+
+import (
+	"encoding/hex"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/data"
+	"github.com/multiversx/mx-chain-core-go/data/api"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+)
+
+// ConvertEpochStart extracts the epoch start data from the provided start of epoch meta block and converts it into
+// the api epoch start info and shards data
+func ConvertEpochStart(metaHeader data.MetaHeaderHandler) (*api.EpochStartInfo, []*api.EpochStartShardData, error) {
+	summary, err := block.NewEpochStartSummary(metaHeader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ToAPIEpochStartInfo(summary), ToAPIEpochStartShardsData(summary), nil
+}
+
+// ToAPIEpochStartInfo converts the summary economics into the api epoch start info
+func ToAPIEpochStartInfo(summary *block.EpochStartSummary) *api.EpochStartInfo {
+	if summary == nil || summary.Economics == nil {
+		return nil
+	}
+
+	economics := summary.Economics
+	return &api.EpochStartInfo{
+		TotalSupply:                      bigIntToString(economics.TotalSupply),
+		TotalToDistribute:                bigIntToString(economics.TotalToDistribute),
+		TotalNewlyMinted:                 bigIntToString(economics.TotalNewlyMinted),
+		RewardsPerBlock:                  bigIntToString(economics.RewardsPerBlock),
+		RewardsForProtocolSustainability: bigIntToString(economics.RewardsForProtocolSustainability),
+		NodePrice:                        bigIntToString(economics.NodePrice),
+		PrevEpochStartRound:              economics.PrevEpochStartRound,
+		PrevEpochStartHash:               hex.EncodeToString(economics.PrevEpochStartHash),
+	}
+}
+
+// ToAPIEpochStartShardsData converts the summary last finalized headers into the api epoch start shards data
+func ToAPIEpochStartShardsData(summary *block.EpochStartSummary) []*api.EpochStartShardData {
+	if summary == nil {
+		return nil
+	}
+
+	shardsData := make([]*api.EpochStartShardData, 0, len(summary.LastFinalizedHeaders))
+	for _, shardData := range summary.LastFinalizedHeaders {
+		pendingMiniBlocks := make([]*api.MiniBlock, 0, len(shardData.PendingMiniBlockHeaders))
+		for _, pendingMb := range shardData.PendingMiniBlockHeaders {
+			pendingMiniBlocks = append(pendingMiniBlocks, &api.MiniBlock{
+				Hash:             hex.EncodeToString(pendingMb.Hash),
+				Type:             pendingMb.Type.String(),
+				SourceShard:      pendingMb.SenderShardID,
+				DestinationShard: pendingMb.ReceiverShardID,
+			})
+		}
+
+		shardsData = append(shardsData, &api.EpochStartShardData{
+			ShardID:                 shardData.ShardID,
+			Epoch:                   shardData.Epoch,
+			Round:                   shardData.Round,
+			Nonce:                   shardData.Nonce,
+			HeaderHash:              hex.EncodeToString(shardData.HeaderHash),
+			RootHash:                hex.EncodeToString(shardData.RootHash),
+			ScheduledRootHash:       hex.EncodeToString(shardData.ScheduledRootHash),
+			FirstPendingMetaBlock:   hex.EncodeToString(shardData.FirstPendingMetaBlock),
+			LastFinishedMetaBlock:   hex.EncodeToString(shardData.LastFinishedMetaBlock),
+			PendingMiniBlockHeaders: pendingMiniBlocks,
+		})
+	}
+
+	return shardsData
+}
+
+func bigIntToString(value *big.Int) string {
+	if value == nil {
+		return "0"
+	}
+
+	return value.String()
+}
@@ -0,0 +1,90 @@
+package converters
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/api"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createEpochStartMetaBlock() *block.MetaBlock {
+	return &block.MetaBlock{
+		Epoch: 3,
+		EpochStart: block.EpochStart{
+			LastFinalizedHeaders: []block.EpochStartShardData{
+				{
+					ShardID:               0,
+					Epoch:                 2,
+					Nonce:                 100,
+					Round:                 110,
+					HeaderHash:            []byte{0x01},
+					RootHash:              []byte{0x02},
+					ScheduledRootHash:     []byte{0x03},
+					FirstPendingMetaBlock: []byte{0x04},
+					LastFinishedMetaBlock: []byte{0x05},
+					PendingMiniBlockHeaders: []block.MiniBlockHeader{
+						{Hash: []byte{0x06}, SenderShardID: 1, ReceiverShardID: 0, TxCount: 2, Type: block.SmartContractResultBlock},
+					},
+				},
+			},
+			Economics: block.Economics{
+				TotalSupply:                      big.NewInt(1000),
+				TotalToDistribute:                big.NewInt(100),
+				TotalNewlyMinted:                 big.NewInt(10),
+				RewardsPerBlock:                  big.NewInt(1),
+				RewardsForProtocolSustainability: big.NewInt(5),
+				PrevEpochStartRound:              50,
+				PrevEpochStartHash:               []byte{0x07},
+			},
+		},
+	}
+}
+
+func TestConvertEpochStart(t *testing.T) {
+	t.Parallel()
+
+	epochStartInfo, shardsData, err := ConvertEpochStart(&block.MetaBlock{})
+	assert.Nil(t, epochStartInfo)
+	assert.Nil(t, shardsData)
+	assert.Equal(t, block.ErrNotEpochStartBlock, err)
+
+	epochStartInfo, shardsData, err = ConvertEpochStart(createEpochStartMetaBlock())
+	require.Nil(t, err)
+	assert.Equal(t, &api.EpochStartInfo{
+		TotalSupply:                      "1000",
+		TotalToDistribute:                "100",
+		TotalNewlyMinted:                 "10",
+		RewardsPerBlock:                  "1",
+		RewardsForProtocolSustainability: "5",
+		NodePrice:                        "0",
+		PrevEpochStartRound:              50,
+		PrevEpochStartHash:               "07",
+	}, epochStartInfo)
+	assert.Equal(t, []*api.EpochStartShardData{
+		{
+			ShardID:               0,
+			Epoch:                 2,
+			Round:                 110,
+			Nonce:                 100,
+			HeaderHash:            "01",
+			RootHash:              "02",
+			ScheduledRootHash:     "03",
+			FirstPendingMetaBlock: "04",
+			LastFinishedMetaBlock: "05",
+			PendingMiniBlockHeaders: []*api.MiniBlock{
+				{Hash: "06", Type: "SmartContractResultBlock", SourceShard: 1, DestinationShard: 0},
+			},
+		},
+	}, shardsData)
+}
+
+func TestToAPIEpochStart_NilSummary(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, ToAPIEpochStartInfo(nil))
+	assert.Nil(t, ToAPIEpochStartInfo(&block.EpochStartSummary{}))
+	assert.Nil(t, ToAPIEpochStartShardsData(nil))
+}
@@ -0,0 +1,39 @@
+package converters
+
+import "errors"
+
+// ErrNilPubkeyConverter signals that a nil public key converter has been provided
+var ErrNilPubkeyConverter = errors.New("nil pub key converter")
+
+// ErrNilMarshaller signals that a nil marshaller has been provided
+var ErrNilMarshaller = errors.New("nil marshaller")
+
+// ErrNilHasher signals that a nil hasher has been provided
+var ErrNilHasher = errors.New("nil hasher")
+
+// ErrNilHeaderHandler signals that a nil header handler has been provided
+var ErrNilHeaderHandler = errors.New("nil header handler")
+
+// ErrNilTransactionsProvider signals that transactions were requested but no transactions provider was set
+var ErrNilTransactionsProvider = errors.New("nil transactions provider")
+
+// ErrMiniBlockNotFoundInBody signals that a miniBlock referenced by the header was not found in the body
+var ErrMiniBlockNotFoundInBody = errors.New("miniBlock not found in body")
+
+// ErrNilAPIBlock signals that a nil api block has been provided
+var ErrNilAPIBlock = errors.New("nil api block")
+
+// ErrNotMetaBlock signals that the api block is not a meta block
+var ErrNotMetaBlock = errors.New("not a meta block")
+
+// ErrShardBlockNotNotarized signals that the shard block is not notarized by the meta block
+var ErrShardBlockNotNotarized = errors.New("shard block not notarized by the meta block")
+
+// ErrNilTransactionHandler signals that a nil transaction handler has been provided
+var ErrNilTransactionHandler = errors.New("nil transaction handler")
+
+// ErrUnknownTransactionType signals that the transaction type can not be converted
+var ErrUnknownTransactionType = errors.New("unknown transaction type")
+
+// ErrInvalidAddress signals that an address could not be encoded
+var ErrInvalidAddress = errors.New("invalid address")
@@ -0,0 +1,12 @@
+package converters
+
+import (
+	"github.com/multiversx/mx-chain-core-go/data"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+)
+
+// TransactionsProvider defines the component able to provide the transactions of a miniBlock by their hashes
+type TransactionsProvider interface {
+	GetTransaction(txHash []byte, miniBlockType block.Type) (data.TransactionHandler, error)
+	IsInterfaceNil() bool
+}
@@ -0,0 +1,317 @@
+package converters
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data"
+	"github.com/multiversx/mx-chain-core-go/data/receipt"
+	"github.com/multiversx/mx-chain-core-go/data/rewardTx"
+	"github.com/multiversx/mx-chain-core-go/data/smartContractResult"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+)
+
+// SmartContractResultWithHash holds a smart contract result along with its hash and its optional log
+type SmartContractResultWithHash struct {
+	Hash                []byte
+	SmartContractResult *smartContractResult.SmartContractResult
+	Log                 *transaction.Log
+}
+
+// TransactionResults holds the execution results of a transaction, all of them being optional
+type TransactionResults struct {
+	Receipt              *receipt.Receipt
+	SmartContractResults []*SmartContractResultWithHash
+	Log                  *transaction.Log
+	StatusInfo           TransactionStatusInfo
+}
+
+// ArgsAPITransactionConverter holds the components needed to create a new api transaction converter
+type ArgsAPITransactionConverter struct {
+	PubkeyConverter core.PubkeyConverter
+}
+
+type apiTransactionConverter struct {
+	pubkeyConverter core.PubkeyConverter
+}
+
+// NewAPITransactionConverter creates a new converter from protocol transactions to api transaction results
+func NewAPITransactionConverter(args ArgsAPITransactionConverter) (*apiTransactionConverter, error) {
+	if check.IfNil(args.PubkeyConverter) {
+		return nil, ErrNilPubkeyConverter
+	}
+
+	return &apiTransactionConverter{
+		pubkeyConverter: args.PubkeyConverter,
+	}, nil
+}
+
+// ConvertTransaction converts the provided transaction, smart contract result or reward transaction into an api
+// transaction result. The status is not computed, as it depends on the execution results
+func (atc *apiTransactionConverter) ConvertTransaction(txHandler data.TransactionHandler, txHash []byte) (*transaction.ApiTransactionResult, error) {
+	if check.IfNil(txHandler) {
+		return nil, ErrNilTransactionHandler
+	}
+
+	var apiTx *transaction.ApiTransactionResult
+	var err error
+	switch tx := txHandler.(type) {
+	case *transaction.Transaction:
+		apiTx, err = atc.convertNormalTransaction(tx)
+	case *smartContractResult.SmartContractResult:
+		apiTx, err = atc.convertSmartContractResultToTransaction(tx)
+	case *rewardTx.RewardTx:
+		apiTx, err = atc.convertRewardTransaction(tx)
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnknownTransactionType, txHandler)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	apiTx.Tx = txHandler
+	apiTx.Hash = hex.EncodeToString(txHash)
+	apiTx.HashBytes = txHash
+
+	return apiTx, nil
+}
+
+// ConvertTransactionWithResults converts the provided transaction, attaches its execution results and resolves its status
+func (atc *apiTransactionConverter) ConvertTransactionWithResults(
+	txHandler data.TransactionHandler,
+	txHash []byte,
+	results *TransactionResults,
+) (*transaction.ApiTransactionResult, error) {
+	apiTx, err := atc.ConvertTransaction(txHandler, txHash)
+	if err != nil {
+		return nil, err
+	}
+	if results == nil {
+		results = &TransactionResults{}
+	}
+
+	apiTx.Receipt, err = atc.ConvertReceipt(results.Receipt)
+	if err != nil {
+		return nil, err
+	}
+	apiTx.Logs, err = atc.ConvertLog(results.Log)
+	if err != nil {
+		return nil, err
+	}
+	for _, scrWithHash := range results.SmartContractResults {
+		if scrWithHash == nil || scrWithHash.SmartContractResult == nil {
+			continue
+		}
+
+		apiSCR, errConvert := atc.ConvertSmartContractResult(scrWithHash.SmartContractResult, scrWithHash.Hash)
+		if errConvert != nil {
+			return nil, errConvert
+		}
+		apiSCR.Logs, errConvert = atc.ConvertLog(scrWithHash.Log)
+		if errConvert != nil {
+			return nil, errConvert
+		}
+		apiTx.SmartContractResults = append(apiTx.SmartContractResults, apiSCR)
+	}
+	apiTx.Status = ResolveTransactionStatus(apiTx, results.StatusInfo)
+
+	return apiTx, nil
+}
+
+func (atc *apiTransactionConverter) convertNormalTransaction(tx *transaction.Transaction) (*transaction.ApiTransactionResult, error) {
+	encoder := atc.newAddressEncoder()
+	apiTx := &transaction.ApiTransactionResult{
+		Type:              string(transaction.TxTypeNormal),
+		Nonce:             tx.Nonce,
+		Value:             bigIntToString(tx.Value),
+		Receiver:          encoder.encode(tx.RcvAddr),
+		Sender:            encoder.encode(tx.SndAddr),
+		SenderUsername:    tx.SndUserName,
+		ReceiverUsername:  tx.RcvUserName,
+		GasPrice:          tx.GasPrice,
+		GasLimit:          tx.GasLimit,
+		Data:              tx.Data,
+		Signature:         hex.EncodeToString(tx.Signature),
+		ChainID:           string(tx.ChainID),
+		Version:           tx.Version,
+		Options:           tx.Options,
+		GuardianAddr:      encoder.encode(tx.GuardianAddr),
+		GuardianSignature: hex.EncodeToString(tx.GuardianSignature),
+		RelayerAddress:    encoder.encode(tx.RelayerAddr),
+		RelayerSignature:  hex.EncodeToString(tx.RelayerSignature),
+		IsRelayed:         len(tx.RelayerAddr) > 0,
+	}
+	if encoder.err != nil {
+		return nil, encoder.err
+	}
+
+	return apiTx, nil
+}
+
+func (atc *apiTransactionConverter) convertSmartContractResultToTransaction(scr *smartContractResult.SmartContractResult) (*transaction.ApiTransactionResult, error) {
+	encoder := atc.newAddressEncoder()
+	apiTx := &transaction.ApiTransactionResult{
+		Type:                    string(transaction.TxTypeUnsigned),
+		Nonce:                   scr.Nonce,
+		Value:                   bigIntToString(scr.Value),
+		Receiver:                encoder.encode(scr.RcvAddr),
+		Sender:                  encoder.encode(scr.SndAddr),
+		GasPrice:                scr.GasPrice,
+		GasLimit:                scr.GasLimit,
+		Data:                    scr.Data,
+		Code:                    string(scr.Code),
+		CodeMetadata:            scr.CodeMetadata,
+		PreviousTransactionHash: hex.EncodeToString(scr.PrevTxHash),
+		OriginalTransactionHash: hex.EncodeToString(scr.OriginalTxHash),
+		ReturnMessage:           string(scr.ReturnMessage),
+		OriginalSender:          encoder.encode(scr.OriginalSender),
+		CallType:                scr.CallType.ToString(),
+		RelayerAddress:          encoder.encode(scr.RelayerAddr),
+	}
+	if encoder.err != nil {
+		return nil, encoder.err
+	}
+	if scr.RelayedValue != nil {
+		apiTx.RelayedValue = scr.RelayedValue.String()
+	}
+
+	return apiTx, nil
+}
+
+func (atc *apiTransactionConverter) convertRewardTransaction(tx *rewardTx.RewardTx) (*transaction.ApiTransactionResult, error) {
+	encoder := atc.newAddressEncoder()
+	apiTx := &transaction.ApiTransactionResult{
+		Type:        string(transaction.TxTypeReward),
+		Round:       tx.Round,
+		Epoch:       tx.Epoch,
+		Value:       bigIntToString(tx.Value),
+		Receiver:    encoder.encode(tx.RcvAddr),
+		Sender:      fmt.Sprintf("%d", core.MetachainShardId),
+		SourceShard: core.MetachainShardId,
+	}
+	if encoder.err != nil {
+		return nil, encoder.err
+	}
+
+	return apiTx, nil
+}
+
+// ConvertSmartContractResult converts the provided smart contract result into its api form
+func (atc *apiTransactionConverter) ConvertSmartContractResult(
+	scr *smartContractResult.SmartContractResult,
+	scrHash []byte,
+) (*transaction.ApiSmartContractResult, error) {
+	if scr == nil {
+		return nil, nil
+	}
+
+	encoder := atc.newAddressEncoder()
+	apiSCR := &transaction.ApiSmartContractResult{
+		Hash:           hex.EncodeToString(scrHash),
+		Nonce:          scr.Nonce,
+		Value:          scr.Value,
+		RcvAddr:        encoder.encode(scr.RcvAddr),
+		SndAddr:        encoder.encode(scr.SndAddr),
+		RelayerAddr:    encoder.encode(scr.RelayerAddr),
+		RelayedValue:   scr.RelayedValue,
+		Code:           string(scr.Code),
+		Data:           string(scr.Data),
+		PrevTxHash:     hex.EncodeToString(scr.PrevTxHash),
+		OriginalTxHash: hex.EncodeToString(scr.OriginalTxHash),
+		GasLimit:       scr.GasLimit,
+		GasPrice:       scr.GasPrice,
+		CallType:       scr.CallType,
+		CodeMetadata:   string(scr.CodeMetadata),
+		ReturnMessage:  string(scr.ReturnMessage),
+		OriginalSender: encoder.encode(scr.OriginalSender),
+	}
+	if encoder.err != nil {
+		return nil, encoder.err
+	}
+
+	return apiSCR, nil
+}
+
+// ConvertReceipt converts the provided receipt into its api form
+func (atc *apiTransactionConverter) ConvertReceipt(rec *receipt.Receipt) (*transaction.ApiReceipt, error) {
+	if rec == nil {
+		return nil, nil
+	}
+
+	encoder := atc.newAddressEncoder()
+	apiReceipt := &transaction.ApiReceipt{
+		Value:   rec.Value,
+		SndAddr: encoder.encode(rec.SndAddr),
+		Data:    string(rec.Data),
+		TxHash:  hex.EncodeToString(rec.TxHash),
+	}
+	if encoder.err != nil {
+		return nil, encoder.err
+	}
+
+	return apiReceipt, nil
+}
+
+// ConvertLog converts the provided log into its api form
+func (atc *apiTransactionConverter) ConvertLog(log *transaction.Log) (*transaction.ApiLogs, error) {
+	if log == nil {
+		return nil, nil
+	}
+
+	encoder := atc.newAddressEncoder()
+	apiLogs := &transaction.ApiLogs{
+		Address: encoder.encode(log.Address),
+		Events:  make([]*transaction.Events, 0, len(log.Events)),
+	}
+	for _, event := range log.Events {
+		if event == nil {
+			continue
+		}
+
+		apiLogs.Events = append(apiLogs.Events, &transaction.Events{
+			Address:        encoder.encode(event.Address),
+			Identifier:     string(event.Identifier),
+			Topics:         event.Topics,
+			Data:           event.Data,
+			AdditionalData: event.AdditionalData,
+		})
+	}
+	if encoder.err != nil {
+		return nil, encoder.err
+	}
+
+	return apiLogs, nil
+}
+
+func (atc *apiTransactionConverter) newAddressEncoder() *addressEncoder {
+	return &addressEncoder{pubkeyConverter: atc.pubkeyConverter}
+}
+
+// addressEncoder encodes addresses, retaining the first encoding error, so that the fields of an api structure can
+// be filled before checking the error once
+type addressEncoder struct {
+	pubkeyConverter core.PubkeyConverter
+	err             error
+}
+
+// encode returns the encoded address, or an empty string for an empty address or after an encoding error
+func (ae *addressEncoder) encode(address []byte) string {
+	if ae.err != nil || len(address) == 0 {
+		return ""
+	}
+
+	encoded, err := ae.pubkeyConverter.Encode(address)
+	if err != nil {
+		ae.err = fmt.Errorf("%w %s: %s", ErrInvalidAddress, hex.EncodeToString(address), err.Error())
+		return ""
+	}
+
+	return encoded
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (atc *apiTransactionConverter) IsInterfaceNil() bool {
+	return atc == nil
+}
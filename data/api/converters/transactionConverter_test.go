@@ -0,0 +1,316 @@
+package converters
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/mock"
+	"github.com/multiversx/mx-chain-core-go/data/receipt"
+	"github.com/multiversx/mx-chain-core-go/data/rewardTx"
+	"github.com/multiversx/mx-chain-core-go/data/smartContractResult"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMockArgsAPITransactionConverter() ArgsAPITransactionConverter {
+	return ArgsAPITransactionConverter{
+		PubkeyConverter: &mock.PubkeyConverterStub{
+			EncodeCalled: func(pkBytes []byte) (string, error) {
+				return "erd_" + string(pkBytes), nil
+			},
+		},
+	}
+}
+
+func TestNewAPITransactionConverter(t *testing.T) {
+	t.Parallel()
+
+	converter, err := NewAPITransactionConverter(ArgsAPITransactionConverter{})
+	assert.Equal(t, ErrNilPubkeyConverter, err)
+	assert.True(t, check.IfNil(converter))
+
+	converter, err = NewAPITransactionConverter(createMockArgsAPITransactionConverter())
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(converter))
+}
+
+func TestApiTransactionConverter_ConvertTransaction(t *testing.T) {
+	t.Parallel()
+
+	converter, _ := NewAPITransactionConverter(createMockArgsAPITransactionConverter())
+
+	t.Run("nil or unknown transaction should error", func(t *testing.T) {
+		t.Parallel()
+
+		apiTx, err := converter.ConvertTransaction(nil, []byte("hash"))
+		assert.Nil(t, apiTx)
+		assert.Equal(t, ErrNilTransactionHandler, err)
+
+		apiTx, err = converter.ConvertTransaction(&receipt.Receipt{}, []byte("hash"))
+		assert.Nil(t, apiTx)
+		assert.True(t, errors.Is(err, ErrUnknownTransactionType))
+	})
+	t.Run("normal transaction", func(t *testing.T) {
+		t.Parallel()
+
+		tx := &transaction.Transaction{
+			Nonce:             3,
+			Value:             big.NewInt(100),
+			RcvAddr:           []byte("receiver"),
+			SndAddr:           []byte("sender"),
+			SndUserName:       []byte("alice"),
+			GasPrice:          1000,
+			GasLimit:          70000,
+			Data:              []byte("data"),
+			ChainID:           []byte("T"),
+			Version:           2,
+			Signature:         []byte{0x01},
+			Options:           2,
+			GuardianAddr:      []byte("guardian"),
+			GuardianSignature: []byte{0x02},
+			RelayerAddr:       []byte("relayer"),
+			RelayerSignature:  []byte{0x03},
+		}
+
+		apiTx, err := converter.ConvertTransaction(tx, []byte{0xAA})
+		require.Nil(t, err)
+		assert.Equal(t, &transaction.ApiTransactionResult{
+			Tx:                tx,
+			Type:              string(transaction.TxTypeNormal),
+			Hash:              "aa",
+			HashBytes:         []byte{0xAA},
+			Nonce:             3,
+			Value:             "100",
+			Receiver:          "erd_receiver",
+			Sender:            "erd_sender",
+			SenderUsername:    []byte("alice"),
+			GasPrice:          1000,
+			GasLimit:          70000,
+			Data:              []byte("data"),
+			Signature:         "01",
+			ChainID:           "T",
+			Version:           2,
+			Options:           2,
+			GuardianAddr:      "erd_guardian",
+			GuardianSignature: "02",
+			RelayerAddress:    "erd_relayer",
+			RelayerSignature:  "03",
+			IsRelayed:         true,
+		}, apiTx)
+	})
+	t.Run("smart contract result", func(t *testing.T) {
+		t.Parallel()
+
+		scr := &smartContractResult.SmartContractResult{
+			Nonce:          1,
+			Value:          big.NewInt(5),
+			RcvAddr:        []byte("receiver"),
+			SndAddr:        []byte("sender"),
+			PrevTxHash:     []byte{0x01},
+			OriginalTxHash: []byte{0x02},
+			ReturnMessage:  []byte("message"),
+			CallType:       vm.AsynchronousCallBack,
+			RelayedValue:   big.NewInt(2),
+		}
+
+		apiTx, err := converter.ConvertTransaction(scr, []byte{0xBB})
+		require.Nil(t, err)
+		assert.Equal(t, string(transaction.TxTypeUnsigned), apiTx.Type)
+		assert.Equal(t, "5", apiTx.Value)
+		assert.Equal(t, "01", apiTx.PreviousTransactionHash)
+		assert.Equal(t, "02", apiTx.OriginalTransactionHash)
+		assert.Equal(t, "message", apiTx.ReturnMessage)
+		assert.Equal(t, vm.AsynchronousCallBack.ToString(), apiTx.CallType)
+		assert.Equal(t, "2", apiTx.RelayedValue)
+	})
+	t.Run("reward transaction", func(t *testing.T) {
+		t.Parallel()
+
+		reward := &rewardTx.RewardTx{Round: 7, Epoch: 1, Value: big.NewInt(9), RcvAddr: []byte("validator")}
+
+		apiTx, err := converter.ConvertTransaction(reward, []byte{0xCC})
+		require.Nil(t, err)
+		assert.Equal(t, string(transaction.TxTypeReward), apiTx.Type)
+		assert.Equal(t, uint64(7), apiTx.Round)
+		assert.Equal(t, uint32(1), apiTx.Epoch)
+		assert.Equal(t, "9", apiTx.Value)
+		assert.Equal(t, "erd_validator", apiTx.Receiver)
+		assert.Equal(t, "4294967295", apiTx.Sender)
+		assert.Equal(t, core.MetachainShardId, apiTx.SourceShard)
+	})
+}
+
+func TestApiTransactionConverter_ConvertTransactionWithResults(t *testing.T) {
+	t.Parallel()
+
+	converter, _ := NewAPITransactionConverter(createMockArgsAPITransactionConverter())
+	tx := &transaction.Transaction{Nonce: 1, Value: big.NewInt(1), SndAddr: []byte("sender"), RcvAddr: []byte("contract")}
+
+	t.Run("nil results should be pending", func(t *testing.T) {
+		t.Parallel()
+
+		apiTx, err := converter.ConvertTransactionWithResults(tx, []byte("hash"), nil)
+		require.Nil(t, err)
+		assert.Equal(t, transaction.TxStatusPending, apiTx.Status)
+		assert.Nil(t, apiTx.Receipt)
+		assert.Nil(t, apiTx.Logs)
+	})
+	t.Run("results should be attached", func(t *testing.T) {
+		t.Parallel()
+
+		results := &TransactionResults{
+			Receipt: &receipt.Receipt{Value: big.NewInt(3), SndAddr: []byte("sender"), Data: []byte("refund"), TxHash: []byte{0x01}},
+			SmartContractResults: []*SmartContractResultWithHash{
+				nil,
+				{
+					Hash:                []byte{0x02},
+					SmartContractResult: &smartContractResult.SmartContractResult{Value: big.NewInt(0), Data: []byte("@6f6b")},
+					Log: &transaction.Log{
+						Address: []byte("contract"),
+						Events:  []*transaction.Event{{Identifier: []byte(core.SignalErrorOperation), Topics: [][]byte{[]byte("t")}}},
+					},
+				},
+			},
+			Log: &transaction.Log{
+				Address: []byte("contract"),
+				Events:  []*transaction.Event{nil, {Address: []byte("contract"), Identifier: []byte(core.WriteLogIdentifier)}},
+			},
+			StatusInfo: TransactionStatusInfo{IsExecutedOnDestination: true},
+		}
+
+		apiTx, err := converter.ConvertTransactionWithResults(tx, []byte("hash"), results)
+		require.Nil(t, err)
+		assert.Equal(t, &transaction.ApiReceipt{Value: big.NewInt(3), SndAddr: "erd_sender", Data: "refund", TxHash: "01"}, apiTx.Receipt)
+		assert.Equal(t, &transaction.ApiLogs{
+			Address: "erd_contract",
+			Events:  []*transaction.Events{{Address: "erd_contract", Identifier: core.WriteLogIdentifier}},
+		}, apiTx.Logs)
+		require.Equal(t, 1, len(apiTx.SmartContractResults))
+		assert.Equal(t, "02", apiTx.SmartContractResults[0].Hash)
+		assert.Equal(t, "@6f6b", apiTx.SmartContractResults[0].Data)
+		assert.Equal(t, core.SignalErrorOperation, apiTx.SmartContractResults[0].Logs.Events[0].Identifier)
+		assert.Equal(t, transaction.TxStatusFail, apiTx.Status)
+	})
+}
+
+func TestResolveTransactionStatus(t *testing.T) {
+	t.Parallel()
+
+	createLogs := func(identifiers ...string) *transaction.ApiLogs {
+		logs := &transaction.ApiLogs{}
+		for _, identifier := range identifiers {
+			logs.Events = append(logs.Events, &transaction.Events{Identifier: identifier})
+		}
+		return logs
+	}
+	executed := TransactionStatusInfo{IsExecutedOnDestination: true}
+
+	assert.Equal(t, transaction.TxStatus(""), ResolveTransactionStatus(nil, executed))
+	assert.Equal(t, transaction.TxStatusInvalid, ResolveTransactionStatus(&transaction.ApiTransactionResult{
+		Type: string(transaction.TxTypeInvalid),
+	}, executed))
+	assert.Equal(t, transaction.TxStatusInvalid, ResolveTransactionStatus(&transaction.ApiTransactionResult{
+		Type:          string(transaction.TxTypeNormal),
+		MiniBlockType: "InvalidBlock",
+	}, executed))
+	assert.Equal(t, transaction.TxStatusRewardReverted, ResolveTransactionStatus(&transaction.ApiTransactionResult{
+		Type: string(transaction.TxTypeReward),
+	}, TransactionStatusInfo{IsExecutedOnDestination: true, IsRewardReverted: true}))
+	assert.Equal(t, transaction.TxStatusSuccess, ResolveTransactionStatus(&transaction.ApiTransactionResult{
+		Type: string(transaction.TxTypeReward),
+	}, executed))
+	assert.Equal(t, transaction.TxStatusPending, ResolveTransactionStatus(&transaction.ApiTransactionResult{
+		Logs: createLogs(core.WriteLogIdentifier),
+	}, TransactionStatusInfo{}))
+	assert.Equal(t, transaction.TxStatusSuccess, ResolveTransactionStatus(&transaction.ApiTransactionResult{
+		Logs: createLogs(core.CompletedTxEventIdentifier),
+	}, TransactionStatusInfo{}))
+	assert.Equal(t, transaction.TxStatusFail, ResolveTransactionStatus(&transaction.ApiTransactionResult{
+		Logs: createLogs(core.CompletedTxEventIdentifier, core.InternalVMErrorsOperation),
+	}, TransactionStatusInfo{}))
+	assert.Equal(t, transaction.TxStatusFail, ResolveTransactionStatus(&transaction.ApiTransactionResult{
+		SmartContractResults: []*transaction.ApiSmartContractResult{nil, {Logs: createLogs(core.SignalErrorOperation)}},
+	}, executed))
+	assert.Equal(t, transaction.TxStatusSuccess, ResolveTransactionStatus(&transaction.ApiTransactionResult{
+		SmartContractResults: []*transaction.ApiSmartContractResult{{}},
+	}, executed))
+
+	refundReceipt := &transaction.ApiReceipt{Value: big.NewInt(10), SndAddr: "erd_sender", Data: "refundedGas"}
+	assert.Equal(t, transaction.TxStatusSuccess, ResolveTransactionStatus(&transaction.ApiTransactionResult{
+		Receipt: refundReceipt,
+	}, executed))
+	assert.Equal(t, transaction.TxStatusPending, ResolveTransactionStatus(&transaction.ApiTransactionResult{
+		Receipt: refundReceipt,
+	}, TransactionStatusInfo{}))
+	assert.Equal(t, transaction.TxStatusFail, ResolveTransactionStatus(&transaction.ApiTransactionResult{
+		Receipt: refundReceipt,
+		Logs:    createLogs(core.SignalErrorOperation),
+	}, executed))
+}
+
+func TestApiTransactionConverter_InvalidAddressShouldError(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	converter, _ := NewAPITransactionConverter(ArgsAPITransactionConverter{
+		PubkeyConverter: &mock.PubkeyConverterStub{
+			EncodeCalled: func(pkBytes []byte) (string, error) {
+				if string(pkBytes) == "bad" {
+					return "", expectedErr
+				}
+				return "erd_" + string(pkBytes), nil
+			},
+		},
+	})
+	tx := &transaction.Transaction{Value: big.NewInt(1), SndAddr: []byte("sender"), RcvAddr: []byte("contract")}
+
+	t.Run("transaction", func(t *testing.T) {
+		t.Parallel()
+
+		apiTx, err := converter.ConvertTransaction(&transaction.Transaction{SndAddr: []byte("sender"), RcvAddr: []byte("bad")}, []byte("hash"))
+		assert.Nil(t, apiTx)
+		assert.True(t, errors.Is(err, ErrInvalidAddress))
+
+		apiTx, err = converter.ConvertTransaction(&smartContractResult.SmartContractResult{OriginalSender: []byte("bad")}, []byte("hash"))
+		assert.Nil(t, apiTx)
+		assert.True(t, errors.Is(err, ErrInvalidAddress))
+
+		apiTx, err = converter.ConvertTransaction(&rewardTx.RewardTx{RcvAddr: []byte("bad")}, []byte("hash"))
+		assert.Nil(t, apiTx)
+		assert.True(t, errors.Is(err, ErrInvalidAddress))
+	})
+	t.Run("receipt", func(t *testing.T) {
+		t.Parallel()
+
+		apiTx, err := converter.ConvertTransactionWithResults(tx, []byte("hash"), &TransactionResults{
+			Receipt: &receipt.Receipt{SndAddr: []byte("bad")},
+		})
+		assert.Nil(t, apiTx)
+		assert.True(t, errors.Is(err, ErrInvalidAddress))
+	})
+	t.Run("log event", func(t *testing.T) {
+		t.Parallel()
+
+		apiTx, err := converter.ConvertTransactionWithResults(tx, []byte("hash"), &TransactionResults{
+			Log: &transaction.Log{Address: []byte("contract"), Events: []*transaction.Event{{Address: []byte("bad")}}},
+		})
+		assert.Nil(t, apiTx)
+		assert.True(t, errors.Is(err, ErrInvalidAddress))
+	})
+	t.Run("smart contract result", func(t *testing.T) {
+		t.Parallel()
+
+		apiTx, err := converter.ConvertTransactionWithResults(tx, []byte("hash"), &TransactionResults{
+			SmartContractResults: []*SmartContractResultWithHash{
+				{SmartContractResult: &smartContractResult.SmartContractResult{RcvAddr: []byte("bad")}},
+			},
+		})
+		assert.Nil(t, apiTx)
+		assert.True(t, errors.Is(err, ErrInvalidAddress))
+	})
+}
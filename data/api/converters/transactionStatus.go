@@ -0,0 +1,74 @@
+package converters
+
+import (
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+)
+
+// TransactionStatusInfo holds the details about the processing of a transaction which are not part of the
+// transaction itself, but are needed in order to resolve its status
+type TransactionStatusInfo struct {
+	IsExecutedOnDestination bool
+	IsRewardReverted        bool
+}
+
+// ResolveTransactionStatus computes the status of the provided api transaction. Transactions included in invalid
+// miniBlocks are invalid, while the ones not yet executed on their destination shard are pending. An executed
+// transaction is failed if its logs, or the logs of its smart contract results, hold a signalError or an
+// internalVMErrors event. A completedTxEvent marks the execution as done, even if the destination was not reached.
+// The receipt is not inspected, as the protocol issues receipts to report the gas refunded to the sender, for both
+// successful and failed executions, so a receipt can not change the status
+func ResolveTransactionStatus(apiTx *transaction.ApiTransactionResult, info TransactionStatusInfo) transaction.TxStatus {
+	if apiTx == nil {
+		return ""
+	}
+
+	if apiTx.Type == string(transaction.TxTypeInvalid) || apiTx.MiniBlockType == block.InvalidBlock.String() {
+		return transaction.TxStatusInvalid
+	}
+	if apiTx.Type == string(transaction.TxTypeReward) && info.IsRewardReverted {
+		return transaction.TxStatusRewardReverted
+	}
+
+	hasFailed, hasCompleted := inspectLogs(apiTx.Logs)
+	for _, scr := range apiTx.SmartContractResults {
+		if scr == nil {
+			continue
+		}
+
+		scrFailed, scrCompleted := inspectLogs(scr.Logs)
+		hasFailed = hasFailed || scrFailed
+		hasCompleted = hasCompleted || scrCompleted
+	}
+
+	if hasFailed {
+		return transaction.TxStatusFail
+	}
+	if !info.IsExecutedOnDestination && !hasCompleted {
+		return transaction.TxStatusPending
+	}
+
+	return transaction.TxStatusSuccess
+}
+
+func inspectLogs(logs *transaction.ApiLogs) (hasFailed bool, hasCompleted bool) {
+	if logs == nil {
+		return false, false
+	}
+
+	for _, event := range logs.Events {
+		if event == nil {
+			continue
+		}
+
+		switch event.Identifier {
+		case core.SignalErrorOperation, core.InternalVMErrorsOperation:
+			hasFailed = true
+		case core.CompletedTxEventIdentifier:
+			hasCompleted = true
+		}
+	}
+
+	return hasFailed, hasCompleted
+}
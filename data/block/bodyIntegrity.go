@@ -0,0 +1,95 @@
+package block
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data"
+	"github.com/multiversx/mx-chain-core-go/hashing"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+)
+
+// CheckBodyAgainstHeader recomputes the hash of each miniBlock from the provided body and matches it against
+// the header's miniBlock headers. Each miniBlock header can be matched only once. The matched miniBlock header should
+// describe the same shards, type, transactions count and processing type, while its processed transactions indexes
+// should be consistent with the construction state
+func CheckBodyAgainstHeader(
+	marshaller marshal.Marshalizer,
+	hasher hashing.Hasher,
+	header data.HeaderHandler,
+	body *Body,
+) error {
+	if check.IfNil(marshaller) {
+		return data.ErrNilMarshalizer
+	}
+	if check.IfNil(hasher) {
+		return ErrNilHasher
+	}
+	if check.IfNil(header) {
+		return ErrNilHeaderHandler
+	}
+	if body == nil {
+		return ErrNilBody
+	}
+
+	mbHeaders := header.GetMiniBlockHeaderHandlers()
+	if len(mbHeaders) != len(body.MiniBlocks) {
+		return fmt.Errorf("%w, header %d, body %d", ErrMiniBlocksCountMismatch, len(mbHeaders), len(body.MiniBlocks))
+	}
+
+	mbHeadersByHash := make(map[string]data.MiniBlockHeaderHandler, len(mbHeaders))
+	for _, mbHeader := range mbHeaders {
+		mbHeadersByHash[string(mbHeader.GetHash())] = mbHeader
+	}
+
+	matchedHashes := make(map[string]struct{}, len(mbHeaders))
+	for i, miniBlock := range body.MiniBlocks {
+		if miniBlock == nil {
+			return fmt.Errorf("%w at index %d", ErrNilMiniBlock, i)
+		}
+
+		mbHash, err := core.CalculateHash(marshaller, hasher, miniBlock)
+		if err != nil {
+			return err
+		}
+
+		mbHeader, found := mbHeadersByHash[string(mbHash)]
+		if !found {
+			return fmt.Errorf("%w, miniBlock index %d, hash %s", ErrMiniBlockHashNotFound, i, hex.EncodeToString(mbHash))
+		}
+		_, alreadyMatched := matchedHashes[string(mbHash)]
+		if alreadyMatched {
+			return fmt.Errorf("%w, miniBlock index %d, hash %s", ErrDuplicatedMiniBlock, i, hex.EncodeToString(mbHash))
+		}
+		matchedHashes[string(mbHash)] = struct{}{}
+
+		err = checkMiniBlockAgainstHeader(miniBlock, mbHeader)
+		if err != nil {
+			return fmt.Errorf("%w for miniBlock hash %s", err, hex.EncodeToString(mbHash))
+		}
+	}
+
+	return nil
+}
+
+func checkMiniBlockAgainstHeader(miniBlock *MiniBlock, mbHeader data.MiniBlockHeaderHandler) error {
+	if miniBlock.SenderShardID != mbHeader.GetSenderShardID() || miniBlock.ReceiverShardID != mbHeader.GetReceiverShardID() {
+		return fmt.Errorf("%w, miniBlock %d -> %d, miniBlock header %d -> %d", ErrShardIDMismatch,
+			miniBlock.SenderShardID, miniBlock.ReceiverShardID, mbHeader.GetSenderShardID(), mbHeader.GetReceiverShardID())
+	}
+	if int32(miniBlock.Type) != mbHeader.GetTypeInt32() {
+		return fmt.Errorf("%w, miniBlock %s, miniBlock header %s",
+			ErrMiniBlockTypeMismatch, miniBlock.Type.String(), Type(mbHeader.GetTypeInt32()).String())
+	}
+	if uint32(len(miniBlock.TxHashes)) != mbHeader.GetTxCount() {
+		return fmt.Errorf("%w, miniBlock %d, miniBlock header %d", ErrTxCountMismatch, len(miniBlock.TxHashes), mbHeader.GetTxCount())
+	}
+	if miniBlock.GetProcessingType() != mbHeader.GetProcessingType() {
+		return fmt.Errorf("%w, miniBlock %s, miniBlock header %s", ErrProcessingTypeMismatch,
+			ProcessingType(miniBlock.GetProcessingType()).String(), ProcessingType(mbHeader.GetProcessingType()).String())
+	}
+
+	return checkProcessedTxsIndexes(mbHeader)
+}
@@ -0,0 +1,157 @@
+package block_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-core-go/data/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createHeaderAndBody(t *testing.T) (*block.HeaderV2, *block.Body) {
+	args := createMockArgsHeaderChainVerifier()
+	body, _ := createBodyAndHashes()
+	body.MiniBlocks[1].Reserved, _ = (&block.MiniBlockReserved{ExecutionType: block.Scheduled}).Marshal()
+
+	hashes := make([][]byte, 0, len(body.MiniBlocks))
+	for _, miniBlock := range body.MiniBlocks {
+		hash, err := core.CalculateHash(args.Marshaller, args.Hasher, miniBlock)
+		require.Nil(t, err)
+		hashes = append(hashes, hash)
+	}
+
+	hb, _ := block.NewHeaderBuilder(createMandatoryFieldsHeaderV2())
+	header, err := hb.WithBody(body, hashes).Build()
+	require.Nil(t, err)
+
+	return header.(*block.HeaderV2), body
+}
+
+func TestCheckBodyAgainstHeader(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsHeaderChainVerifier()
+
+	t.Run("nil arguments should error", func(t *testing.T) {
+		t.Parallel()
+
+		header, body := createHeaderAndBody(t)
+		assert.Equal(t, data.ErrNilMarshalizer, block.CheckBodyAgainstHeader(nil, args.Hasher, header, body))
+		assert.Equal(t, block.ErrNilHasher, block.CheckBodyAgainstHeader(args.Marshaller, nil, header, body))
+		assert.Equal(t, block.ErrNilHeaderHandler, block.CheckBodyAgainstHeader(args.Marshaller, args.Hasher, nil, body))
+		assert.Equal(t, block.ErrNilBody, block.CheckBodyAgainstHeader(args.Marshaller, args.Hasher, header, nil))
+	})
+	t.Run("miniBlocks count mismatch should error", func(t *testing.T) {
+		t.Parallel()
+
+		header, body := createHeaderAndBody(t)
+		body.MiniBlocks = body.MiniBlocks[:1]
+		err := block.CheckBodyAgainstHeader(args.Marshaller, args.Hasher, header, body)
+		assert.True(t, errors.Is(err, block.ErrMiniBlocksCountMismatch))
+	})
+	t.Run("nil miniBlock should error", func(t *testing.T) {
+		t.Parallel()
+
+		header, body := createHeaderAndBody(t)
+		body.MiniBlocks[0] = nil
+		err := block.CheckBodyAgainstHeader(args.Marshaller, args.Hasher, header, body)
+		assert.True(t, errors.Is(err, block.ErrNilMiniBlock))
+	})
+	t.Run("altered miniBlock should error", func(t *testing.T) {
+		t.Parallel()
+
+		header, body := createHeaderAndBody(t)
+		body.MiniBlocks[1].TxHashes[0] = []byte("another tx")
+		err := block.CheckBodyAgainstHeader(args.Marshaller, args.Hasher, header, body)
+		assert.True(t, errors.Is(err, block.ErrMiniBlockHashNotFound))
+	})
+	t.Run("duplicated miniBlock should error", func(t *testing.T) {
+		t.Parallel()
+
+		header, body := createHeaderAndBody(t)
+		body.MiniBlocks[1] = body.MiniBlocks[0]
+		err := block.CheckBodyAgainstHeader(args.Marshaller, args.Hasher, header, body)
+		assert.True(t, errors.Is(err, block.ErrDuplicatedMiniBlock))
+	})
+	t.Run("shards mismatch should error", func(t *testing.T) {
+		t.Parallel()
+
+		header, body := createHeaderAndBody(t)
+		header.Header.MiniBlockHeaders[0].SenderShardID = 0
+		err := block.CheckBodyAgainstHeader(args.Marshaller, args.Hasher, header, body)
+		assert.True(t, errors.Is(err, block.ErrShardIDMismatch))
+	})
+	t.Run("type mismatch should error", func(t *testing.T) {
+		t.Parallel()
+
+		header, body := createHeaderAndBody(t)
+		header.Header.MiniBlockHeaders[0].Type = block.InvalidBlock
+		err := block.CheckBodyAgainstHeader(args.Marshaller, args.Hasher, header, body)
+		assert.True(t, errors.Is(err, block.ErrMiniBlockTypeMismatch))
+	})
+	t.Run("tx count mismatch should error", func(t *testing.T) {
+		t.Parallel()
+
+		header, body := createHeaderAndBody(t)
+		header.Header.MiniBlockHeaders[0].TxCount = 3
+		err := block.CheckBodyAgainstHeader(args.Marshaller, args.Hasher, header, body)
+		assert.True(t, errors.Is(err, block.ErrTxCountMismatch))
+	})
+	t.Run("processing type mismatch should error", func(t *testing.T) {
+		t.Parallel()
+
+		header, body := createHeaderAndBody(t)
+		_ = header.Header.MiniBlockHeaders[1].SetProcessingType(int32(block.Normal))
+		err := block.CheckBodyAgainstHeader(args.Marshaller, args.Hasher, header, body)
+		assert.True(t, errors.Is(err, block.ErrProcessingTypeMismatch))
+	})
+	t.Run("partially executed miniBlock including the last tx should error", func(t *testing.T) {
+		t.Parallel()
+
+		header, body := createHeaderAndBody(t)
+		_ = header.Header.MiniBlockHeaders[1].SetConstructionState(int32(block.PartialExecuted))
+		err := block.CheckBodyAgainstHeader(args.Marshaller, args.Hasher, header, body)
+		assert.True(t, errors.Is(err, block.ErrInvalidMiniBlockHeader))
+	})
+	t.Run("final miniBlock not including the last tx should error", func(t *testing.T) {
+		t.Parallel()
+
+		header, body := createHeaderAndBody(t)
+		_ = header.Header.MiniBlockHeaders[1].SetIndexOfLastTxProcessed(1)
+		err := block.CheckBodyAgainstHeader(args.Marshaller, args.Hasher, header, body)
+		assert.True(t, errors.Is(err, block.ErrInvalidMiniBlockHeader))
+	})
+	t.Run("partially executed miniBlock should work", func(t *testing.T) {
+		t.Parallel()
+
+		header, body := createHeaderAndBody(t)
+		_ = header.Header.MiniBlockHeaders[1].SetConstructionState(int32(block.PartialExecuted))
+		_ = header.Header.MiniBlockHeaders[1].SetIndexOfFirstTxProcessed(1)
+		_ = header.Header.MiniBlockHeaders[1].SetIndexOfLastTxProcessed(1)
+		assert.Nil(t, block.CheckBodyAgainstHeader(args.Marshaller, args.Hasher, header, body))
+	})
+	t.Run("miniBlocks in a different order should work", func(t *testing.T) {
+		t.Parallel()
+
+		header, body := createHeaderAndBody(t)
+		body.MiniBlocks[0], body.MiniBlocks[1] = body.MiniBlocks[1], body.MiniBlocks[0]
+		assert.Nil(t, block.CheckBodyAgainstHeader(args.Marshaller, args.Hasher, header, body))
+	})
+	t.Run("marshaller error should error", func(t *testing.T) {
+		t.Parallel()
+
+		header, body := createHeaderAndBody(t)
+		err := block.CheckBodyAgainstHeader(&mock.MarshalizerMock{Fail: true}, args.Hasher, header, body)
+		assert.NotNil(t, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		header, body := createHeaderAndBody(t)
+		assert.Nil(t, block.CheckBodyAgainstHeader(args.Marshaller, args.Hasher, header, body))
+	})
+}
@@ -0,0 +1,106 @@
+package block
+
+import (
+	"math/big"
+	"reflect"
+)
+
+var bigIntPointerType = reflect.TypeOf(&big.Int{})
+
+// DeepClone returns a deep copy of the header, not sharing any slice, pointer or big integer with the original one
+func (h *Header) DeepClone() *Header {
+	if h == nil {
+		return nil
+	}
+
+	return deepCopy(h).(*Header)
+}
+
+// DeepClone returns a deep copy of the header, not sharing any slice, pointer or big integer with the original one
+func (hv2 *HeaderV2) DeepClone() *HeaderV2 {
+	if hv2 == nil {
+		return nil
+	}
+
+	return deepCopy(hv2).(*HeaderV2)
+}
+
+// DeepClone returns a deep copy of the meta block, not sharing any slice, pointer or big integer with the original one
+func (m *MetaBlock) DeepClone() *MetaBlock {
+	if m == nil {
+		return nil
+	}
+
+	return deepCopy(m).(*MetaBlock)
+}
+
+// DeepClone returns a deep copy of the shard data, not sharing any slice, pointer or big integer with the original one
+func (sd *ShardData) DeepClone() *ShardData {
+	if sd == nil {
+		return nil
+	}
+
+	return deepCopy(sd).(*ShardData)
+}
+
+// DeepClone returns a deep copy of the body, including all its miniBlocks
+func (b *Body) DeepClone() *Body {
+	if b == nil {
+		return nil
+	}
+
+	return deepCopy(b).(*Body)
+}
+
+func deepCopy(object interface{}) interface{} {
+	return deepCopyValue(reflect.ValueOf(object)).Interface()
+}
+
+func deepCopyValue(value reflect.Value) reflect.Value {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return reflect.Zero(value.Type())
+		}
+		if value.Type() == bigIntPointerType {
+			return reflect.ValueOf(big.NewInt(0).Set(value.Interface().(*big.Int)))
+		}
+
+		valueCopy := reflect.New(value.Type().Elem())
+		valueCopy.Elem().Set(deepCopyValue(value.Elem()))
+		return valueCopy
+	case reflect.Struct:
+		valueCopy := reflect.New(value.Type()).Elem()
+		valueCopy.Set(value)
+		for i := 0; i < value.NumField(); i++ {
+			if !valueCopy.Field(i).CanSet() {
+				continue
+			}
+			valueCopy.Field(i).Set(deepCopyValue(value.Field(i)))
+		}
+		return valueCopy
+	case reflect.Slice:
+		if value.IsNil() {
+			return reflect.Zero(value.Type())
+		}
+
+		valueCopy := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for i := 0; i < value.Len(); i++ {
+			valueCopy.Index(i).Set(deepCopyValue(value.Index(i)))
+		}
+		return valueCopy
+	case reflect.Map:
+		if value.IsNil() {
+			return reflect.Zero(value.Type())
+		}
+
+		valueCopy := reflect.MakeMapWithSize(value.Type(), value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			valueCopy.SetMapIndex(deepCopyValue(iter.Key()), deepCopyValue(iter.Value()))
+		}
+		return valueCopy
+	default:
+		return value
+	}
+}
@@ -0,0 +1,67 @@
+package block_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeepClone(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil receivers", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Nil(t, (*block.Header)(nil).DeepClone())
+		assert.Nil(t, (*block.HeaderV2)(nil).DeepClone())
+		assert.Nil(t, (*block.MetaBlock)(nil).DeepClone())
+		assert.Nil(t, (*block.ShardData)(nil).DeepClone())
+		assert.Nil(t, (*block.Body)(nil).DeepClone())
+	})
+	t.Run("meta block", func(t *testing.T) {
+		t.Parallel()
+
+		metaBlock := createMetaBlockWithShardInfo()
+		clone := metaBlock.DeepClone()
+		require.Equal(t, metaBlock, clone)
+
+		clone.ShardInfo[2].ShardMiniBlockHeaders[0].TxCount = 6
+		clone.ShardInfo[1].HeaderHash[0] = 9
+		clone.ShardInfo[0].AccumulatedFees.SetInt64(100)
+		clone.AccumulatedFees.SetInt64(100)
+		assert.Equal(t, uint32(5), metaBlock.ShardInfo[2].ShardMiniBlockHeaders[0].TxCount)
+		assert.Equal(t, []byte{1}, metaBlock.ShardInfo[1].HeaderHash)
+		assert.Equal(t, big.NewInt(0), metaBlock.ShardInfo[0].AccumulatedFees)
+		assert.Equal(t, big.NewInt(0), metaBlock.AccumulatedFees)
+	})
+	t.Run("header v2", func(t *testing.T) {
+		t.Parallel()
+
+		headerV2 := createMandatoryFieldsHeaderV2()
+		clone := headerV2.DeepClone()
+		require.Equal(t, headerV2, clone)
+
+		clone.Header.Nonce = 100
+		clone.ScheduledDeveloperFees.SetInt64(100)
+		assert.Equal(t, uint64(10), headerV2.Header.Nonce)
+		assert.Equal(t, big.NewInt(0), headerV2.ScheduledDeveloperFees)
+	})
+	t.Run("body and shard data", func(t *testing.T) {
+		t.Parallel()
+
+		body, _ := createBodyAndHashes()
+		bodyClone := body.DeepClone()
+		require.Equal(t, body, bodyClone)
+		bodyClone.MiniBlocks[0].TxHashes[0] = []byte("another tx")
+		assert.Equal(t, []byte("tx1"), body.MiniBlocks[0].TxHashes[0])
+
+		shardData := &createMetaBlockWithShardInfo().ShardInfo[1]
+		shardDataClone := shardData.DeepClone()
+		require.Equal(t, shardData, shardDataClone)
+		shardDataClone.ShardMiniBlockHeaders[0].Hash = nil
+		assert.Equal(t, []byte("mb"), shardData.ShardMiniBlockHeaders[0].Hash)
+	})
+}
@@ -0,0 +1,185 @@
+package block
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data"
+)
+
+// EpochStartSummary holds the epoch start data of a start of epoch meta block in a typed form
+type EpochStartSummary struct {
+	Epoch                uint32
+	Nonce                uint64
+	Round                uint64
+	LastFinalizedHeaders []*EpochStartShardData
+	Economics            *Economics
+}
+
+// NewEpochStartSummary extracts the epoch start data from the provided start of epoch meta block
+func NewEpochStartSummary(metaHeader data.MetaHeaderHandler) (*EpochStartSummary, error) {
+	if check.IfNil(metaHeader) {
+		return nil, ErrNilHeaderHandler
+	}
+	if !metaHeader.IsStartOfEpochBlock() {
+		return nil, ErrNotEpochStartBlock
+	}
+
+	epochStart := metaHeader.GetEpochStartHandler()
+	if check.IfNilReflect(epochStart) {
+		return nil, ErrNilEpochStartData
+	}
+
+	summary := &EpochStartSummary{
+		Epoch:                metaHeader.GetEpoch(),
+		Nonce:                metaHeader.GetNonce(),
+		Round:                metaHeader.GetRound(),
+		LastFinalizedHeaders: make([]*EpochStartShardData, 0, len(epochStart.GetLastFinalizedHeaderHandlers())),
+		Economics:            economicsFromHandler(epochStart.GetEconomicsHandler()),
+	}
+	for _, shardData := range epochStart.GetLastFinalizedHeaderHandlers() {
+		if check.IfNilReflect(shardData) {
+			return nil, fmt.Errorf("%w, nil last finalized header", ErrInvalidEpochStartShardData)
+		}
+
+		summary.LastFinalizedHeaders = append(summary.LastFinalizedHeaders, epochStartShardDataFromHandler(shardData))
+	}
+
+	return summary, nil
+}
+
+func epochStartShardDataFromHandler(shardData data.EpochStartShardDataHandler) *EpochStartShardData {
+	mbHeaderHandlers := shardData.GetPendingMiniBlockHeaderHandlers()
+	pendingMiniBlockHeaders := make([]MiniBlockHeader, 0, len(mbHeaderHandlers))
+	for _, mbHeader := range mbHeaderHandlers {
+		pendingMiniBlockHeaders = append(pendingMiniBlockHeaders, MiniBlockHeader{
+			Hash:            mbHeader.GetHash(),
+			SenderShardID:   mbHeader.GetSenderShardID(),
+			ReceiverShardID: mbHeader.GetReceiverShardID(),
+			TxCount:         mbHeader.GetTxCount(),
+			Type:            Type(mbHeader.GetTypeInt32()),
+			Reserved:        mbHeader.GetReserved(),
+		})
+	}
+
+	var scheduledRootHash []byte
+	scheduledHandler, ok := shardData.(interface{ GetScheduledRootHash() []byte })
+	if ok {
+		scheduledRootHash = scheduledHandler.GetScheduledRootHash()
+	}
+
+	return &EpochStartShardData{
+		ShardID:                 shardData.GetShardID(),
+		Epoch:                   shardData.GetEpoch(),
+		Round:                   shardData.GetRound(),
+		Nonce:                   shardData.GetNonce(),
+		HeaderHash:              shardData.GetHeaderHash(),
+		RootHash:                shardData.GetRootHash(),
+		ScheduledRootHash:       scheduledRootHash,
+		FirstPendingMetaBlock:   shardData.GetFirstPendingMetaBlock(),
+		LastFinishedMetaBlock:   shardData.GetLastFinishedMetaBlock(),
+		PendingMiniBlockHeaders: pendingMiniBlockHeaders,
+	}
+}
+
+func economicsFromHandler(economics data.EconomicsHandler) *Economics {
+	if check.IfNilReflect(economics) {
+		return nil
+	}
+
+	return &Economics{
+		TotalSupply:                      economics.GetTotalSupply(),
+		TotalToDistribute:                economics.GetTotalToDistribute(),
+		TotalNewlyMinted:                 economics.GetTotalNewlyMinted(),
+		RewardsPerBlock:                  economics.GetRewardsPerBlock(),
+		RewardsForProtocolSustainability: economics.GetRewardsForProtocolSustainability(),
+		NodePrice:                        economics.GetNodePrice(),
+		PrevEpochStartRound:              economics.GetPrevEpochStartRound(),
+		PrevEpochStartHash:               economics.GetPrevEpochStartHash(),
+	}
+}
+
+// Validate checks that the summary holds exactly one last finalized header for each of the provided number of
+// shards, each of them from the previous or the current epoch, and that all economics values are set
+func (ess *EpochStartSummary) Validate(numOfShards uint32) error {
+	if ess == nil {
+		return data.ErrNilPointerReceiver
+	}
+	if numOfShards == 0 {
+		return ErrInvalidNumberOfShards
+	}
+
+	if uint32(len(ess.LastFinalizedHeaders)) != numOfShards {
+		return fmt.Errorf("%w, %d last finalized headers for %d shards",
+			ErrInvalidEpochStartShardData, len(ess.LastFinalizedHeaders), numOfShards)
+	}
+
+	seenShards := make(map[uint32]struct{}, numOfShards)
+	for _, shardData := range ess.LastFinalizedHeaders {
+		err := ess.checkEpochStartShardData(shardData, numOfShards, seenShards)
+		if err != nil {
+			return err
+		}
+	}
+
+	return checkEpochStartEconomics(ess.Economics)
+}
+
+func (ess *EpochStartSummary) checkEpochStartShardData(
+	shardData *EpochStartShardData,
+	numOfShards uint32,
+	seenShards map[uint32]struct{},
+) error {
+	if shardData == nil {
+		return fmt.Errorf("%w, nil last finalized header", ErrInvalidEpochStartShardData)
+	}
+	if shardData.ShardID >= numOfShards {
+		return fmt.Errorf("%w, invalid shard %d", ErrInvalidEpochStartShardData, shardData.ShardID)
+	}
+	_, seen := seenShards[shardData.ShardID]
+	if seen {
+		return fmt.Errorf("%w, duplicated shard %d", ErrInvalidEpochStartShardData, shardData.ShardID)
+	}
+	seenShards[shardData.ShardID] = struct{}{}
+
+	isCurrentEpoch := shardData.Epoch == ess.Epoch
+	isPreviousEpoch := shardData.Epoch+1 == ess.Epoch
+	if !isCurrentEpoch && !isPreviousEpoch {
+		return fmt.Errorf("%w, shard %d epoch %d, meta block epoch %d",
+			ErrInvalidEpochStartShardData, shardData.ShardID, shardData.Epoch, ess.Epoch)
+	}
+	if len(shardData.HeaderHash) == 0 || len(shardData.RootHash) == 0 {
+		return fmt.Errorf("%w, missing header hash or root hash for shard %d", ErrInvalidEpochStartShardData, shardData.ShardID)
+	}
+
+	return nil
+}
+
+func checkEpochStartEconomics(economics *Economics) error {
+	if economics == nil {
+		return fmt.Errorf("%w, nil economics", ErrInvalidEpochStartEconomics)
+	}
+
+	values := []struct {
+		name  string
+		value *big.Int
+	}{
+		{name: "TotalSupply", value: economics.TotalSupply},
+		{name: "TotalToDistribute", value: economics.TotalToDistribute},
+		{name: "TotalNewlyMinted", value: economics.TotalNewlyMinted},
+		{name: "RewardsPerBlock", value: economics.RewardsPerBlock},
+		{name: "RewardsForProtocolSustainability", value: economics.RewardsForProtocolSustainability},
+		{name: "NodePrice", value: economics.NodePrice},
+	}
+	for _, field := range values {
+		if field.value == nil {
+			return fmt.Errorf("%w, nil %s", ErrInvalidEpochStartEconomics, field.name)
+		}
+		if field.value.Sign() < 0 {
+			return fmt.Errorf("%w, negative %s", ErrInvalidEpochStartEconomics, field.name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package block_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createEpochStartMetaBlock() *block.MetaBlock {
+	metaBlock := createMandatoryFieldsMetaBlock()
+	metaBlock.Epoch = 3
+	metaBlock.EpochStart = block.EpochStart{
+		LastFinalizedHeaders: []block.EpochStartShardData{
+			{
+				ShardID:           0,
+				Epoch:             2,
+				Nonce:             100,
+				Round:             110,
+				HeaderHash:        []byte("shard0 hash"),
+				RootHash:          []byte("shard0 root hash"),
+				ScheduledRootHash: []byte("shard0 scheduled root hash"),
+				PendingMiniBlockHeaders: []block.MiniBlockHeader{
+					{Hash: []byte("pending mb"), SenderShardID: 1, ReceiverShardID: 0, TxCount: 2, Type: block.TxBlock},
+				},
+			},
+			{
+				ShardID:    1,
+				Epoch:      2,
+				Nonce:      101,
+				Round:      111,
+				HeaderHash: []byte("shard1 hash"),
+				RootHash:   []byte("shard1 root hash"),
+			},
+		},
+		Economics: block.Economics{
+			TotalSupply:                      big.NewInt(1000),
+			TotalToDistribute:                big.NewInt(100),
+			TotalNewlyMinted:                 big.NewInt(10),
+			RewardsPerBlock:                  big.NewInt(1),
+			RewardsForProtocolSustainability: big.NewInt(5),
+			NodePrice:                        big.NewInt(2500),
+			PrevEpochStartRound:              50,
+			PrevEpochStartHash:               []byte("prev epoch start hash"),
+		},
+	}
+
+	return metaBlock
+}
+
+func TestNewEpochStartSummary(t *testing.T) {
+	t.Parallel()
+
+	summary, err := block.NewEpochStartSummary(nil)
+	assert.Nil(t, summary)
+	assert.Equal(t, block.ErrNilHeaderHandler, err)
+
+	summary, err = block.NewEpochStartSummary(createMandatoryFieldsMetaBlock())
+	assert.Nil(t, summary)
+	assert.Equal(t, block.ErrNotEpochStartBlock, err)
+
+	metaBlock := createEpochStartMetaBlock()
+	summary, err = block.NewEpochStartSummary(metaBlock)
+	require.Nil(t, err)
+	assert.Equal(t, uint32(3), summary.Epoch)
+	assert.Equal(t, metaBlock.Nonce, summary.Nonce)
+	assert.Equal(t, metaBlock.Round, summary.Round)
+	assert.Equal(t, &metaBlock.EpochStart.Economics, summary.Economics)
+	require.Equal(t, 2, len(summary.LastFinalizedHeaders))
+	assert.Equal(t, &metaBlock.EpochStart.LastFinalizedHeaders[0], summary.LastFinalizedHeaders[0])
+	assert.Equal(t, []byte("shard1 hash"), summary.LastFinalizedHeaders[1].HeaderHash)
+}
+
+func TestEpochStartSummary_Validate(t *testing.T) {
+	t.Parallel()
+
+	createSummary := func() *block.EpochStartSummary {
+		summary, _ := block.NewEpochStartSummary(createEpochStartMetaBlock())
+		return summary
+	}
+
+	t.Run("nil summary should error", func(t *testing.T) {
+		t.Parallel()
+
+		var summary *block.EpochStartSummary
+		assert.Equal(t, data.ErrNilPointerReceiver, summary.Validate(2))
+	})
+	t.Run("invalid number of shards should error", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, block.ErrInvalidNumberOfShards, createSummary().Validate(0))
+	})
+	t.Run("missing shard should error", func(t *testing.T) {
+		t.Parallel()
+
+		err := createSummary().Validate(3)
+		assert.True(t, errors.Is(err, block.ErrInvalidEpochStartShardData))
+	})
+	t.Run("duplicated shard should error", func(t *testing.T) {
+		t.Parallel()
+
+		summary := createSummary()
+		summary.LastFinalizedHeaders[1].ShardID = 0
+		err := summary.Validate(2)
+		assert.True(t, errors.Is(err, block.ErrInvalidEpochStartShardData))
+		assert.Contains(t, err.Error(), "duplicated shard 0")
+	})
+	t.Run("nil last finalized header should error", func(t *testing.T) {
+		t.Parallel()
+
+		summary := createSummary()
+		summary.LastFinalizedHeaders[0] = nil
+		err := summary.Validate(2)
+		assert.True(t, errors.Is(err, block.ErrInvalidEpochStartShardData))
+	})
+	t.Run("shard out of range should error", func(t *testing.T) {
+		t.Parallel()
+
+		summary := createSummary()
+		summary.LastFinalizedHeaders[1].ShardID = 2
+		err := summary.Validate(2)
+		assert.True(t, errors.Is(err, block.ErrInvalidEpochStartShardData))
+	})
+	t.Run("inconsistent epoch should error", func(t *testing.T) {
+		t.Parallel()
+
+		summary := createSummary()
+		summary.LastFinalizedHeaders[0].Epoch = 1
+		err := summary.Validate(2)
+		assert.True(t, errors.Is(err, block.ErrInvalidEpochStartShardData))
+
+		summary.LastFinalizedHeaders[0].Epoch = 4
+		err = summary.Validate(2)
+		assert.True(t, errors.Is(err, block.ErrInvalidEpochStartShardData))
+	})
+	t.Run("missing root hash should error", func(t *testing.T) {
+		t.Parallel()
+
+		summary := createSummary()
+		summary.LastFinalizedHeaders[1].RootHash = nil
+		err := summary.Validate(2)
+		assert.True(t, errors.Is(err, block.ErrInvalidEpochStartShardData))
+	})
+	t.Run("invalid economics should error", func(t *testing.T) {
+		t.Parallel()
+
+		summary := createSummary()
+		summary.Economics = nil
+		assert.True(t, errors.Is(summary.Validate(2), block.ErrInvalidEpochStartEconomics))
+
+		summary = createSummary()
+		summary.Economics.NodePrice = nil
+		err := summary.Validate(2)
+		assert.True(t, errors.Is(err, block.ErrInvalidEpochStartEconomics))
+		assert.Contains(t, err.Error(), "NodePrice")
+
+		summary = createSummary()
+		summary.Economics.NodePrice = nil
+		summary.Economics.TotalSupply = nil
+		for i := 0; i < 10; i++ {
+			assert.Contains(t, summary.Validate(2).Error(), "nil TotalSupply")
+		}
+
+		summary = createSummary()
+		summary.Economics.TotalSupply = big.NewInt(-1)
+		assert.True(t, errors.Is(summary.Validate(2), block.ErrInvalidEpochStartEconomics))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Nil(t, createSummary().Validate(2))
+
+		summary := createSummary()
+		summary.LastFinalizedHeaders[1].Epoch = 3
+		assert.Nil(t, summary.Validate(2))
+	})
+}
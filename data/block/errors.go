@@ -0,0 +1,120 @@
+package block
+
+import "errors"
+
+// ErrNilHeaderHandler signals that a nil header handler has been provided
+var ErrNilHeaderHandler = errors.New("nil header handler")
+
+// ErrNilBody signals that a nil block body has been provided
+var ErrNilBody = errors.New("nil block body")
+
+// ErrMiniBlockHashesMismatch signals that the number of miniBlock hashes does not match the number of miniBlocks
+var ErrMiniBlockHashesMismatch = errors.New("miniBlock hashes do not match the miniBlocks")
+
+// ErrNilMiniBlock signals that a nil miniBlock has been provided
+var ErrNilMiniBlock = errors.New("nil miniBlock")
+
+// ErrTxCountMismatch signals that the header's transactions count does not match the miniBlock headers
+var ErrTxCountMismatch = errors.New("transactions count mismatch")
+
+// ErrInvalidMiniBlockHeader signals that an invalid miniBlock header has been detected
+var ErrInvalidMiniBlockHeader = errors.New("invalid miniBlock header")
+
+// ErrShardIDMismatch signals that the shard IDs do not match
+var ErrShardIDMismatch = errors.New("shard ID mismatch")
+
+// ErrWrongNonce signals that the nonce does not follow the previous one
+var ErrWrongNonce = errors.New("wrong nonce")
+
+// ErrLowerRoundInBlock signals that the round is not higher than the previous one
+var ErrLowerRoundInBlock = errors.New("header round is lower than the previous one")
+
+// ErrWrongEpoch signals that the epoch does not follow the previous one
+var ErrWrongEpoch = errors.New("wrong epoch")
+
+// ErrEpochStartMismatch signals that the epoch start flag is not consistent with the epoch change
+var ErrEpochStartMismatch = errors.New("epoch start mismatch")
+
+// ErrNilHasher signals that a nil hasher has been provided
+var ErrNilHasher = errors.New("nil hasher")
+
+// ErrHeadersHashesMismatch signals that the number of headers does not match the number of hashes
+var ErrHeadersHashesMismatch = errors.New("headers do not match the hashes")
+
+// ErrHeaderHashMismatch signals that the computed header hash does not match the provided one
+var ErrHeaderHashMismatch = errors.New("header hash mismatch")
+
+// ErrPrevHashMismatch signals that the previous hash does not match the previous header's hash
+var ErrPrevHashMismatch = errors.New("previous hash mismatch")
+
+// ErrRandSeedMismatch signals that the previous random seed does not match the previous header's random seed
+var ErrRandSeedMismatch = errors.New("random seed mismatch")
+
+// ErrMiniBlocksCountMismatch signals that the number of miniBlocks does not match the number of miniBlock headers
+var ErrMiniBlocksCountMismatch = errors.New("miniBlocks count mismatch")
+
+// ErrMiniBlockHashNotFound signals that a miniBlock hash was not found in the header's miniBlock headers
+var ErrMiniBlockHashNotFound = errors.New("miniBlock hash not found in header")
+
+// ErrDuplicatedMiniBlock signals that the body holds the same miniBlock more than once
+var ErrDuplicatedMiniBlock = errors.New("duplicated miniBlock")
+
+// ErrMiniBlockTypeMismatch signals that the miniBlock type does not match the miniBlock header type
+var ErrMiniBlockTypeMismatch = errors.New("miniBlock type mismatch")
+
+// ErrProcessingTypeMismatch signals that the miniBlock processing type does not match the miniBlock header one
+var ErrProcessingTypeMismatch = errors.New("processing type mismatch")
+
+// ErrNilHeaderProof signals that a nil header proof has been provided
+var ErrNilHeaderProof = errors.New("nil header proof")
+
+// ErrNilAggregateSignatureVerifier signals that a nil aggregate signature verifier has been provided
+var ErrNilAggregateSignatureVerifier = errors.New("nil aggregate signature verifier")
+
+// ErrInvalidConsensusSize signals that an invalid consensus size has been provided
+var ErrInvalidConsensusSize = errors.New("invalid consensus size")
+
+// ErrWrongSizeBitmap signals that the public keys bitmap size does not match the consensus size
+var ErrWrongSizeBitmap = errors.New("wrong size bitmap")
+
+// ErrNotEnoughSignatures signals that the public keys bitmap does not reach the consensus threshold
+var ErrNotEnoughSignatures = errors.New("not enough signatures")
+
+// ErrNilAggregatedSignature signals that the proof does not hold an aggregated signature
+var ErrNilAggregatedSignature = errors.New("nil aggregated signature")
+
+// ErrProofHeaderMismatch signals that the proof does not match the header
+var ErrProofHeaderMismatch = errors.New("proof does not match the header")
+
+// ErrEmptyHeaderBytes signals that empty header bytes have been provided
+var ErrEmptyHeaderBytes = errors.New("empty header bytes")
+
+// ErrHeaderTypeAlreadyRegistered signals that the header type is already registered
+var ErrHeaderTypeAlreadyRegistered = errors.New("header type already registered")
+
+// ErrCannotDecodeHeader signals that the header bytes could not be decoded as any of the known header types
+var ErrCannotDecodeHeader = errors.New("cannot decode header")
+
+// ErrEmptyHeaderHash signals that an empty header hash has been provided
+var ErrEmptyHeaderHash = errors.New("empty header hash")
+
+// ErrShardHeaderNotNotarized signals that the shard header was not notarized by any of the indexed meta blocks
+var ErrShardHeaderNotNotarized = errors.New("shard header not notarized")
+
+// ErrInvalidNumberOfShards signals that an invalid number of shards has been provided
+var ErrInvalidNumberOfShards = errors.New("invalid number of shards")
+
+// ErrInvalidNumMetaBlocksToKeep signals that an invalid number of meta blocks to keep has been provided
+var ErrInvalidNumMetaBlocksToKeep = errors.New("invalid number of meta blocks to keep")
+
+// ErrNotEpochStartBlock signals that the provided header is not a start of epoch block
+var ErrNotEpochStartBlock = errors.New("not an epoch start block")
+
+// ErrNilEpochStartData signals that the epoch start data is missing
+var ErrNilEpochStartData = errors.New("nil epoch start data")
+
+// ErrInvalidEpochStartShardData signals that the epoch start last finalized headers are not valid
+var ErrInvalidEpochStartShardData = errors.New("invalid epoch start shard data")
+
+// ErrInvalidEpochStartEconomics signals that the epoch start economics data is not valid
+var ErrInvalidEpochStartEconomics = errors.New("invalid epoch start economics")
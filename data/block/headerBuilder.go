@@ -0,0 +1,223 @@
+package block
+
+import (
+	"fmt"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data"
+)
+
+// headerBuilder fills the chain related fields and the miniBlock headers of a header in a consistent way.
+// It works on a shallow clone of the provided header, the first error encountered being returned on Build
+type headerBuilder struct {
+	header          data.HeaderHandler
+	prevHeader      data.HeaderHandler
+	body            *Body
+	miniBlockHashes [][]byte
+	err             error
+}
+
+// NewHeaderBuilder creates a new header builder starting from the provided header, which can be any of
+// Header, HeaderV2 or MetaBlock
+func NewHeaderBuilder(header data.HeaderHandler) (*headerBuilder, error) {
+	if check.IfNil(header) {
+		return nil, ErrNilHeaderHandler
+	}
+
+	return &headerBuilder{
+		header: header.ShallowClone(),
+	}, nil
+}
+
+// WithPrevHeader links the header to the provided previous header: it sets the nonce, the previous hash,
+// the previous random seed and the epoch
+func (hb *headerBuilder) WithPrevHeader(prevHeader data.HeaderHandler, prevHeaderHash []byte) *headerBuilder {
+	if hb.err != nil {
+		return hb
+	}
+	if check.IfNil(prevHeader) {
+		hb.err = fmt.Errorf("%w for previous header", ErrNilHeaderHandler)
+		return hb
+	}
+
+	hb.prevHeader = prevHeader
+	hb.setErr(hb.header.SetNonce(prevHeader.GetNonce() + 1))
+	hb.setErr(hb.header.SetPrevHash(prevHeaderHash))
+	hb.setErr(hb.header.SetPrevRandSeed(prevHeader.GetRandSeed()))
+	hb.setErr(hb.header.SetEpoch(prevHeader.GetEpoch()))
+
+	return hb
+}
+
+// WithRound sets the header's round
+func (hb *headerBuilder) WithRound(round uint64) *headerBuilder {
+	if hb.err != nil {
+		return hb
+	}
+
+	hb.setErr(hb.header.SetRound(round))
+	return hb
+}
+
+// WithEpoch sets the header's epoch. It should be called after WithPrevHeader when starting a new epoch
+func (hb *headerBuilder) WithEpoch(epoch uint32) *headerBuilder {
+	if hb.err != nil {
+		return hb
+	}
+
+	hb.setErr(hb.header.SetEpoch(epoch))
+	return hb
+}
+
+// WithBody sets the block body along with the already computed miniBlock hashes, in the same order
+// as the body's miniBlocks
+func (hb *headerBuilder) WithBody(body *Body, miniBlockHashes [][]byte) *headerBuilder {
+	if hb.err != nil {
+		return hb
+	}
+	if body == nil {
+		hb.err = ErrNilBody
+		return hb
+	}
+	if len(body.MiniBlocks) != len(miniBlockHashes) {
+		hb.err = fmt.Errorf("%w, %d miniBlocks, %d hashes", ErrMiniBlockHashesMismatch, len(body.MiniBlocks), len(miniBlockHashes))
+		return hb
+	}
+
+	hb.body = body
+	hb.miniBlockHashes = miniBlockHashes
+	return hb
+}
+
+// Build populates the miniBlock headers and the transactions count from the provided body, then validates the
+// resulting header and returns it
+func (hb *headerBuilder) Build() (data.HeaderHandler, error) {
+	if hb.err != nil {
+		return nil, hb.err
+	}
+
+	if hb.body != nil {
+		err := hb.setMiniBlockHeaders()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := ValidateHeader(hb.header)
+	if err != nil {
+		return nil, err
+	}
+
+	if !check.IfNil(hb.prevHeader) {
+		err = ValidateHeaderAgainstPrevious(hb.header, hb.prevHeader)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return hb.header, nil
+}
+
+func (hb *headerBuilder) setMiniBlockHeaders() error {
+	withReserved := core.GetHeaderType(hb.header) != core.ShardHeaderV1
+	mbHeaderHandlers, txCount, err := CreateMiniBlockHeaderHandlers(hb.body, hb.miniBlockHashes, withReserved)
+	if err != nil {
+		return err
+	}
+
+	err = hb.header.SetMiniBlockHeaderHandlers(mbHeaderHandlers)
+	if err != nil {
+		return err
+	}
+
+	return hb.header.SetTxCount(txCount + getShardInfoTxCount(hb.header))
+}
+
+func (hb *headerBuilder) setErr(err error) {
+	if hb.err == nil {
+		hb.err = err
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (hb *headerBuilder) IsInterfaceNil() bool {
+	return hb == nil
+}
+
+// CreateMiniBlockHeaderHandlers creates the miniBlock headers for the provided body and returns them along with
+// the total number of transactions. If withReserved is set, the reserved field of each miniBlock header will hold
+// the processing type, the final construction state and the indexes of the first and last processed transactions
+func CreateMiniBlockHeaderHandlers(
+	body *Body,
+	miniBlockHashes [][]byte,
+	withReserved bool,
+) ([]data.MiniBlockHeaderHandler, uint32, error) {
+	if body == nil {
+		return nil, 0, ErrNilBody
+	}
+	if len(body.MiniBlocks) != len(miniBlockHashes) {
+		return nil, 0, fmt.Errorf("%w, %d miniBlocks, %d hashes", ErrMiniBlockHashesMismatch, len(body.MiniBlocks), len(miniBlockHashes))
+	}
+
+	txCount := uint32(0)
+	mbHeaderHandlers := make([]data.MiniBlockHeaderHandler, 0, len(body.MiniBlocks))
+	for i, miniBlock := range body.MiniBlocks {
+		if miniBlock == nil {
+			return nil, 0, fmt.Errorf("%w at index %d", ErrNilMiniBlock, i)
+		}
+
+		mbHeader := &MiniBlockHeader{
+			Hash:            miniBlockHashes[i],
+			SenderShardID:   miniBlock.SenderShardID,
+			ReceiverShardID: miniBlock.ReceiverShardID,
+			TxCount:         uint32(len(miniBlock.TxHashes)),
+			Type:            miniBlock.Type,
+		}
+
+		if withReserved {
+			err := setMiniBlockHeaderReserved(mbHeader, miniBlock)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+
+		txCount += mbHeader.TxCount
+		mbHeaderHandlers = append(mbHeaderHandlers, mbHeader)
+	}
+
+	return mbHeaderHandlers, txCount, nil
+}
+
+func setMiniBlockHeaderReserved(mbHeader *MiniBlockHeader, miniBlock *MiniBlock) error {
+	err := mbHeader.SetProcessingType(miniBlock.GetProcessingType())
+	if err != nil {
+		return err
+	}
+
+	err = mbHeader.SetConstructionState(int32(Final))
+	if err != nil {
+		return err
+	}
+
+	err = mbHeader.SetIndexOfFirstTxProcessed(0)
+	if err != nil {
+		return err
+	}
+
+	return mbHeader.SetIndexOfLastTxProcessed(int32(mbHeader.TxCount) - 1)
+}
+
+func getShardInfoTxCount(header data.HeaderHandler) uint32 {
+	metaHeader, ok := header.(data.MetaHeaderHandler)
+	if !ok {
+		return 0
+	}
+
+	txCount := uint32(0)
+	for _, shardData := range metaHeader.GetShardInfoHandlers() {
+		txCount += shardData.GetTxCount()
+	}
+
+	return txCount
+}
@@ -0,0 +1,400 @@
+package block_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMandatoryFieldsHeader() *block.Header {
+	return &block.Header{
+		Nonce:           10,
+		Round:           12,
+		Epoch:           2,
+		ShardID:         1,
+		PrevHash:        []byte("prev hash"),
+		PrevRandSeed:    []byte("prev rand seed"),
+		RandSeed:        []byte("rand seed"),
+		RootHash:        []byte("root hash"),
+		ChainID:         []byte("chain"),
+		SoftwareVersion: []byte("version"),
+		AccumulatedFees: big.NewInt(0),
+		DeveloperFees:   big.NewInt(0),
+	}
+}
+
+func createMandatoryFieldsHeaderV2() *block.HeaderV2 {
+	return &block.HeaderV2{
+		Header:                   createMandatoryFieldsHeader(),
+		ScheduledAccumulatedFees: big.NewInt(0),
+		ScheduledDeveloperFees:   big.NewInt(0),
+	}
+}
+
+func createMandatoryFieldsMetaBlock() *block.MetaBlock {
+	return &block.MetaBlock{
+		Nonce:                  10,
+		Round:                  12,
+		Epoch:                  2,
+		PrevHash:               []byte("prev hash"),
+		PrevRandSeed:           []byte("prev rand seed"),
+		RandSeed:               []byte("rand seed"),
+		RootHash:               []byte("root hash"),
+		ValidatorStatsRootHash: []byte("validator stats root hash"),
+		ChainID:                []byte("chain"),
+		SoftwareVersion:        []byte("version"),
+		AccumulatedFees:        big.NewInt(0),
+		AccumulatedFeesInEpoch: big.NewInt(0),
+		DeveloperFees:          big.NewInt(0),
+		DevFeesInEpoch:         big.NewInt(0),
+	}
+}
+
+func createBodyAndHashes() (*block.Body, [][]byte) {
+	body := &block.Body{
+		MiniBlocks: []*block.MiniBlock{
+			{
+				TxHashes:        [][]byte{[]byte("tx1"), []byte("tx2")},
+				SenderShardID:   1,
+				ReceiverShardID: 1,
+				Type:            block.TxBlock,
+			},
+			{
+				TxHashes:        [][]byte{[]byte("tx3"), []byte("tx4"), []byte("tx5")},
+				SenderShardID:   0,
+				ReceiverShardID: 1,
+				Type:            block.SmartContractResultBlock,
+			},
+		},
+	}
+
+	return body, [][]byte{[]byte("mb1"), []byte("mb2")}
+}
+
+func TestNewHeaderBuilder(t *testing.T) {
+	t.Parallel()
+
+	hb, err := block.NewHeaderBuilder(nil)
+	assert.Equal(t, block.ErrNilHeaderHandler, err)
+	assert.True(t, check.IfNil(hb))
+
+	hb, err = block.NewHeaderBuilder(createMandatoryFieldsHeader())
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(hb))
+}
+
+func TestHeaderBuilder_Build(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil body should error", func(t *testing.T) {
+		t.Parallel()
+
+		hb, _ := block.NewHeaderBuilder(createMandatoryFieldsHeader())
+		header, err := hb.WithBody(nil, nil).Build()
+		assert.Nil(t, header)
+		assert.Equal(t, block.ErrNilBody, err)
+	})
+	t.Run("miniBlock hashes mismatch should error", func(t *testing.T) {
+		t.Parallel()
+
+		body, hashes := createBodyAndHashes()
+		hb, _ := block.NewHeaderBuilder(createMandatoryFieldsHeader())
+		header, err := hb.WithBody(body, hashes[:1]).Build()
+		assert.Nil(t, header)
+		assert.True(t, errors.Is(err, block.ErrMiniBlockHashesMismatch))
+	})
+	t.Run("nil miniBlock should error", func(t *testing.T) {
+		t.Parallel()
+
+		body, hashes := createBodyAndHashes()
+		body.MiniBlocks[1] = nil
+		hb, _ := block.NewHeaderBuilder(createMandatoryFieldsHeader())
+		header, err := hb.WithBody(body, hashes).Build()
+		assert.Nil(t, header)
+		assert.True(t, errors.Is(err, block.ErrNilMiniBlock))
+	})
+	t.Run("nil previous header should error", func(t *testing.T) {
+		t.Parallel()
+
+		hb, _ := block.NewHeaderBuilder(createMandatoryFieldsHeader())
+		header, err := hb.WithPrevHeader(nil, nil).WithRound(20).Build()
+		assert.Nil(t, header)
+		assert.True(t, errors.Is(err, block.ErrNilHeaderHandler))
+	})
+	t.Run("miniBlock not involving the header's shard should error", func(t *testing.T) {
+		t.Parallel()
+
+		body, hashes := createBodyAndHashes()
+		body.MiniBlocks[1].ReceiverShardID = 2
+		hb, _ := block.NewHeaderBuilder(createMandatoryFieldsHeader())
+		header, err := hb.WithBody(body, hashes).Build()
+		assert.Nil(t, header)
+		assert.True(t, errors.Is(err, block.ErrShardIDMismatch))
+	})
+	t.Run("shard header v1 should not set reserved fields", func(t *testing.T) {
+		t.Parallel()
+
+		original := createMandatoryFieldsHeader()
+		body, hashes := createBodyAndHashes()
+		hb, _ := block.NewHeaderBuilder(original)
+		header, err := hb.WithBody(body, hashes).Build()
+		require.Nil(t, err)
+
+		assert.Equal(t, uint32(5), header.GetTxCount())
+		assert.Equal(t, []block.MiniBlockHeader{
+			{Hash: []byte("mb1"), SenderShardID: 1, ReceiverShardID: 1, TxCount: 2, Type: block.TxBlock},
+			{Hash: []byte("mb2"), SenderShardID: 0, ReceiverShardID: 1, TxCount: 3, Type: block.SmartContractResultBlock},
+		}, header.(*block.Header).MiniBlockHeaders)
+		assert.Empty(t, original.MiniBlockHeaders)
+		assert.Zero(t, original.TxCount)
+	})
+	t.Run("shard header v2 should set reserved fields", func(t *testing.T) {
+		t.Parallel()
+
+		body, hashes := createBodyAndHashes()
+		body.MiniBlocks[0].Reserved, _ = (&block.MiniBlockReserved{ExecutionType: block.Scheduled}).Marshal()
+		hb, _ := block.NewHeaderBuilder(createMandatoryFieldsHeaderV2())
+		header, err := hb.WithBody(body, hashes).Build()
+		require.Nil(t, err)
+
+		mbHeaders := header.GetMiniBlockHeaderHandlers()
+		require.Equal(t, 2, len(mbHeaders))
+		assert.Equal(t, int32(block.Scheduled), mbHeaders[0].GetProcessingType())
+		assert.Equal(t, int32(block.Normal), mbHeaders[1].GetProcessingType())
+		for _, mbHeader := range mbHeaders {
+			assert.NotEmpty(t, mbHeader.GetReserved())
+			assert.True(t, mbHeader.IsFinal())
+			assert.Equal(t, int32(0), mbHeader.GetIndexOfFirstTxProcessed())
+			assert.Equal(t, int32(mbHeader.GetTxCount())-1, mbHeader.GetIndexOfLastTxProcessed())
+		}
+	})
+	t.Run("meta block should add the shard info transactions", func(t *testing.T) {
+		t.Parallel()
+
+		metaBlock := createMandatoryFieldsMetaBlock()
+		metaBlock.ShardInfo = []block.ShardData{{TxCount: 7}, {TxCount: 3}}
+		body := &block.Body{
+			MiniBlocks: []*block.MiniBlock{
+				{
+					TxHashes:        [][]byte{[]byte("reward")},
+					SenderShardID:   core.MetachainShardId,
+					ReceiverShardID: 0,
+					Type:            block.RewardsBlock,
+				},
+				{
+					TxHashes:        [][]byte{[]byte("peer")},
+					SenderShardID:   core.MetachainShardId,
+					ReceiverShardID: core.AllShardId,
+					Type:            block.PeerBlock,
+				},
+			},
+		}
+		hb, _ := block.NewHeaderBuilder(metaBlock)
+		header, err := hb.WithBody(body, [][]byte{[]byte("mb1"), []byte("mb2")}).Build()
+		require.Nil(t, err)
+
+		assert.Equal(t, uint32(12), header.GetTxCount())
+		assert.Equal(t, 2, len(header.GetMiniBlockHeaderHandlers()))
+	})
+	t.Run("should link to the previous header", func(t *testing.T) {
+		t.Parallel()
+
+		prevHeader := createMandatoryFieldsHeaderV2()
+		body, hashes := createBodyAndHashes()
+		hb, _ := block.NewHeaderBuilder(createMandatoryFieldsHeaderV2())
+		header, err := hb.WithPrevHeader(prevHeader, []byte("prev header hash")).
+			WithRound(15).
+			WithBody(body, hashes).
+			Build()
+		require.Nil(t, err)
+
+		assert.Equal(t, uint64(11), header.GetNonce())
+		assert.Equal(t, uint64(15), header.GetRound())
+		assert.Equal(t, uint32(2), header.GetEpoch())
+		assert.Equal(t, []byte("prev header hash"), header.GetPrevHash())
+		assert.Equal(t, prevHeader.GetRandSeed(), header.GetPrevRandSeed())
+	})
+	t.Run("epoch change on a regular block should error", func(t *testing.T) {
+		t.Parallel()
+
+		hb, _ := block.NewHeaderBuilder(createMandatoryFieldsHeader())
+		header, err := hb.WithPrevHeader(createMandatoryFieldsHeader(), []byte("prev header hash")).
+			WithRound(15).
+			WithEpoch(3).
+			Build()
+		assert.Nil(t, header)
+		assert.True(t, errors.Is(err, block.ErrEpochStartMismatch))
+	})
+	t.Run("epoch start block should work", func(t *testing.T) {
+		t.Parallel()
+
+		startOfEpochHeader := createMandatoryFieldsHeader()
+		startOfEpochHeader.EpochStartMetaHash = []byte("epoch start meta hash")
+		hb, _ := block.NewHeaderBuilder(startOfEpochHeader)
+		header, err := hb.WithPrevHeader(createMandatoryFieldsHeader(), []byte("prev header hash")).
+			WithRound(15).
+			WithEpoch(3).
+			Build()
+		require.Nil(t, err)
+		assert.Equal(t, uint32(3), header.GetEpoch())
+	})
+}
+
+func TestValidateHeader(t *testing.T) {
+	t.Parallel()
+
+	createHeader := func() *block.Header {
+		header := createMandatoryFieldsHeader()
+		header.TxCount = 5
+		header.MiniBlockHeaders = []block.MiniBlockHeader{
+			{Hash: []byte("mb1"), SenderShardID: 1, ReceiverShardID: 1, TxCount: 2},
+			{Hash: []byte("mb2"), SenderShardID: 0, ReceiverShardID: 1, TxCount: 3},
+		}
+
+		return header
+	}
+
+	t.Run("nil header should error", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, block.ErrNilHeaderHandler, block.ValidateHeader(nil))
+	})
+	t.Run("missing mandatory field should error", func(t *testing.T) {
+		t.Parallel()
+
+		header := createHeader()
+		header.RootHash = nil
+		assert.True(t, errors.Is(block.ValidateHeader(header), data.ErrNilValue))
+	})
+	t.Run("tx count mismatch should error", func(t *testing.T) {
+		t.Parallel()
+
+		header := createHeader()
+		header.TxCount = 6
+		assert.True(t, errors.Is(block.ValidateHeader(header), block.ErrTxCountMismatch))
+	})
+	t.Run("empty miniBlock hash should error", func(t *testing.T) {
+		t.Parallel()
+
+		header := createHeader()
+		header.MiniBlockHeaders[1].Hash = nil
+		assert.True(t, errors.Is(block.ValidateHeader(header), block.ErrInvalidMiniBlockHeader))
+	})
+	t.Run("invalid processed txs range should error", func(t *testing.T) {
+		t.Parallel()
+
+		header := createHeader()
+		_ = header.MiniBlockHeaders[1].SetConstructionState(int32(block.PartialExecuted))
+		_ = header.MiniBlockHeaders[1].SetIndexOfFirstTxProcessed(2)
+		_ = header.MiniBlockHeaders[1].SetIndexOfLastTxProcessed(1)
+		assert.True(t, errors.Is(block.ValidateHeader(header), block.ErrInvalidMiniBlockHeader))
+
+		_ = header.MiniBlockHeaders[1].SetIndexOfFirstTxProcessed(0)
+		_ = header.MiniBlockHeaders[1].SetIndexOfLastTxProcessed(3)
+		assert.True(t, errors.Is(block.ValidateHeader(header), block.ErrInvalidMiniBlockHeader))
+	})
+	t.Run("final miniBlock not fully processed should error", func(t *testing.T) {
+		t.Parallel()
+
+		header := createHeader()
+		_ = header.MiniBlockHeaders[1].SetConstructionState(int32(block.Final))
+		_ = header.MiniBlockHeaders[1].SetIndexOfLastTxProcessed(1)
+		assert.True(t, errors.Is(block.ValidateHeader(header), block.ErrInvalidMiniBlockHeader))
+	})
+	t.Run("partially executed miniBlock should work", func(t *testing.T) {
+		t.Parallel()
+
+		header := createHeader()
+		_ = header.MiniBlockHeaders[1].SetConstructionState(int32(block.PartialExecuted))
+		_ = header.MiniBlockHeaders[1].SetIndexOfFirstTxProcessed(0)
+		_ = header.MiniBlockHeaders[1].SetIndexOfLastTxProcessed(1)
+		assert.Nil(t, block.ValidateHeader(header))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Nil(t, block.ValidateHeader(createHeader()))
+	})
+}
+
+func TestValidateHeaderAgainstPrevious(t *testing.T) {
+	t.Parallel()
+
+	createHeaders := func() (*block.Header, *block.Header) {
+		header := createMandatoryFieldsHeader()
+		header.Nonce = 11
+		header.Round = 14
+
+		return header, createMandatoryFieldsHeader()
+	}
+
+	t.Run("nil headers should error", func(t *testing.T) {
+		t.Parallel()
+
+		header, prevHeader := createHeaders()
+		assert.Equal(t, block.ErrNilHeaderHandler, block.ValidateHeaderAgainstPrevious(nil, prevHeader))
+		assert.Equal(t, block.ErrNilHeaderHandler, block.ValidateHeaderAgainstPrevious(header, nil))
+	})
+	t.Run("different shard should error", func(t *testing.T) {
+		t.Parallel()
+
+		header, prevHeader := createHeaders()
+		header.ShardID = 0
+		assert.True(t, errors.Is(block.ValidateHeaderAgainstPrevious(header, prevHeader), block.ErrShardIDMismatch))
+	})
+	t.Run("wrong nonce should error", func(t *testing.T) {
+		t.Parallel()
+
+		header, prevHeader := createHeaders()
+		header.Nonce = 12
+		assert.True(t, errors.Is(block.ValidateHeaderAgainstPrevious(header, prevHeader), block.ErrWrongNonce))
+	})
+	t.Run("same round should error", func(t *testing.T) {
+		t.Parallel()
+
+		header, prevHeader := createHeaders()
+		header.Round = prevHeader.Round
+		assert.True(t, errors.Is(block.ValidateHeaderAgainstPrevious(header, prevHeader), block.ErrLowerRoundInBlock))
+	})
+	t.Run("skipped epoch should error", func(t *testing.T) {
+		t.Parallel()
+
+		header, prevHeader := createHeaders()
+		header.Epoch = 4
+		header.EpochStartMetaHash = []byte("epoch start meta hash")
+		assert.True(t, errors.Is(block.ValidateHeaderAgainstPrevious(header, prevHeader), block.ErrWrongEpoch))
+	})
+	t.Run("start of epoch block in the same epoch should error", func(t *testing.T) {
+		t.Parallel()
+
+		header, prevHeader := createHeaders()
+		header.EpochStartMetaHash = []byte("epoch start meta hash")
+		assert.True(t, errors.Is(block.ValidateHeaderAgainstPrevious(header, prevHeader), block.ErrEpochStartMismatch))
+	})
+	t.Run("meta block epoch start should work", func(t *testing.T) {
+		t.Parallel()
+
+		prevMetaBlock := createMandatoryFieldsMetaBlock()
+		metaBlock := createMandatoryFieldsMetaBlock()
+		metaBlock.Nonce = 11
+		metaBlock.Round = 13
+		metaBlock.Epoch = 3
+		assert.True(t, errors.Is(block.ValidateHeaderAgainstPrevious(metaBlock, prevMetaBlock), block.ErrEpochStartMismatch))
+
+		metaBlock.EpochStart.LastFinalizedHeaders = []block.EpochStartShardData{{ShardID: 0}}
+		assert.Nil(t, block.ValidateHeaderAgainstPrevious(metaBlock, prevMetaBlock))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		header, prevHeader := createHeaders()
+		assert.Nil(t, block.ValidateHeaderAgainstPrevious(header, prevHeader))
+	})
+}
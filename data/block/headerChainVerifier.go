@@ -0,0 +1,115 @@
+package block
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data"
+	"github.com/multiversx/mx-chain-core-go/hashing"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+)
+
+// BrokenLinkError defines the error returned when a headers chain is broken. It holds the index and the hash of the
+// first header which does not correctly link to its predecessor, while the reason can be checked with errors.Is
+type BrokenLinkError struct {
+	Index  int
+	Hash   []byte
+	Reason error
+}
+
+// Error returns the error as string
+func (e *BrokenLinkError) Error() string {
+	return fmt.Sprintf("broken headers chain at index %d, hash %s: %s", e.Index, hex.EncodeToString(e.Hash), e.Reason.Error())
+}
+
+// Unwrap returns the reason of the broken link
+func (e *BrokenLinkError) Unwrap() error {
+	return e.Reason
+}
+
+// ArgsHeaderChainVerifier holds the components needed to create a new header chain verifier
+type ArgsHeaderChainVerifier struct {
+	Marshaller marshal.Marshalizer
+	Hasher     hashing.Hasher
+}
+
+type headerChainVerifier struct {
+	marshaller marshal.Marshalizer
+	hasher     hashing.Hasher
+}
+
+// NewHeaderChainVerifier creates a new header chain verifier
+func NewHeaderChainVerifier(args ArgsHeaderChainVerifier) (*headerChainVerifier, error) {
+	if check.IfNil(args.Marshaller) {
+		return nil, data.ErrNilMarshalizer
+	}
+	if check.IfNil(args.Hasher) {
+		return nil, ErrNilHasher
+	}
+
+	return &headerChainVerifier{
+		marshaller: args.Marshaller,
+		hasher:     args.Hasher,
+	}, nil
+}
+
+// VerifyChain checks that the provided headers, sorted ascending by nonce, form a valid chain: each header should
+// hash to the provided hash and should link to its predecessor through the previous hash, the random seed, the nonce,
+// the round and the epoch. Both shard and meta chains are supported. The first broken link is returned
+// as a *BrokenLinkError
+func (hcv *headerChainVerifier) VerifyChain(headers []data.HeaderHandler, hashes [][]byte) error {
+	if len(headers) != len(hashes) {
+		return fmt.Errorf("%w, %d headers, %d hashes", ErrHeadersHashesMismatch, len(headers), len(hashes))
+	}
+
+	for i := range headers {
+		err := hcv.verifyLink(headers, hashes, i)
+		if err != nil {
+			return &BrokenLinkError{
+				Index:  i,
+				Hash:   hashes[i],
+				Reason: err,
+			}
+		}
+	}
+
+	return nil
+}
+
+func (hcv *headerChainVerifier) verifyLink(headers []data.HeaderHandler, hashes [][]byte, index int) error {
+	header := headers[index]
+	if check.IfNil(header) {
+		return ErrNilHeaderHandler
+	}
+
+	computedHash, err := core.CalculateHash(hcv.marshaller, hcv.hasher, header)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(computedHash, hashes[index]) {
+		return fmt.Errorf("%w, computed %s", ErrHeaderHashMismatch, hex.EncodeToString(computedHash))
+	}
+
+	if index == 0 {
+		return nil
+	}
+
+	prevHeader := headers[index-1]
+	if !bytes.Equal(header.GetPrevHash(), hashes[index-1]) {
+		return fmt.Errorf("%w, header %s, previous %s",
+			ErrPrevHashMismatch, hex.EncodeToString(header.GetPrevHash()), hex.EncodeToString(hashes[index-1]))
+	}
+	if !bytes.Equal(header.GetPrevRandSeed(), prevHeader.GetRandSeed()) {
+		return ErrRandSeedMismatch
+	}
+
+	return ValidateHeaderAgainstPrevious(header, prevHeader)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (hcv *headerChainVerifier) IsInterfaceNil() bool {
+	return hcv == nil
+}
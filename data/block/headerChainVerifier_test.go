@@ -0,0 +1,182 @@
+package block_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-core-go/data/mock"
+	"github.com/multiversx/mx-chain-core-go/marshal/factory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMockArgsHeaderChainVerifier() block.ArgsHeaderChainVerifier {
+	marshaller, _ := factory.NewMarshalizer(factory.GogoProtobuf)
+
+	return block.ArgsHeaderChainVerifier{
+		Marshaller: marshaller,
+		Hasher:     &mock.HasherMock{},
+	}
+}
+
+func createHeadersChain(t *testing.T, first data.HeaderHandler, numHeaders int) ([]data.HeaderHandler, [][]byte) {
+	args := createMockArgsHeaderChainVerifier()
+
+	headers := []data.HeaderHandler{first}
+	firstHash, err := core.CalculateHash(args.Marshaller, args.Hasher, first)
+	require.Nil(t, err)
+	hashes := [][]byte{firstHash}
+
+	for i := 1; i < numHeaders; i++ {
+		prevHeader := headers[i-1]
+		header := prevHeader.ShallowClone()
+		_ = header.SetRandSeed([]byte{byte(i)})
+		_ = header.SetPrevRandSeed(prevHeader.GetRandSeed())
+		_ = header.SetPrevHash(hashes[i-1])
+		_ = header.SetNonce(prevHeader.GetNonce() + 1)
+		_ = header.SetRound(prevHeader.GetRound() + 2)
+
+		hash, errCalculate := core.CalculateHash(args.Marshaller, args.Hasher, header)
+		require.Nil(t, errCalculate)
+
+		headers = append(headers, header)
+		hashes = append(hashes, hash)
+	}
+
+	return headers, hashes
+}
+
+func rehashHeaders(t *testing.T, headers []data.HeaderHandler, hashes [][]byte) {
+	args := createMockArgsHeaderChainVerifier()
+	for i, header := range headers {
+		hash, err := core.CalculateHash(args.Marshaller, args.Hasher, header)
+		require.Nil(t, err)
+		hashes[i] = hash
+	}
+}
+
+func TestNewHeaderChainVerifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil marshaller should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsHeaderChainVerifier()
+		args.Marshaller = nil
+		hcv, err := block.NewHeaderChainVerifier(args)
+		assert.Equal(t, data.ErrNilMarshalizer, err)
+		assert.True(t, check.IfNil(hcv))
+	})
+	t.Run("nil hasher should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsHeaderChainVerifier()
+		args.Hasher = nil
+		hcv, err := block.NewHeaderChainVerifier(args)
+		assert.Equal(t, block.ErrNilHasher, err)
+		assert.True(t, check.IfNil(hcv))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		hcv, err := block.NewHeaderChainVerifier(createMockArgsHeaderChainVerifier())
+		assert.Nil(t, err)
+		assert.False(t, check.IfNil(hcv))
+	})
+}
+
+func TestHeaderChainVerifier_VerifyChain(t *testing.T) {
+	t.Parallel()
+
+	hcv, _ := block.NewHeaderChainVerifier(createMockArgsHeaderChainVerifier())
+
+	checkBrokenLink := func(t *testing.T, err error, expectedIndex int, expectedReason error) {
+		brokenLinkErr := &block.BrokenLinkError{}
+		require.True(t, errors.As(err, &brokenLinkErr))
+		assert.Equal(t, expectedIndex, brokenLinkErr.Index)
+		assert.True(t, errors.Is(err, expectedReason))
+	}
+
+	t.Run("headers and hashes mismatch should error", func(t *testing.T) {
+		t.Parallel()
+
+		headers, hashes := createHeadersChain(t, createMandatoryFieldsHeader(), 3)
+		err := hcv.VerifyChain(headers, hashes[:2])
+		assert.True(t, errors.Is(err, block.ErrHeadersHashesMismatch))
+	})
+	t.Run("nil header should error", func(t *testing.T) {
+		t.Parallel()
+
+		headers, hashes := createHeadersChain(t, createMandatoryFieldsHeader(), 3)
+		headers[1] = nil
+		checkBrokenLink(t, hcv.VerifyChain(headers, hashes), 1, block.ErrNilHeaderHandler)
+	})
+	t.Run("wrong hash should error", func(t *testing.T) {
+		t.Parallel()
+
+		headers, hashes := createHeadersChain(t, createMandatoryFieldsHeader(), 3)
+		hashes[2] = []byte("wrong hash")
+		err := hcv.VerifyChain(headers, hashes)
+		checkBrokenLink(t, err, 2, block.ErrHeaderHashMismatch)
+		assert.Contains(t, err.Error(), "index 2")
+	})
+	t.Run("broken previous hash should error", func(t *testing.T) {
+		t.Parallel()
+
+		headers, hashes := createHeadersChain(t, createMandatoryFieldsHeader(), 4)
+		_ = headers[1].SetPrevHash([]byte("another hash"))
+		rehashHeaders(t, headers, hashes)
+		checkBrokenLink(t, hcv.VerifyChain(headers, hashes), 1, block.ErrPrevHashMismatch)
+	})
+	t.Run("broken random seed should error", func(t *testing.T) {
+		t.Parallel()
+
+		headers, hashes := createHeadersChain(t, createMandatoryFieldsHeader(), 4)
+		_ = headers[2].SetPrevRandSeed([]byte("another seed"))
+		hashes[2], _ = core.CalculateHash(createMockArgsHeaderChainVerifier().Marshaller, &mock.HasherMock{}, headers[2])
+		_ = headers[3].SetPrevHash(hashes[2])
+		hashes[3], _ = core.CalculateHash(createMockArgsHeaderChainVerifier().Marshaller, &mock.HasherMock{}, headers[3])
+		checkBrokenLink(t, hcv.VerifyChain(headers, hashes), 2, block.ErrRandSeedMismatch)
+	})
+	t.Run("wrong round should error", func(t *testing.T) {
+		t.Parallel()
+
+		headers, hashes := createHeadersChain(t, createMandatoryFieldsMetaBlock(), 3)
+		_ = headers[2].SetRound(headers[1].GetRound())
+		hashes[2], _ = core.CalculateHash(createMockArgsHeaderChainVerifier().Marshaller, &mock.HasherMock{}, headers[2])
+		checkBrokenLink(t, hcv.VerifyChain(headers, hashes), 2, block.ErrLowerRoundInBlock)
+	})
+	t.Run("epoch change without start of epoch block should error", func(t *testing.T) {
+		t.Parallel()
+
+		headers, hashes := createHeadersChain(t, createMandatoryFieldsMetaBlock(), 2)
+		_ = headers[1].SetEpoch(3)
+		hashes[1], _ = core.CalculateHash(createMockArgsHeaderChainVerifier().Marshaller, &mock.HasherMock{}, headers[1])
+		checkBrokenLink(t, hcv.VerifyChain(headers, hashes), 1, block.ErrEpochStartMismatch)
+	})
+	t.Run("empty chain should work", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Nil(t, hcv.VerifyChain(nil, nil))
+	})
+	t.Run("shard chain should work", func(t *testing.T) {
+		t.Parallel()
+
+		headers, hashes := createHeadersChain(t, createMandatoryFieldsHeaderV2(), 5)
+		assert.Nil(t, hcv.VerifyChain(headers, hashes))
+	})
+	t.Run("meta chain with epoch change should work", func(t *testing.T) {
+		t.Parallel()
+
+		headers, hashes := createHeadersChain(t, createMandatoryFieldsMetaBlock(), 3)
+		metaBlock := headers[2].(*block.MetaBlock)
+		metaBlock.Epoch = 3
+		metaBlock.EpochStart.LastFinalizedHeaders = []block.EpochStartShardData{{ShardID: 0}}
+		hashes[2], _ = core.CalculateHash(createMockArgsHeaderChainVerifier().Marshaller, &mock.HasherMock{}, metaBlock)
+		assert.Nil(t, hcv.VerifyChain(headers, hashes))
+	})
+}
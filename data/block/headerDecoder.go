@@ -0,0 +1,183 @@
+package block
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+)
+
+type registeredHeaderType struct {
+	headerType  core.HeaderType
+	creator     EmptyBlockCreator
+	reflectType reflect.Type
+}
+
+// headerDecoder decodes header bytes into the right data.HeaderHandler implementation. The known header types are
+// held in a registry, the detection being tried in the registration order
+type headerDecoder struct {
+	marshaller marshal.Marshalizer
+	mut        sync.RWMutex
+	registered []*registeredHeaderType
+}
+
+// NewHeaderDecoder creates a new header decoder with the HeaderV2, MetaBlock and Header types already registered
+func NewHeaderDecoder(marshaller marshal.Marshalizer) (*headerDecoder, error) {
+	if check.IfNil(marshaller) {
+		return nil, data.ErrNilMarshalizer
+	}
+
+	hd := &headerDecoder{
+		marshaller: marshaller,
+		registered: make([]*registeredHeaderType, 0),
+	}
+
+	defaultCreators := []struct {
+		headerType core.HeaderType
+		creator    EmptyBlockCreator
+	}{
+		{headerType: core.ShardHeaderV2, creator: NewEmptyHeaderV2Creator()},
+		{headerType: core.MetaHeader, creator: NewEmptyMetaBlockCreator()},
+		{headerType: core.ShardHeaderV1, creator: NewEmptyHeaderCreator()},
+	}
+	for _, dc := range defaultCreators {
+		err := hd.RegisterHeaderType(dc.headerType, dc.creator)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return hd, nil
+}
+
+// RegisterHeaderType adds a new header type to the registry. The types registered later are tried last
+// when detecting the type of the header bytes
+func (hd *headerDecoder) RegisterHeaderType(headerType core.HeaderType, creator EmptyBlockCreator) error {
+	if check.IfNil(creator) {
+		return data.ErrNilEmptyBlockCreator
+	}
+
+	hd.mut.Lock()
+	defer hd.mut.Unlock()
+
+	for _, rht := range hd.registered {
+		if rht.headerType == headerType {
+			return fmt.Errorf("%w, type %s", ErrHeaderTypeAlreadyRegistered, headerType)
+		}
+	}
+
+	hd.registered = append(hd.registered, &registeredHeaderType{
+		headerType:  headerType,
+		creator:     creator,
+		reflectType: reflect.TypeOf(creator.CreateNewHeader()),
+	})
+
+	return nil
+}
+
+// Decode unmarshalls the header bytes. If the header type hint is provided, only that type is used, otherwise
+// all registered types are tried and the first one that decodes the bytes without losing information is returned
+func (hd *headerDecoder) Decode(headerBytes []byte, headerTypeHint core.HeaderType) (data.HeaderHandler, core.HeaderType, error) {
+	if len(headerBytes) == 0 {
+		return nil, "", ErrEmptyHeaderBytes
+	}
+
+	hd.mut.RLock()
+	registered := make([]*registeredHeaderType, len(hd.registered))
+	copy(registered, hd.registered)
+	hd.mut.RUnlock()
+
+	if len(headerTypeHint) > 0 {
+		rht, err := getRegisteredHeaderType(registered, headerTypeHint)
+		if err != nil {
+			return nil, "", err
+		}
+
+		header, err := GetHeaderFromBytes(hd.marshaller, rht.creator, headerBytes)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return header, rht.headerType, nil
+	}
+
+	for _, rht := range registered {
+		header, ok := hd.tryDecode(rht.creator, headerBytes)
+		if ok {
+			return header, rht.headerType, nil
+		}
+	}
+
+	return nil, "", ErrCannotDecodeHeader
+}
+
+// tryDecode considers the decoding successful only if marshalling the resulting header gives back the same bytes,
+// so that a type which silently drops or reinterprets fields is not selected
+func (hd *headerDecoder) tryDecode(creator EmptyBlockCreator, headerBytes []byte) (data.HeaderHandler, bool) {
+	header, err := GetHeaderFromBytes(hd.marshaller, creator, headerBytes)
+	if err != nil {
+		return nil, false
+	}
+
+	marshalledHeader, err := hd.marshaller.Marshal(header)
+	if err != nil {
+		return nil, false
+	}
+
+	return header, bytes.Equal(marshalledHeader, headerBytes)
+}
+
+// Encode marshals the provided header and returns its registered header type
+func (hd *headerDecoder) Encode(header data.HeaderHandler) ([]byte, core.HeaderType, error) {
+	headerType, err := hd.GetHeaderType(header)
+	if err != nil {
+		return nil, "", err
+	}
+
+	headerBytes, err := hd.marshaller.Marshal(header)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return headerBytes, headerType, nil
+}
+
+// GetHeaderType returns the registered header type of the provided header
+func (hd *headerDecoder) GetHeaderType(header data.HeaderHandler) (core.HeaderType, error) {
+	if check.IfNil(header) {
+		return "", ErrNilHeaderHandler
+	}
+
+	headerReflectType := reflect.TypeOf(header)
+
+	hd.mut.RLock()
+	defer hd.mut.RUnlock()
+
+	for _, rht := range hd.registered {
+		if rht.reflectType == headerReflectType {
+			return rht.headerType, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w, %s", data.ErrInvalidHeaderType, headerReflectType.String())
+}
+
+func getRegisteredHeaderType(registered []*registeredHeaderType, headerType core.HeaderType) (*registeredHeaderType, error) {
+	for _, rht := range registered {
+		if rht.headerType == headerType {
+			return rht, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w, %s", data.ErrInvalidHeaderType, headerType)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (hd *headerDecoder) IsInterfaceNil() bool {
+	return hd == nil
+}
@@ -0,0 +1,152 @@
+package block_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-core-go/data/mock"
+	"github.com/multiversx/mx-chain-core-go/marshal/factory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type customHeader struct {
+	block.Header
+}
+
+func createHeadersOfAllTypes() map[core.HeaderType]data.HeaderHandler {
+	header := createMandatoryFieldsHeader()
+	header.MiniBlockHeaders = []block.MiniBlockHeader{{Hash: []byte("mb"), TxCount: 2, SenderShardID: 1}}
+	header.MetaBlockHashes = [][]byte{[]byte("meta hash")}
+	header.TxCount = 2
+
+	headerV2 := createMandatoryFieldsHeaderV2()
+	headerV2.ScheduledRootHash = []byte("scheduled root hash")
+	headerV2.ScheduledGasProvided = 100
+	headerV2.ScheduledAccumulatedFees = big.NewInt(10)
+
+	metaBlock := createMandatoryFieldsMetaBlock()
+	metaBlock.ShardInfo = []block.ShardData{{HeaderHash: []byte("shard hash"), ShardID: 1, TxCount: 3}}
+	metaBlock.MiniBlockHeaders = []block.MiniBlockHeader{{Hash: []byte("mb"), TxCount: 1}}
+
+	return map[core.HeaderType]data.HeaderHandler{
+		core.ShardHeaderV1: header,
+		core.ShardHeaderV2: headerV2,
+		core.MetaHeader:    metaBlock,
+	}
+}
+
+func TestNewHeaderDecoder(t *testing.T) {
+	t.Parallel()
+
+	hd, err := block.NewHeaderDecoder(nil)
+	assert.Equal(t, data.ErrNilMarshalizer, err)
+	assert.True(t, check.IfNil(hd))
+
+	hd, err = block.NewHeaderDecoder(&mock.MarshalizerMock{})
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(hd))
+}
+
+func TestHeaderDecoder_RegisterHeaderType(t *testing.T) {
+	t.Parallel()
+
+	hd, _ := block.NewHeaderDecoder(&mock.MarshalizerMock{})
+
+	err := hd.RegisterHeaderType("custom", nil)
+	assert.Equal(t, data.ErrNilEmptyBlockCreator, err)
+
+	err = hd.RegisterHeaderType(core.ShardHeaderV1, block.NewEmptyHeaderCreator())
+	assert.True(t, errors.Is(err, block.ErrHeaderTypeAlreadyRegistered))
+
+	customCreator := &mock.EmptyBlockCreatorStub{
+		CreateNewHeaderCalled: func() data.HeaderHandler {
+			return &customHeader{}
+		},
+	}
+	headerType, err := hd.GetHeaderType(&customHeader{})
+	assert.True(t, errors.Is(err, data.ErrInvalidHeaderType))
+	assert.Empty(t, headerType)
+
+	err = hd.RegisterHeaderType("custom", customCreator)
+	require.Nil(t, err)
+
+	headerType, err = hd.GetHeaderType(&customHeader{})
+	assert.Nil(t, err)
+	assert.Equal(t, core.HeaderType("custom"), headerType)
+}
+
+func TestHeaderDecoder_Decode(t *testing.T) {
+	t.Parallel()
+
+	marshaller, _ := factory.NewMarshalizer(factory.GogoProtobuf)
+
+	t.Run("empty bytes should error", func(t *testing.T) {
+		t.Parallel()
+
+		hd, _ := block.NewHeaderDecoder(marshaller)
+		header, headerType, err := hd.Decode(nil, "")
+		assert.Nil(t, header)
+		assert.Empty(t, headerType)
+		assert.Equal(t, block.ErrEmptyHeaderBytes, err)
+	})
+	t.Run("unknown hint should error", func(t *testing.T) {
+		t.Parallel()
+
+		hd, _ := block.NewHeaderDecoder(marshaller)
+		header, _, err := hd.Decode([]byte("bytes"), "unknown")
+		assert.Nil(t, header)
+		assert.True(t, errors.Is(err, data.ErrInvalidHeaderType))
+	})
+	t.Run("wrong hint should error", func(t *testing.T) {
+		t.Parallel()
+
+		hd, _ := block.NewHeaderDecoder(marshaller)
+		headerBytes, _ := marshaller.Marshal(createHeadersOfAllTypes()[core.ShardHeaderV1])
+		header, _, err := hd.Decode(headerBytes, core.ShardHeaderV2)
+		assert.Nil(t, header)
+		assert.NotNil(t, err)
+	})
+	t.Run("garbage bytes should error", func(t *testing.T) {
+		t.Parallel()
+
+		hd, _ := block.NewHeaderDecoder(marshaller)
+		header, _, err := hd.Decode([]byte{0xFF, 0xFF, 0xFF}, "")
+		assert.Nil(t, header)
+		assert.Equal(t, block.ErrCannotDecodeHeader, err)
+	})
+	t.Run("should decode with and without hint", func(t *testing.T) {
+		t.Parallel()
+
+		hd, _ := block.NewHeaderDecoder(marshaller)
+		for expectedType, expectedHeader := range createHeadersOfAllTypes() {
+			headerBytes, headerType, err := hd.Encode(expectedHeader)
+			require.Nil(t, err)
+			assert.Equal(t, expectedType, headerType)
+
+			header, headerType, err := hd.Decode(headerBytes, expectedType)
+			require.Nil(t, err)
+			assert.Equal(t, expectedType, headerType)
+			assert.Equal(t, expectedHeader, header)
+
+			header, headerType, err = hd.Decode(headerBytes, "")
+			require.Nil(t, err, string(expectedType))
+			assert.Equal(t, expectedType, headerType)
+			assert.Equal(t, expectedHeader, header)
+		}
+	})
+	t.Run("encode unknown type should error", func(t *testing.T) {
+		t.Parallel()
+
+		hd, _ := block.NewHeaderDecoder(marshaller)
+		headerBytes, headerType, err := hd.Encode(nil)
+		assert.Nil(t, headerBytes)
+		assert.Empty(t, headerType)
+		assert.Equal(t, block.ErrNilHeaderHandler, err)
+	})
+}
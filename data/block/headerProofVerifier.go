@@ -0,0 +1,160 @@
+package block
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/crypto"
+	"github.com/multiversx/mx-chain-core-go/data"
+)
+
+// IsSignerInBitmap returns true if the consensus member with the provided index is marked as signer in the bitmap.
+// The bit of the member i is the bit i%8 of the byte i/8
+func IsSignerInBitmap(bitmap []byte, index int) bool {
+	if index < 0 || index/8 >= len(bitmap) {
+		return false
+	}
+
+	return bitmap[index/8]&(1<<uint8(index%8)) != 0
+}
+
+// GetSignersIndexes returns the indexes of the consensus members marked as signers in the bitmap,
+// ignoring any bit beyond the consensus size
+func GetSignersIndexes(bitmap []byte, consensusSize int) []int {
+	indexes := make([]int, 0, consensusSize)
+	for i := 0; i < consensusSize; i++ {
+		if IsSignerInBitmap(bitmap, i) {
+			indexes = append(indexes, i)
+		}
+	}
+
+	return indexes
+}
+
+// CountSigners returns the number of consensus members marked as signers in the bitmap
+func CountSigners(bitmap []byte, consensusSize int) int {
+	return len(GetSignersIndexes(bitmap, consensusSize))
+}
+
+// CheckBitmapThreshold verifies that the bitmap has the size required by the consensus size and that
+// the number of signers reaches the pBFT threshold
+func CheckBitmapThreshold(bitmap []byte, consensusSize int) error {
+	if consensusSize <= 0 {
+		return fmt.Errorf("%w, provided %d", ErrInvalidConsensusSize, consensusSize)
+	}
+
+	expectedBitmapSize := (consensusSize + 7) / 8
+	if len(bitmap) != expectedBitmapSize {
+		return fmt.Errorf("%w, provided %d, expected %d", ErrWrongSizeBitmap, len(bitmap), expectedBitmapSize)
+	}
+
+	numSigners := CountSigners(bitmap, consensusSize)
+	threshold := core.GetPBFTThreshold(consensusSize)
+	if numSigners < threshold {
+		return fmt.Errorf("%w, signers %d, threshold %d", ErrNotEnoughSignatures, numSigners, threshold)
+	}
+
+	return nil
+}
+
+// CheckProofMatchesHeader verifies that the header related fields of the proof match the provided header and its hash
+func CheckProofMatchesHeader(proof data.HeaderProofHandler, header data.HeaderHandler, headerHash []byte) error {
+	if check.IfNil(proof) {
+		return ErrNilHeaderProof
+	}
+	if check.IfNil(header) {
+		return ErrNilHeaderHandler
+	}
+
+	if !bytes.Equal(proof.GetHeaderHash(), headerHash) {
+		return fmt.Errorf("%w, header hash: proof %s, header %s",
+			ErrProofHeaderMismatch, hex.EncodeToString(proof.GetHeaderHash()), hex.EncodeToString(headerHash))
+	}
+	if proof.GetHeaderNonce() != header.GetNonce() {
+		return fmt.Errorf("%w, nonce: proof %d, header %d", ErrProofHeaderMismatch, proof.GetHeaderNonce(), header.GetNonce())
+	}
+	if proof.GetHeaderRound() != header.GetRound() {
+		return fmt.Errorf("%w, round: proof %d, header %d", ErrProofHeaderMismatch, proof.GetHeaderRound(), header.GetRound())
+	}
+	if proof.GetHeaderShardId() != header.GetShardID() {
+		return fmt.Errorf("%w, shard: proof %d, header %d", ErrProofHeaderMismatch, proof.GetHeaderShardId(), header.GetShardID())
+	}
+	if proof.GetHeaderEpoch() != header.GetEpoch() {
+		return fmt.Errorf("%w, epoch: proof %d, header %d", ErrProofHeaderMismatch, proof.GetHeaderEpoch(), header.GetEpoch())
+	}
+	if proof.GetIsStartOfEpoch() != header.IsStartOfEpochBlock() {
+		return fmt.Errorf("%w, start of epoch: proof %v, header %v",
+			ErrProofHeaderMismatch, proof.GetIsStartOfEpoch(), header.IsStartOfEpochBlock())
+	}
+
+	return nil
+}
+
+// ArgsHeaderProofVerifier holds the components needed to create a new header proof verifier
+type ArgsHeaderProofVerifier struct {
+	AggregateSignatureVerifier crypto.AggregateSignatureVerifier
+}
+
+type headerProofVerifier struct {
+	aggregateSignatureVerifier crypto.AggregateSignatureVerifier
+}
+
+// NewHeaderProofVerifier creates a new header proof verifier
+func NewHeaderProofVerifier(args ArgsHeaderProofVerifier) (*headerProofVerifier, error) {
+	if check.IfNil(args.AggregateSignatureVerifier) {
+		return nil, ErrNilAggregateSignatureVerifier
+	}
+
+	return &headerProofVerifier{
+		aggregateSignatureVerifier: args.AggregateSignatureVerifier,
+	}, nil
+}
+
+// VerifyProof verifies the provided proof against the public keys of the consensus group, in consensus order:
+// the bitmap should reach the consensus threshold and the aggregated signature should be valid for the header hash
+// and the public keys of the signers
+func (hpv *headerProofVerifier) VerifyProof(proof data.HeaderProofHandler, consensusPubKeys [][]byte) error {
+	if check.IfNil(proof) {
+		return ErrNilHeaderProof
+	}
+	if len(proof.GetAggregatedSignature()) == 0 {
+		return ErrNilAggregatedSignature
+	}
+
+	consensusSize := len(consensusPubKeys)
+	err := CheckBitmapThreshold(proof.GetPubKeysBitmap(), consensusSize)
+	if err != nil {
+		return err
+	}
+
+	signersIndexes := GetSignersIndexes(proof.GetPubKeysBitmap(), consensusSize)
+	signersPubKeys := make([][]byte, 0, len(signersIndexes))
+	for _, index := range signersIndexes {
+		signersPubKeys = append(signersPubKeys, consensusPubKeys[index])
+	}
+
+	return hpv.aggregateSignatureVerifier.VerifyAggregatedSignature(signersPubKeys, proof.GetHeaderHash(), proof.GetAggregatedSignature())
+}
+
+// VerifyProofForHeader checks that the proof matches the provided header and then verifies it
+func (hpv *headerProofVerifier) VerifyProofForHeader(
+	proof data.HeaderProofHandler,
+	header data.HeaderHandler,
+	headerHash []byte,
+	consensusPubKeys [][]byte,
+) error {
+	err := CheckProofMatchesHeader(proof, header, headerHash)
+	if err != nil {
+		return err
+	}
+
+	return hpv.VerifyProof(proof, consensusPubKeys)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (hpv *headerProofVerifier) IsInterfaceNil() bool {
+	return hpv == nil
+}
@@ -0,0 +1,200 @@
+package block_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-core-go/data/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createConsensusPubKeys(size int) [][]byte {
+	pubKeys := make([][]byte, 0, size)
+	for i := 0; i < size; i++ {
+		pubKeys = append(pubKeys, []byte{'p', 'k', byte(i)})
+	}
+
+	return pubKeys
+}
+
+func createProofForHeader(header *block.Header) *block.HeaderProof {
+	return &block.HeaderProof{
+		PubKeysBitmap:       []byte{0xFF, 0x01},
+		AggregatedSignature: []byte("aggregated signature"),
+		HeaderHash:          []byte("header hash"),
+		HeaderEpoch:         header.Epoch,
+		HeaderNonce:         header.Nonce,
+		HeaderShardId:       header.ShardID,
+		HeaderRound:         header.Round,
+	}
+}
+
+func TestBitmapHelpers(t *testing.T) {
+	t.Parallel()
+
+	bitmap := []byte{0x05, 0x82}
+
+	assert.True(t, block.IsSignerInBitmap(bitmap, 0))
+	assert.False(t, block.IsSignerInBitmap(bitmap, 1))
+	assert.True(t, block.IsSignerInBitmap(bitmap, 2))
+	assert.True(t, block.IsSignerInBitmap(bitmap, 9))
+	assert.True(t, block.IsSignerInBitmap(bitmap, 15))
+	assert.False(t, block.IsSignerInBitmap(bitmap, 16))
+	assert.False(t, block.IsSignerInBitmap(bitmap, -1))
+
+	assert.Equal(t, []int{0, 2, 9, 15}, block.GetSignersIndexes(bitmap, 16))
+	assert.Equal(t, []int{0, 2, 9}, block.GetSignersIndexes(bitmap, 10))
+	assert.Equal(t, 3, block.CountSigners(bitmap, 10))
+	assert.Equal(t, 0, block.CountSigners(nil, 10))
+}
+
+func TestCheckBitmapThreshold(t *testing.T) {
+	t.Parallel()
+
+	err := block.CheckBitmapThreshold([]byte{0xFF}, 0)
+	assert.True(t, errors.Is(err, block.ErrInvalidConsensusSize))
+
+	err = block.CheckBitmapThreshold([]byte{0xFF}, 9)
+	assert.True(t, errors.Is(err, block.ErrWrongSizeBitmap))
+
+	// threshold for 9 members is 7
+	err = block.CheckBitmapThreshold([]byte{0x3F, 0x00}, 9)
+	assert.True(t, errors.Is(err, block.ErrNotEnoughSignatures))
+
+	// bits beyond the consensus size are not counted
+	err = block.CheckBitmapThreshold([]byte{0x3F, 0xFE}, 9)
+	assert.True(t, errors.Is(err, block.ErrNotEnoughSignatures))
+
+	assert.Nil(t, block.CheckBitmapThreshold([]byte{0x7F, 0x00}, 9))
+	assert.Nil(t, block.CheckBitmapThreshold([]byte{0x01}, 1))
+}
+
+func TestCheckProofMatchesHeader(t *testing.T) {
+	t.Parallel()
+
+	header := createMandatoryFieldsHeader()
+	headerHash := []byte("header hash")
+
+	assert.Equal(t, block.ErrNilHeaderProof, block.CheckProofMatchesHeader(nil, header, headerHash))
+	assert.Equal(t, block.ErrNilHeaderHandler, block.CheckProofMatchesHeader(createProofForHeader(header), nil, headerHash))
+
+	alterations := map[string]func(proof *block.HeaderProof){
+		"header hash":    func(proof *block.HeaderProof) { proof.HeaderHash = []byte("another hash") },
+		"nonce":          func(proof *block.HeaderProof) { proof.HeaderNonce++ },
+		"round":          func(proof *block.HeaderProof) { proof.HeaderRound++ },
+		"shard":          func(proof *block.HeaderProof) { proof.HeaderShardId++ },
+		"epoch":          func(proof *block.HeaderProof) { proof.HeaderEpoch++ },
+		"start of epoch": func(proof *block.HeaderProof) { proof.IsStartOfEpoch = true },
+	}
+	for field, alter := range alterations {
+		proof := createProofForHeader(header)
+		alter(proof)
+		err := block.CheckProofMatchesHeader(proof, header, headerHash)
+		assert.True(t, errors.Is(err, block.ErrProofHeaderMismatch), field)
+		assert.Contains(t, err.Error(), field)
+	}
+
+	assert.Nil(t, block.CheckProofMatchesHeader(createProofForHeader(header), header, headerHash))
+}
+
+func TestNewHeaderProofVerifier(t *testing.T) {
+	t.Parallel()
+
+	hpv, err := block.NewHeaderProofVerifier(block.ArgsHeaderProofVerifier{})
+	assert.Equal(t, block.ErrNilAggregateSignatureVerifier, err)
+	assert.True(t, check.IfNil(hpv))
+
+	hpv, err = block.NewHeaderProofVerifier(block.ArgsHeaderProofVerifier{
+		AggregateSignatureVerifier: &mock.AggregateSignatureVerifierStub{},
+	})
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(hpv))
+}
+
+func TestHeaderProofVerifier_VerifyProof(t *testing.T) {
+	t.Parallel()
+
+	header := createMandatoryFieldsHeader()
+	consensusPubKeys := createConsensusPubKeys(9)
+
+	t.Run("nil proof should error", func(t *testing.T) {
+		t.Parallel()
+
+		hpv, _ := block.NewHeaderProofVerifier(block.ArgsHeaderProofVerifier{
+			AggregateSignatureVerifier: &mock.AggregateSignatureVerifierStub{},
+		})
+		assert.Equal(t, block.ErrNilHeaderProof, hpv.VerifyProof(nil, consensusPubKeys))
+	})
+	t.Run("missing aggregated signature should error", func(t *testing.T) {
+		t.Parallel()
+
+		hpv, _ := block.NewHeaderProofVerifier(block.ArgsHeaderProofVerifier{
+			AggregateSignatureVerifier: &mock.AggregateSignatureVerifierStub{},
+		})
+		proof := createProofForHeader(header)
+		proof.AggregatedSignature = nil
+		assert.Equal(t, block.ErrNilAggregatedSignature, hpv.VerifyProof(proof, consensusPubKeys))
+	})
+	t.Run("threshold not reached should error", func(t *testing.T) {
+		t.Parallel()
+
+		hpv, _ := block.NewHeaderProofVerifier(block.ArgsHeaderProofVerifier{
+			AggregateSignatureVerifier: &mock.AggregateSignatureVerifierStub{
+				VerifyAggregatedSignatureCalled: func(publicKeys [][]byte, message []byte, aggregatedSignature []byte) error {
+					require.Fail(t, "should not have been called")
+					return nil
+				},
+			},
+		})
+		proof := createProofForHeader(header)
+		proof.PubKeysBitmap = []byte{0x0F, 0x00}
+		err := hpv.VerifyProof(proof, consensusPubKeys)
+		assert.True(t, errors.Is(err, block.ErrNotEnoughSignatures))
+	})
+	t.Run("invalid aggregated signature should error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		hpv, _ := block.NewHeaderProofVerifier(block.ArgsHeaderProofVerifier{
+			AggregateSignatureVerifier: &mock.AggregateSignatureVerifierStub{
+				VerifyAggregatedSignatureCalled: func(publicKeys [][]byte, message []byte, aggregatedSignature []byte) error {
+					return expectedErr
+				},
+			},
+		})
+		assert.Equal(t, expectedErr, hpv.VerifyProof(createProofForHeader(header), consensusPubKeys))
+	})
+	t.Run("proof not matching the header should error", func(t *testing.T) {
+		t.Parallel()
+
+		hpv, _ := block.NewHeaderProofVerifier(block.ArgsHeaderProofVerifier{
+			AggregateSignatureVerifier: &mock.AggregateSignatureVerifierStub{},
+		})
+		err := hpv.VerifyProofForHeader(createProofForHeader(header), header, []byte("another hash"), consensusPubKeys)
+		assert.True(t, errors.Is(err, block.ErrProofHeaderMismatch))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		proof := createProofForHeader(header)
+		proof.PubKeysBitmap = []byte{0xEF, 0x01}
+		hpv, _ := block.NewHeaderProofVerifier(block.ArgsHeaderProofVerifier{
+			AggregateSignatureVerifier: &mock.AggregateSignatureVerifierStub{
+				VerifyAggregatedSignatureCalled: func(publicKeys [][]byte, message []byte, aggregatedSignature []byte) error {
+					expectedPubKeys := [][]byte{
+						consensusPubKeys[0], consensusPubKeys[1], consensusPubKeys[2], consensusPubKeys[3],
+						consensusPubKeys[5], consensusPubKeys[6], consensusPubKeys[7], consensusPubKeys[8],
+					}
+					assert.Equal(t, expectedPubKeys, publicKeys)
+					assert.Equal(t, proof.HeaderHash, message)
+					assert.Equal(t, proof.AggregatedSignature, aggregatedSignature)
+					return nil
+				},
+			},
+		})
+		assert.Nil(t, hpv.VerifyProofForHeader(proof, header, []byte("header hash"), consensusPubKeys))
+	})
+}
@@ -0,0 +1,111 @@
+package block
+
+import (
+	"fmt"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data"
+)
+
+// ValidateHeader checks the provided header's invariants beyond the mandatory fields: the miniBlock headers should
+// be consistent with the header's shard and with their own reserved fields, while the header's transactions count
+// should equal the sum of the miniBlock headers' transactions counts (plus the notarized shard data ones for MetaBlock)
+func ValidateHeader(header data.HeaderHandler) error {
+	if check.IfNil(header) {
+		return ErrNilHeaderHandler
+	}
+
+	err := header.CheckFieldsForNil()
+	if err != nil {
+		return err
+	}
+
+	txCount := uint32(0)
+	for i, mbHeader := range header.GetMiniBlockHeaderHandlers() {
+		err = checkMiniBlockHeader(mbHeader, header.GetShardID())
+		if err != nil {
+			return fmt.Errorf("%w at index %d", err, i)
+		}
+
+		txCount += mbHeader.GetTxCount()
+	}
+
+	txCount += getShardInfoTxCount(header)
+	if txCount != header.GetTxCount() {
+		return fmt.Errorf("%w, header %d, computed %d", ErrTxCountMismatch, header.GetTxCount(), txCount)
+	}
+
+	return nil
+}
+
+func checkMiniBlockHeader(mbHeader data.MiniBlockHeaderHandler, shardID uint32) error {
+	if len(mbHeader.GetHash()) == 0 {
+		return fmt.Errorf("%w, empty hash", ErrInvalidMiniBlockHeader)
+	}
+
+	isShardInvolved := mbHeader.GetSenderShardID() == shardID ||
+		mbHeader.GetReceiverShardID() == shardID ||
+		mbHeader.GetReceiverShardID() == core.AllShardId
+	if !isShardInvolved {
+		return fmt.Errorf("%w, sender %d, receiver %d, header shard %d",
+			ErrShardIDMismatch, mbHeader.GetSenderShardID(), mbHeader.GetReceiverShardID(), shardID)
+	}
+
+	return checkProcessedTxsIndexes(mbHeader)
+}
+
+// checkProcessedTxsIndexes verifies the processed transactions range held in the miniBlock header's reserved field:
+// a partially executed miniBlock should not include its last transaction, while any other should include it
+func checkProcessedTxsIndexes(mbHeader data.MiniBlockHeaderHandler) error {
+	if mbHeader.GetTxCount() == 0 {
+		return nil
+	}
+
+	txCount := int32(mbHeader.GetTxCount())
+	first := mbHeader.GetIndexOfFirstTxProcessed()
+	last := mbHeader.GetIndexOfLastTxProcessed()
+	isIndexRangeValid := first >= 0 && first <= last && last < txCount
+	if !isIndexRangeValid {
+		return fmt.Errorf("%w, processed txs range [%d, %d] for %d txs",
+			ErrInvalidMiniBlockHeader, first, last, txCount)
+	}
+
+	isPartialExecuted := mbHeader.GetConstructionState() == int32(PartialExecuted)
+	isLastTxProcessed := last == txCount-1
+	if isPartialExecuted == isLastTxProcessed {
+		return fmt.Errorf("%w, construction state %d with last processed tx index %d for %d txs",
+			ErrInvalidMiniBlockHeader, mbHeader.GetConstructionState(), last, txCount)
+	}
+
+	return nil
+}
+
+// ValidateHeaderAgainstPrevious checks that the provided header correctly follows the previous one: same shard,
+// consecutive nonces, increasing rounds and an epoch change only on start of epoch blocks
+func ValidateHeaderAgainstPrevious(header data.HeaderHandler, prevHeader data.HeaderHandler) error {
+	if check.IfNil(header) || check.IfNil(prevHeader) {
+		return ErrNilHeaderHandler
+	}
+
+	if header.GetShardID() != prevHeader.GetShardID() {
+		return fmt.Errorf("%w, header %d, previous %d", ErrShardIDMismatch, header.GetShardID(), prevHeader.GetShardID())
+	}
+	if header.GetNonce() != prevHeader.GetNonce()+1 {
+		return fmt.Errorf("%w, header %d, previous %d", ErrWrongNonce, header.GetNonce(), prevHeader.GetNonce())
+	}
+	if header.GetRound() <= prevHeader.GetRound() {
+		return fmt.Errorf("%w, header %d, previous %d", ErrLowerRoundInBlock, header.GetRound(), prevHeader.GetRound())
+	}
+
+	isSameEpoch := header.GetEpoch() == prevHeader.GetEpoch()
+	isNextEpoch := header.GetEpoch() == prevHeader.GetEpoch()+1
+	if !isSameEpoch && !isNextEpoch {
+		return fmt.Errorf("%w, header %d, previous %d", ErrWrongEpoch, header.GetEpoch(), prevHeader.GetEpoch())
+	}
+	if isNextEpoch != header.IsStartOfEpochBlock() {
+		return fmt.Errorf("%w, epoch changed: %v, start of epoch block: %v", ErrEpochStartMismatch, isNextEpoch, header.IsStartOfEpochBlock())
+	}
+
+	return nil
+}
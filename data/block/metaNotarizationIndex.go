@@ -0,0 +1,319 @@
+package block
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data"
+)
+
+// NotarizationInfo holds the details about the meta block which notarized a shard header
+type NotarizationInfo struct {
+	MetaHash         []byte
+	MetaNonce        uint64
+	MetaRound        uint64
+	MetaEpoch        uint32
+	ShardID          uint32
+	ShardHeaderNonce uint64
+	ShardHeaderRound uint64
+}
+
+// ArgsMetaNotarizationIndex holds the arguments needed to create a new meta notarization index
+type ArgsMetaNotarizationIndex struct {
+	NumOfShards uint32
+	// NumMetaBlocksToKeep is the nonce window of the tracked meta blocks. The notarization details and the executed
+	// miniBlocks recorded by older meta blocks are pruned
+	NumMetaBlocksToKeep uint64
+}
+
+// metaNonceEntries holds the keys recorded while ingesting the meta blocks with the same nonce, so they can be pruned
+type metaNonceEntries struct {
+	metaHashes         []string
+	shardHeaderHashes  []string
+	executedMiniBlocks []executedMiniBlockKey
+}
+
+type executedMiniBlockKey struct {
+	destShardID uint32
+	hash        string
+}
+
+// metaNotarizationIndex is an in-memory index built from the ingested meta blocks. It resolves the meta block which
+// notarized a shard header, the last notarized nonce of each shard and the cross miniBlocks still pending execution
+// in their destination shard. A cross miniBlock stops being pending once a notarized header of the destination shard,
+// or the meta block itself for the metachain destination, holds it as a final incoming miniBlock. Only the meta blocks
+// within the configured nonce window of the highest ingested meta block are tracked
+type metaNotarizationIndex struct {
+	numOfShards         uint32
+	numMetaBlocksToKeep uint64
+
+	mut                 sync.RWMutex
+	indexedMetaBlocks   map[string]struct{}
+	notarizedBy         map[string]*NotarizationInfo
+	lastNotarizedNonces map[uint32]uint64
+	pendingMiniBlocks   map[uint32][]*data.MiniBlockInfo
+	executedMiniBlocks  map[uint32]map[string]uint64
+	entriesByMetaNonce  map[uint64]*metaNonceEntries
+	highestMetaNonce    uint64
+	lowestTrackedNonce  uint64
+}
+
+// NewMetaNotarizationIndex creates a new meta notarization index for a network with the provided number of shards
+func NewMetaNotarizationIndex(args ArgsMetaNotarizationIndex) (*metaNotarizationIndex, error) {
+	if args.NumOfShards == 0 {
+		return nil, ErrInvalidNumberOfShards
+	}
+	if args.NumMetaBlocksToKeep == 0 {
+		return nil, ErrInvalidNumMetaBlocksToKeep
+	}
+
+	return &metaNotarizationIndex{
+		numOfShards:         args.NumOfShards,
+		numMetaBlocksToKeep: args.NumMetaBlocksToKeep,
+		indexedMetaBlocks:   make(map[string]struct{}),
+		notarizedBy:         make(map[string]*NotarizationInfo),
+		lastNotarizedNonces: make(map[uint32]uint64),
+		pendingMiniBlocks:   make(map[uint32][]*data.MiniBlockInfo),
+		executedMiniBlocks:  make(map[uint32]map[string]uint64),
+		entriesByMetaNonce:  make(map[uint64]*metaNonceEntries),
+	}, nil
+}
+
+// AddMetaBlock ingests the provided meta block. Adding an already indexed meta block, or one older than the tracked
+// nonce window, has no effect
+func (ni *metaNotarizationIndex) AddMetaBlock(metaHash []byte, metaHeader data.MetaHeaderHandler) error {
+	if len(metaHash) == 0 {
+		return ErrEmptyHeaderHash
+	}
+	if check.IfNil(metaHeader) {
+		return ErrNilHeaderHandler
+	}
+
+	ni.mut.Lock()
+	defer ni.mut.Unlock()
+
+	_, alreadyIndexed := ni.indexedMetaBlocks[string(metaHash)]
+	if alreadyIndexed || metaHeader.GetNonce() < ni.lowestTrackedNonce {
+		return nil
+	}
+	ni.indexedMetaBlocks[string(metaHash)] = struct{}{}
+	entries := ni.getOrCreateEntries(metaHeader.GetNonce())
+	entries.metaHashes = append(entries.metaHashes, string(metaHash))
+
+	for _, shardData := range metaHeader.GetShardInfoHandlers() {
+		ni.addShardData(metaHash, metaHeader, shardData, entries)
+	}
+	ni.addMetaIncomingMiniBlocks(metaHeader, entries)
+
+	for _, destShardID := range ni.getAllShardIDs() {
+		ni.addPendingMiniBlocks(destShardID, metaHeader.GetOrderedCrossMiniblocksWithDst(destShardID))
+	}
+
+	if metaHeader.GetNonce() > ni.highestMetaNonce {
+		ni.highestMetaNonce = metaHeader.GetNonce()
+		ni.prune()
+	}
+
+	return nil
+}
+
+func (ni *metaNotarizationIndex) getOrCreateEntries(metaNonce uint64) *metaNonceEntries {
+	entries, found := ni.entriesByMetaNonce[metaNonce]
+	if !found {
+		entries = &metaNonceEntries{}
+		ni.entriesByMetaNonce[metaNonce] = entries
+	}
+
+	return entries
+}
+
+func (ni *metaNotarizationIndex) addShardData(
+	metaHash []byte,
+	metaHeader data.MetaHeaderHandler,
+	shardData data.ShardDataHandler,
+	entries *metaNonceEntries,
+) {
+	shardID := shardData.GetShardID()
+	entries.shardHeaderHashes = append(entries.shardHeaderHashes, string(shardData.GetHeaderHash()))
+	ni.notarizedBy[string(shardData.GetHeaderHash())] = &NotarizationInfo{
+		MetaHash:         metaHash,
+		MetaNonce:        metaHeader.GetNonce(),
+		MetaRound:        metaHeader.GetRound(),
+		MetaEpoch:        metaHeader.GetEpoch(),
+		ShardID:          shardID,
+		ShardHeaderNonce: shardData.GetNonce(),
+		ShardHeaderRound: shardData.GetRound(),
+	}
+
+	lastNonce, found := ni.lastNotarizedNonces[shardID]
+	if !found || shardData.GetNonce() > lastNonce {
+		ni.lastNotarizedNonces[shardID] = shardData.GetNonce()
+	}
+
+	for _, mbHeader := range shardData.GetShardMiniBlockHeaderHandlers() {
+		isIncomingMiniBlock := mbHeader.GetSenderShardID() != shardID &&
+			(mbHeader.GetReceiverShardID() == shardID || mbHeader.GetReceiverShardID() == core.AllShardId)
+		if !isIncomingMiniBlock || !mbHeader.IsFinal() {
+			continue
+		}
+
+		ni.markMiniBlockExecuted(shardID, mbHeader.GetHash(), metaHeader.GetNonce(), entries)
+	}
+}
+
+// addMetaIncomingMiniBlocks marks as executed the cross miniBlocks towards the metachain, which are processed by the
+// meta block itself
+func (ni *metaNotarizationIndex) addMetaIncomingMiniBlocks(metaHeader data.MetaHeaderHandler, entries *metaNonceEntries) {
+	for _, mbHeader := range metaHeader.GetMiniBlockHeaderHandlers() {
+		isIncomingMiniBlock := mbHeader.GetSenderShardID() != core.MetachainShardId &&
+			mbHeader.GetReceiverShardID() == core.MetachainShardId
+		if !isIncomingMiniBlock || !mbHeader.IsFinal() {
+			continue
+		}
+
+		ni.markMiniBlockExecuted(core.MetachainShardId, mbHeader.GetHash(), metaHeader.GetNonce(), entries)
+	}
+}
+
+func (ni *metaNotarizationIndex) markMiniBlockExecuted(destShardID uint32, mbHash []byte, metaNonce uint64, entries *metaNonceEntries) {
+	executed, ok := ni.executedMiniBlocks[destShardID]
+	if !ok {
+		executed = make(map[string]uint64)
+		ni.executedMiniBlocks[destShardID] = executed
+	}
+	executed[string(mbHash)] = metaNonce
+	entries.executedMiniBlocks = append(entries.executedMiniBlocks, executedMiniBlockKey{destShardID: destShardID, hash: string(mbHash)})
+
+	pending := ni.pendingMiniBlocks[destShardID]
+	remaining := pending[:0]
+	for _, mbInfo := range pending {
+		if string(mbInfo.Hash) != string(mbHash) {
+			remaining = append(remaining, mbInfo)
+		}
+	}
+	ni.pendingMiniBlocks[destShardID] = remaining
+}
+
+func (ni *metaNotarizationIndex) addPendingMiniBlocks(destShardID uint32, miniBlocks []*data.MiniBlockInfo) {
+	if len(miniBlocks) == 0 {
+		return
+	}
+
+	pending := ni.pendingMiniBlocks[destShardID]
+	for _, mbInfo := range miniBlocks {
+		_, isExecuted := ni.executedMiniBlocks[destShardID][string(mbInfo.Hash)]
+		if isExecuted || containsMiniBlockInfo(pending, mbInfo.Hash) {
+			continue
+		}
+
+		pending = append(pending, mbInfo)
+	}
+
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].Round < pending[j].Round
+	})
+	ni.pendingMiniBlocks[destShardID] = pending
+}
+
+// prune drops the entries recorded by the meta blocks which fell out of the tracked nonce window. An entry overwritten
+// by a newer meta block is kept
+func (ni *metaNotarizationIndex) prune() {
+	if ni.highestMetaNonce < ni.numMetaBlocksToKeep {
+		return
+	}
+
+	newLowestNonce := ni.highestMetaNonce - ni.numMetaBlocksToKeep + 1
+	for nonce, entries := range ni.entriesByMetaNonce {
+		if nonce < newLowestNonce {
+			ni.pruneEntries(nonce, entries)
+		}
+	}
+	ni.lowestTrackedNonce = newLowestNonce
+}
+
+func (ni *metaNotarizationIndex) pruneEntries(nonce uint64, entries *metaNonceEntries) {
+	for _, metaHash := range entries.metaHashes {
+		delete(ni.indexedMetaBlocks, metaHash)
+	}
+	for _, shardHeaderHash := range entries.shardHeaderHashes {
+		info, ok := ni.notarizedBy[shardHeaderHash]
+		if ok && info.MetaNonce == nonce {
+			delete(ni.notarizedBy, shardHeaderHash)
+		}
+	}
+	for _, key := range entries.executedMiniBlocks {
+		executedNonce, ok := ni.executedMiniBlocks[key.destShardID][key.hash]
+		if ok && executedNonce == nonce {
+			delete(ni.executedMiniBlocks[key.destShardID], key.hash)
+		}
+	}
+	delete(ni.entriesByMetaNonce, nonce)
+}
+
+func (ni *metaNotarizationIndex) getAllShardIDs() []uint32 {
+	shardIDs := make([]uint32, 0, ni.numOfShards+1)
+	for shardID := uint32(0); shardID < ni.numOfShards; shardID++ {
+		shardIDs = append(shardIDs, shardID)
+	}
+
+	return append(shardIDs, core.MetachainShardId)
+}
+
+// GetNotarizingMetaBlock returns the details about the meta block which notarized the provided shard header
+func (ni *metaNotarizationIndex) GetNotarizingMetaBlock(shardHeaderHash []byte) (*NotarizationInfo, error) {
+	ni.mut.RLock()
+	defer ni.mut.RUnlock()
+
+	info, ok := ni.notarizedBy[string(shardHeaderHash)]
+	if !ok {
+		return nil, fmt.Errorf("%w, hash %s", ErrShardHeaderNotNotarized, hex.EncodeToString(shardHeaderHash))
+	}
+
+	infoCopy := *info
+	return &infoCopy, nil
+}
+
+// GetPendingCrossMiniBlocks returns the cross miniBlocks with the provided destination which were not yet executed,
+// ordered by the round in which they were created
+func (ni *metaNotarizationIndex) GetPendingCrossMiniBlocks(destShardID uint32) []*data.MiniBlockInfo {
+	ni.mut.RLock()
+	defer ni.mut.RUnlock()
+
+	pending := ni.pendingMiniBlocks[destShardID]
+	result := make([]*data.MiniBlockInfo, 0, len(pending))
+	for _, mbInfo := range pending {
+		mbInfoCopy := *mbInfo
+		result = append(result, &mbInfoCopy)
+	}
+
+	return result
+}
+
+// GetLastNotarizedNonce returns the highest notarized header nonce of the provided shard. The second returned value
+// is false if no header of that shard was notarized yet
+func (ni *metaNotarizationIndex) GetLastNotarizedNonce(shardID uint32) (uint64, bool) {
+	ni.mut.RLock()
+	defer ni.mut.RUnlock()
+
+	nonce, ok := ni.lastNotarizedNonces[shardID]
+	return nonce, ok
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ni *metaNotarizationIndex) IsInterfaceNil() bool {
+	return ni == nil
+}
+
+func containsMiniBlockInfo(miniBlocks []*data.MiniBlockInfo, hash []byte) bool {
+	for _, mbInfo := range miniBlocks {
+		if string(mbInfo.Hash) == string(hash) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,266 @@
+package block_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createFinalMiniBlockHeader(hash string, sender uint32, receiver uint32) block.MiniBlockHeader {
+	mbHeader := block.MiniBlockHeader{
+		Hash:            []byte(hash),
+		SenderShardID:   sender,
+		ReceiverShardID: receiver,
+		TxCount:         1,
+	}
+	_ = mbHeader.SetConstructionState(int32(block.Final))
+
+	return mbHeader
+}
+
+func getMiniBlockInfoHashes(miniBlocks []*data.MiniBlockInfo) []string {
+	hashes := make([]string, 0, len(miniBlocks))
+	for _, mbInfo := range miniBlocks {
+		hashes = append(hashes, string(mbInfo.Hash))
+	}
+
+	return hashes
+}
+
+func createMockArgsMetaNotarizationIndex() block.ArgsMetaNotarizationIndex {
+	return block.ArgsMetaNotarizationIndex{
+		NumOfShards:         2,
+		NumMetaBlocksToKeep: 10,
+	}
+}
+
+func TestNewMetaNotarizationIndex(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsMetaNotarizationIndex()
+	args.NumOfShards = 0
+	ni, err := block.NewMetaNotarizationIndex(args)
+	assert.Equal(t, block.ErrInvalidNumberOfShards, err)
+	assert.True(t, check.IfNil(ni))
+
+	args = createMockArgsMetaNotarizationIndex()
+	args.NumMetaBlocksToKeep = 0
+	ni, err = block.NewMetaNotarizationIndex(args)
+	assert.Equal(t, block.ErrInvalidNumMetaBlocksToKeep, err)
+	assert.True(t, check.IfNil(ni))
+
+	ni, err = block.NewMetaNotarizationIndex(createMockArgsMetaNotarizationIndex())
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(ni))
+}
+
+func TestMetaNotarizationIndex_AddMetaBlock(t *testing.T) {
+	t.Parallel()
+
+	ni, _ := block.NewMetaNotarizationIndex(createMockArgsMetaNotarizationIndex())
+
+	assert.Equal(t, block.ErrEmptyHeaderHash, ni.AddMetaBlock(nil, &block.MetaBlock{}))
+	assert.Equal(t, block.ErrNilHeaderHandler, ni.AddMetaBlock([]byte("meta"), nil))
+}
+
+func TestMetaNotarizationIndex_Queries(t *testing.T) {
+	t.Parallel()
+
+	ni, _ := block.NewMetaNotarizationIndex(createMockArgsMetaNotarizationIndex())
+
+	_, found := ni.GetLastNotarizedNonce(0)
+	assert.False(t, found)
+	_, err := ni.GetNotarizingMetaBlock([]byte("shard0 h1"))
+	assert.True(t, errors.Is(err, block.ErrShardHeaderNotNotarized))
+
+	metaBlock1 := &block.MetaBlock{
+		Nonce: 1,
+		Round: 1,
+		ShardInfo: []block.ShardData{
+			{
+				HeaderHash: []byte("shard0 h1"),
+				ShardID:    0,
+				Nonce:      5,
+				Round:      1,
+				ShardMiniBlockHeaders: []block.MiniBlockHeader{
+					createFinalMiniBlockHeader("mb 0->1", 0, 1),
+				},
+			},
+			{
+				HeaderHash: []byte("shard1 h1"),
+				ShardID:    1,
+				Nonce:      7,
+				Round:      1,
+				ShardMiniBlockHeaders: []block.MiniBlockHeader{
+					createFinalMiniBlockHeader("mb 1->0", 1, 0),
+				},
+			},
+		},
+		MiniBlockHeaders: []block.MiniBlockHeader{
+			createFinalMiniBlockHeader("peer mb", core.MetachainShardId, core.AllShardId),
+		},
+	}
+	require.Nil(t, ni.AddMetaBlock([]byte("meta1"), metaBlock1))
+	require.Nil(t, ni.AddMetaBlock([]byte("meta1"), metaBlock1))
+
+	info, err := ni.GetNotarizingMetaBlock([]byte("shard1 h1"))
+	require.Nil(t, err)
+	assert.Equal(t, &block.NotarizationInfo{
+		MetaHash:         []byte("meta1"),
+		MetaNonce:        1,
+		MetaRound:        1,
+		ShardID:          1,
+		ShardHeaderNonce: 7,
+		ShardHeaderRound: 1,
+	}, info)
+
+	assert.Equal(t, []string{"mb 1->0", "peer mb"}, getMiniBlockInfoHashes(ni.GetPendingCrossMiniBlocks(0)))
+	assert.Equal(t, []string{"mb 0->1", "peer mb"}, getMiniBlockInfoHashes(ni.GetPendingCrossMiniBlocks(1)))
+	assert.Empty(t, ni.GetPendingCrossMiniBlocks(core.MetachainShardId))
+
+	partialMbHeader := createFinalMiniBlockHeader("mb 0->1", 0, 1)
+	_ = partialMbHeader.SetConstructionState(int32(block.PartialExecuted))
+	metaBlock2 := &block.MetaBlock{
+		Nonce: 2,
+		Round: 3,
+		ShardInfo: []block.ShardData{
+			{
+				HeaderHash: []byte("shard0 h2"),
+				ShardID:    0,
+				Nonce:      6,
+				Round:      2,
+				ShardMiniBlockHeaders: []block.MiniBlockHeader{
+					createFinalMiniBlockHeader("mb 1->0", 1, 0),
+					createFinalMiniBlockHeader("peer mb", core.MetachainShardId, core.AllShardId),
+					createFinalMiniBlockHeader("mb 0->meta", 0, core.MetachainShardId),
+				},
+			},
+			{
+				HeaderHash: []byte("shard1 h2"),
+				ShardID:    1,
+				Nonce:      8,
+				Round:      3,
+				ShardMiniBlockHeaders: []block.MiniBlockHeader{
+					partialMbHeader,
+					createFinalMiniBlockHeader("mb 1->meta", 1, core.MetachainShardId),
+				},
+			},
+		},
+		MiniBlockHeaders: []block.MiniBlockHeader{
+			createFinalMiniBlockHeader("mb 0->meta", 0, core.MetachainShardId),
+		},
+	}
+	require.Nil(t, ni.AddMetaBlock([]byte("meta2"), metaBlock2))
+
+	info, err = ni.GetNotarizingMetaBlock([]byte("shard0 h2"))
+	require.Nil(t, err)
+	assert.Equal(t, []byte("meta2"), info.MetaHash)
+	info, err = ni.GetNotarizingMetaBlock([]byte("shard0 h1"))
+	require.Nil(t, err)
+	assert.Equal(t, []byte("meta1"), info.MetaHash)
+
+	nonce, found := ni.GetLastNotarizedNonce(0)
+	assert.True(t, found)
+	assert.Equal(t, uint64(6), nonce)
+	nonce, found = ni.GetLastNotarizedNonce(1)
+	assert.True(t, found)
+	assert.Equal(t, uint64(8), nonce)
+
+	assert.Empty(t, ni.GetPendingCrossMiniBlocks(0))
+	assert.Equal(t, []string{"mb 0->1", "peer mb"}, getMiniBlockInfoHashes(ni.GetPendingCrossMiniBlocks(1)))
+	assert.Equal(t, []string{"mb 1->meta"}, getMiniBlockInfoHashes(ni.GetPendingCrossMiniBlocks(core.MetachainShardId)))
+
+	metaBlock3 := &block.MetaBlock{
+		Nonce: 3,
+		Round: 4,
+		MiniBlockHeaders: []block.MiniBlockHeader{
+			createFinalMiniBlockHeader("mb 1->meta", 1, core.MetachainShardId),
+		},
+	}
+	require.Nil(t, ni.AddMetaBlock([]byte("meta3"), metaBlock3))
+	assert.Empty(t, ni.GetPendingCrossMiniBlocks(core.MetachainShardId))
+}
+
+func TestMetaNotarizationIndex_Pruning(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsMetaNotarizationIndex()
+	args.NumMetaBlocksToKeep = 2
+	ni, _ := block.NewMetaNotarizationIndex(args)
+
+	createMetaBlock := func(nonce uint64, shardHeaderHash string) *block.MetaBlock {
+		return &block.MetaBlock{
+			Nonce: nonce,
+			Round: nonce,
+			ShardInfo: []block.ShardData{
+				{
+					HeaderHash: []byte(shardHeaderHash),
+					ShardID:    0,
+					Nonce:      nonce,
+					Round:      nonce,
+					ShardMiniBlockHeaders: []block.MiniBlockHeader{
+						createFinalMiniBlockHeader("mb 1->0 "+shardHeaderHash, 1, 0),
+					},
+				},
+			},
+		}
+	}
+
+	require.Nil(t, ni.AddMetaBlock([]byte("meta1"), createMetaBlock(1, "h1")))
+	require.Nil(t, ni.AddMetaBlock([]byte("meta2"), createMetaBlock(2, "h2")))
+	_, err := ni.GetNotarizingMetaBlock([]byte("h1"))
+	assert.Nil(t, err)
+
+	require.Nil(t, ni.AddMetaBlock([]byte("meta3"), createMetaBlock(3, "h3")))
+	_, err = ni.GetNotarizingMetaBlock([]byte("h1"))
+	assert.True(t, errors.Is(err, block.ErrShardHeaderNotNotarized))
+	_, err = ni.GetNotarizingMetaBlock([]byte("h2"))
+	assert.Nil(t, err)
+
+	require.Nil(t, ni.AddMetaBlock([]byte("meta1"), createMetaBlock(1, "h1")))
+	_, err = ni.GetNotarizingMetaBlock([]byte("h1"))
+	assert.True(t, errors.Is(err, block.ErrShardHeaderNotNotarized))
+
+	nonce, _ := ni.GetLastNotarizedNonce(0)
+	assert.Equal(t, uint64(3), nonce)
+}
+
+func TestMetaNotarizationIndex_ConcurrentOperations(t *testing.T) {
+	t.Parallel()
+
+	ni, _ := block.NewMetaNotarizationIndex(createMockArgsMetaNotarizationIndex())
+
+	numCalls := 100
+	wg := sync.WaitGroup{}
+	wg.Add(numCalls)
+	for i := 0; i < numCalls; i++ {
+		go func(idx int) {
+			defer wg.Done()
+
+			switch idx % 4 {
+			case 0:
+				_ = ni.AddMetaBlock([]byte{byte(idx)}, &block.MetaBlock{
+					Nonce:     uint64(idx),
+					ShardInfo: []block.ShardData{{HeaderHash: []byte{byte(idx)}, Nonce: uint64(idx)}},
+				})
+			case 1:
+				_, _ = ni.GetNotarizingMetaBlock([]byte{byte(idx)})
+			case 2:
+				_ = ni.GetPendingCrossMiniBlocks(0)
+			case 3:
+				_, _ = ni.GetLastNotarizedNonce(0)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	nonce, _ := ni.GetLastNotarizedNonce(0)
+	assert.Equal(t, uint64(96), nonce)
+}
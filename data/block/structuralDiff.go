@@ -0,0 +1,156 @@
+package block
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data"
+)
+
+// FieldDiff holds a field which has different values in the two compared objects. The path is built from the
+// field names and the slice indexes, such as ShardInfo[2].ShardMiniBlockHeaders[0].TxCount. A missing slice element
+// is reported with a nil value on the side where it is missing
+type FieldDiff struct {
+	Path   string
+	First  interface{}
+	Second interface{}
+}
+
+// String returns the field diff in a human readable format
+func (fd FieldDiff) String() string {
+	return fmt.Sprintf("%s: %s != %s", fd.Path, diffValueToString(fd.First), diffValueToString(fd.Second))
+}
+
+// DiffHeaders returns the fields that differ between the two provided headers, which should have the same type
+func DiffHeaders(first data.HeaderHandler, second data.HeaderHandler) ([]FieldDiff, error) {
+	if check.IfNil(first) || check.IfNil(second) {
+		return nil, ErrNilHeaderHandler
+	}
+	if reflect.TypeOf(first) != reflect.TypeOf(second) {
+		return nil, fmt.Errorf("%w, cannot compare %T with %T", data.ErrInvalidHeaderType, first, second)
+	}
+
+	return diff(first, second), nil
+}
+
+// DiffShardData returns the fields that differ between the two provided shard data
+func DiffShardData(first *ShardData, second *ShardData) []FieldDiff {
+	return diff(first, second)
+}
+
+// DiffBodies returns the fields that differ between the two provided bodies
+func DiffBodies(first *Body, second *Body) []FieldDiff {
+	return diff(first, second)
+}
+
+func diff(first interface{}, second interface{}) []FieldDiff {
+	diffs := make([]FieldDiff, 0)
+	diffValues("", reflect.ValueOf(first), reflect.ValueOf(second), &diffs)
+
+	return diffs
+}
+
+func diffValues(path string, first reflect.Value, second reflect.Value, diffs *[]FieldDiff) {
+	switch first.Kind() {
+	case reflect.Ptr:
+		if first.Type() == bigIntPointerType {
+			diffBigInts(path, first, second, diffs)
+			return
+		}
+		if first.IsNil() && second.IsNil() {
+			return
+		}
+		if first.IsNil() || second.IsNil() {
+			appendDiff(path, first.Interface(), second.Interface(), diffs)
+			return
+		}
+
+		diffValues(path, first.Elem(), second.Elem(), diffs)
+	case reflect.Struct:
+		for i := 0; i < first.NumField(); i++ {
+			field := first.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			diffValues(joinPath(path, field.Name), first.Field(i), second.Field(i), diffs)
+		}
+	case reflect.Slice:
+		if first.Type().Elem().Kind() == reflect.Uint8 {
+			if !bytes.Equal(first.Bytes(), second.Bytes()) {
+				appendDiff(path, first.Interface(), second.Interface(), diffs)
+			}
+			return
+		}
+
+		diffSlices(path, first, second, diffs)
+	default:
+		if !reflect.DeepEqual(first.Interface(), second.Interface()) {
+			appendDiff(path, first.Interface(), second.Interface(), diffs)
+		}
+	}
+}
+
+func diffSlices(path string, first reflect.Value, second reflect.Value, diffs *[]FieldDiff) {
+	maxLen := first.Len()
+	if second.Len() > maxLen {
+		maxLen = second.Len()
+	}
+
+	for i := 0; i < maxLen; i++ {
+		elementPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= first.Len():
+			appendDiff(elementPath, nil, second.Index(i).Interface(), diffs)
+		case i >= second.Len():
+			appendDiff(elementPath, first.Index(i).Interface(), nil, diffs)
+		default:
+			diffValues(elementPath, first.Index(i), second.Index(i), diffs)
+		}
+	}
+}
+
+func diffBigInts(path string, first reflect.Value, second reflect.Value, diffs *[]FieldDiff) {
+	firstValue := first.Interface().(*big.Int)
+	secondValue := second.Interface().(*big.Int)
+	if firstValue == nil && secondValue == nil {
+		return
+	}
+	if firstValue == nil || secondValue == nil || firstValue.Cmp(secondValue) != 0 {
+		appendDiff(path, firstValue, secondValue, diffs)
+	}
+}
+
+func appendDiff(path string, first interface{}, second interface{}, diffs *[]FieldDiff) {
+	*diffs = append(*diffs, FieldDiff{
+		Path:   path,
+		First:  first,
+		Second: second,
+	})
+}
+
+func joinPath(path string, fieldName string) string {
+	if len(path) == 0 {
+		return fieldName
+	}
+
+	return path + "." + fieldName
+}
+
+func diffValueToString(value interface{}) string {
+	switch v := value.(type) {
+	case []byte:
+		return hex.EncodeToString(v)
+	case *big.Int:
+		if v == nil {
+			return "<nil>"
+		}
+		return v.String()
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
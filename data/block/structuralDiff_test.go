@@ -0,0 +1,134 @@
+package block_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMetaBlockWithShardInfo() *block.MetaBlock {
+	metaBlock := createMandatoryFieldsMetaBlock()
+	for i := 0; i < 3; i++ {
+		metaBlock.ShardInfo = append(metaBlock.ShardInfo, block.ShardData{
+			HeaderHash: []byte{byte(i)},
+			ShardID:    uint32(i),
+			ShardMiniBlockHeaders: []block.MiniBlockHeader{
+				{Hash: []byte("mb"), TxCount: 5},
+			},
+			AccumulatedFees: big.NewInt(int64(i)),
+		})
+	}
+
+	return metaBlock
+}
+
+func TestDiffHeaders(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil or different types should error", func(t *testing.T) {
+		t.Parallel()
+
+		diffs, err := block.DiffHeaders(nil, createMandatoryFieldsHeader())
+		assert.Nil(t, diffs)
+		assert.Equal(t, block.ErrNilHeaderHandler, err)
+
+		diffs, err = block.DiffHeaders(createMandatoryFieldsHeader(), createMandatoryFieldsHeaderV2())
+		assert.Nil(t, diffs)
+		assert.True(t, errors.Is(err, data.ErrInvalidHeaderType))
+	})
+	t.Run("equal headers", func(t *testing.T) {
+		t.Parallel()
+
+		metaBlock := createMetaBlockWithShardInfo()
+		diffs, err := block.DiffHeaders(metaBlock, metaBlock.DeepClone())
+		require.Nil(t, err)
+		assert.Empty(t, diffs)
+	})
+	t.Run("meta block nested fields", func(t *testing.T) {
+		t.Parallel()
+
+		metaBlock := createMetaBlockWithShardInfo()
+		clone := metaBlock.DeepClone()
+		clone.ShardInfo[2].ShardMiniBlockHeaders[0].TxCount = 6
+		clone.ShardInfo[0].AccumulatedFees = nil
+		clone.ShardInfo[1].ShardMiniBlockHeaders = append(clone.ShardInfo[1].ShardMiniBlockHeaders, block.MiniBlockHeader{})
+		clone.AccumulatedFees = big.NewInt(7)
+		clone.RootHash = []byte{0xAB}
+
+		diffs, err := block.DiffHeaders(metaBlock, clone)
+		require.Nil(t, err)
+		require.Equal(t, 5, len(diffs))
+
+		paths := make([]string, 0, len(diffs))
+		for _, fieldDiff := range diffs {
+			paths = append(paths, fieldDiff.Path)
+		}
+		assert.Equal(t, []string{
+			"ShardInfo[0].AccumulatedFees",
+			"ShardInfo[1].ShardMiniBlockHeaders[1]",
+			"ShardInfo[2].ShardMiniBlockHeaders[0].TxCount",
+			"RootHash",
+			"AccumulatedFees",
+		}, paths)
+
+		assert.Equal(t, "ShardInfo[2].ShardMiniBlockHeaders[0].TxCount: 5 != 6", diffs[2].String())
+		assert.Nil(t, diffs[1].First)
+		assert.Equal(t, "ShardInfo[0].AccumulatedFees: 0 != <nil>", diffs[0].String())
+		assert.Equal(t, "AccumulatedFees: 0 != 7", diffs[4].String())
+		assert.Equal(t, "RootHash: 726f6f742068617368 != ab", diffs[3].String())
+	})
+	t.Run("header v2 embedded header fields", func(t *testing.T) {
+		t.Parallel()
+
+		headerV2 := createMandatoryFieldsHeaderV2()
+		clone := headerV2.DeepClone()
+		clone.Header.Nonce++
+		clone.ScheduledGasRefunded = 10
+
+		diffs, err := block.DiffHeaders(headerV2, clone)
+		require.Nil(t, err)
+		assert.Equal(t, []block.FieldDiff{
+			{Path: "Header.Nonce", First: uint64(10), Second: uint64(11)},
+			{Path: "ScheduledGasRefunded", First: uint64(0), Second: uint64(10)},
+		}, diffs)
+	})
+}
+
+func TestDiffShardDataAndBodies(t *testing.T) {
+	t.Parallel()
+
+	shardData := &createMetaBlockWithShardInfo().ShardInfo[0]
+	shardDataClone := shardData.DeepClone()
+	assert.Empty(t, block.DiffShardData(shardData, shardDataClone))
+
+	shardDataClone.TxCount = 3
+	diffs := block.DiffShardData(shardData, shardDataClone)
+	require.Equal(t, 1, len(diffs))
+	assert.Equal(t, "TxCount", diffs[0].Path)
+
+	body, _ := createBodyAndHashes()
+	bodyClone := body.DeepClone()
+	assert.Empty(t, block.DiffBodies(body, bodyClone))
+
+	bodyClone.MiniBlocks[1].TxHashes[2] = []byte("tx6")
+	bodyClone.MiniBlocks = bodyClone.MiniBlocks[:1]
+	diffs = block.DiffBodies(body, bodyClone)
+	require.Equal(t, 1, len(diffs))
+	assert.Equal(t, "MiniBlocks[1]", diffs[0].Path)
+	assert.Nil(t, diffs[0].Second)
+
+	bodyClone = body.DeepClone()
+	bodyClone.MiniBlocks[1].TxHashes[2] = []byte("tx6")
+	diffs = block.DiffBodies(body, bodyClone)
+	require.Equal(t, 1, len(diffs))
+	assert.Equal(t, "MiniBlocks[1].TxHashes[2]", diffs[0].Path)
+
+	diffs = block.DiffBodies(body, nil)
+	require.Equal(t, 1, len(diffs))
+	assert.Equal(t, "", diffs[0].Path)
+}
@@ -0,0 +1,24 @@
+package economics
+
+import "errors"
+
+// ErrInvalidMinGasLimit signals that an invalid min gas limit has been provided
+var ErrInvalidMinGasLimit = errors.New("invalid min gas limit")
+
+// ErrInvalidGasPriceModifier signals that an invalid gas price modifier has been provided
+var ErrInvalidGasPriceModifier = errors.New("invalid gas price modifier")
+
+// ErrNilTransaction signals that a nil transaction has been provided
+var ErrNilTransaction = errors.New("nil transaction")
+
+// ErrNegativeRefund signals that a negative refund value has been provided
+var ErrNegativeRefund = errors.New("negative refund value")
+
+// ErrRefundHigherThanFee signals that the provided refund value is higher than the initially paid fee
+var ErrRefundHigherThanFee = errors.New("refund value is higher than the initially paid fee")
+
+// ErrInsufficientGasLimit signals that the transaction's gas limit does not cover the minimum required gas
+var ErrInsufficientGasLimit = errors.New("insufficient gas limit")
+
+// ErrGasPriceTooLow signals that the transaction's gas price is lower than the minimum gas price
+var ErrGasPriceTooLow = errors.New("gas price is too low")
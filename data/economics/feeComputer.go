@@ -0,0 +1,194 @@
+package economics
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data"
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+)
+
+// ArgsFeeComputer holds the economics configuration needed to create a new fee computer
+type ArgsFeeComputer struct {
+	MinGasLimit      uint64
+	GasPerDataByte   uint64
+	MinGasPrice      uint64
+	GasPriceModifier float64
+}
+
+// feeComputer computes gas units and fees for transactions. The move balance gas (min gas limit plus the gas
+// for each data byte) is paid at the full gas price while the gas used for processing is paid at the gas price
+// adjusted with the gas price modifier
+type feeComputer struct {
+	minGasLimit      uint64
+	gasPerDataByte   uint64
+	minGasPrice      uint64
+	gasPriceModifier float64
+}
+
+// NewFeeComputer creates a new fee computer instance
+func NewFeeComputer(args ArgsFeeComputer) (*feeComputer, error) {
+	if args.MinGasLimit == 0 {
+		return nil, ErrInvalidMinGasLimit
+	}
+	if args.GasPriceModifier <= 0 || args.GasPriceModifier > 1 {
+		return nil, fmt.Errorf("%w, provided %f, expected value in (0, 1]", ErrInvalidGasPriceModifier, args.GasPriceModifier)
+	}
+
+	return &feeComputer{
+		minGasLimit:      args.MinGasLimit,
+		gasPerDataByte:   args.GasPerDataByte,
+		minGasPrice:      args.MinGasPrice,
+		gasPriceModifier: args.GasPriceModifier,
+	}, nil
+}
+
+// MinGasLimit returns the gas limit of a move balance transaction without data
+func (fc *feeComputer) MinGasLimit() uint64 {
+	return fc.minGasLimit
+}
+
+// ComputeGasLimit returns the move balance gas units of the provided transaction
+func (fc *feeComputer) ComputeGasLimit(tx data.TransactionWithFeeHandler) uint64 {
+	dataLen := uint64(len(tx.GetData()))
+
+	return fc.minGasLimit + dataLen*fc.gasPerDataByte
+}
+
+// ComputeMinRequiredGasLimit returns the minimum gas limit the provided transaction should have. Transactions
+// towards the metachain smart contracts should provide an extra amount of gas
+func (fc *feeComputer) ComputeMinRequiredGasLimit(tx data.TransactionWithFeeHandler) uint64 {
+	gasLimit := fc.ComputeGasLimit(tx)
+	if isSmartContractOnMetachain(tx.GetRcvAddr()) {
+		gasLimit += core.MinMetaTxExtraGasCost
+	}
+
+	return gasLimit
+}
+
+// CheckTransaction verifies that the provided transaction's gas price and gas limit are enough for it to be processed
+func (fc *feeComputer) CheckTransaction(tx data.TransactionWithFeeHandler) error {
+	if check.IfNilReflect(tx) {
+		return ErrNilTransaction
+	}
+	if tx.GetGasPrice() < fc.minGasPrice {
+		return fmt.Errorf("%w, provided %d, min %d", ErrGasPriceTooLow, tx.GetGasPrice(), fc.minGasPrice)
+	}
+
+	minGasLimit := fc.ComputeMinRequiredGasLimit(tx)
+	if tx.GetGasLimit() < minGasLimit {
+		return fmt.Errorf("%w, provided %d, min %d", ErrInsufficientGasLimit, tx.GetGasLimit(), minGasLimit)
+	}
+
+	return nil
+}
+
+// GasPriceForProcessing returns the gas price paid for the gas units used in processing
+func (fc *feeComputer) GasPriceForProcessing(tx data.TransactionWithFeeHandler) uint64 {
+	return uint64(float64(tx.GetGasPrice()) * fc.gasPriceModifier)
+}
+
+// ComputeMoveBalanceFee returns the fee paid for the move balance gas units
+func (fc *feeComputer) ComputeMoveBalanceFee(tx data.TransactionWithFeeHandler) *big.Int {
+	return core.SafeMul(tx.GetGasPrice(), fc.ComputeGasLimit(tx))
+}
+
+// ComputeFeeForProcessing returns the fee paid for the provided processing gas units
+func (fc *feeComputer) ComputeFeeForProcessing(tx data.TransactionWithFeeHandler, gasUnits uint64) *big.Int {
+	return core.SafeMul(fc.GasPriceForProcessing(tx), gasUnits)
+}
+
+// ComputeTxFee returns the fee initially paid by the sender, when the whole gas limit is consumed
+func (fc *feeComputer) ComputeTxFee(tx data.TransactionWithFeeHandler) *big.Int {
+	moveBalanceGas := fc.ComputeGasLimit(tx)
+	moveBalanceFee := core.SafeMul(tx.GetGasPrice(), moveBalanceGas)
+	if tx.GetGasLimit() <= moveBalanceGas {
+		return moveBalanceFee
+	}
+
+	processingFee := fc.ComputeFeeForProcessing(tx, tx.GetGasLimit()-moveBalanceGas)
+
+	return moveBalanceFee.Add(moveBalanceFee, processingFee)
+}
+
+// ComputeGasUnitsFromRefundValue returns the number of gas units corresponding to the provided refund value
+func (fc *feeComputer) ComputeGasUnitsFromRefundValue(tx data.TransactionWithFeeHandler, refundValue *big.Int) uint64 {
+	gasPrice := fc.GasPriceForProcessing(tx)
+	if gasPrice == 0 || refundValue == nil {
+		return 0
+	}
+
+	gasUnits := big.NewInt(0).Div(refundValue, big.NewInt(0).SetUint64(gasPrice))
+
+	return gasUnits.Uint64()
+}
+
+// ComputeGasUsedAndFeeBasedOnRefundValue returns the gas units used and the final fee of the provided transaction,
+// after the refund value was given back to the sender
+func (fc *feeComputer) ComputeGasUsedAndFeeBasedOnRefundValue(
+	tx data.TransactionWithFeeHandler,
+	refundValue *big.Int,
+) (uint64, *big.Int, error) {
+	if check.IfNilReflect(tx) {
+		return 0, nil, ErrNilTransaction
+	}
+
+	txFee := fc.ComputeTxFee(tx)
+	if refundValue == nil || refundValue.Sign() == 0 {
+		return tx.GetGasLimit(), txFee, nil
+	}
+	if refundValue.Sign() < 0 {
+		return 0, nil, ErrNegativeRefund
+	}
+	if refundValue.Cmp(txFee) > 0 {
+		return 0, nil, fmt.Errorf("%w, refund %s, fee %s", ErrRefundHigherThanFee, refundValue.String(), txFee.String())
+	}
+
+	txFee.Sub(txFee, refundValue)
+
+	moveBalanceGas := fc.ComputeGasLimit(tx)
+	moveBalanceFee := fc.ComputeMoveBalanceFee(tx)
+	if txFee.Cmp(moveBalanceFee) <= 0 {
+		return moveBalanceGas, txFee, nil
+	}
+
+	processingFee := big.NewInt(0).Sub(txFee, moveBalanceFee)
+	processingGas := fc.ComputeGasUnitsFromRefundValue(tx, processingFee)
+
+	return moveBalanceGas + processingGas, txFee, nil
+}
+
+// ComputeFeeInfo returns the outport fee info of the provided transaction, given the value refunded to the sender
+func (fc *feeComputer) ComputeFeeInfo(tx data.TransactionWithFeeHandler, refundValue *big.Int) (*outport.FeeInfo, error) {
+	gasUsed, fee, err := fc.ComputeGasUsedAndFeeBasedOnRefundValue(tx, refundValue)
+	if err != nil {
+		return nil, err
+	}
+
+	feeInfo := &outport.FeeInfo{
+		GasUsed:        gasUsed,
+		Fee:            fee,
+		InitialPaidFee: fc.ComputeTxFee(tx),
+	}
+	if refundValue != nil && refundValue.Sign() > 0 {
+		feeInfo.SetHadRefund()
+		feeInfo.SetGasRefunded(fc.ComputeGasUnitsFromRefundValue(tx, refundValue))
+	}
+
+	return feeInfo, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (fc *feeComputer) IsInterfaceNil() bool {
+	return fc == nil
+}
+
+func isSmartContractOnMetachain(address []byte) bool {
+	if len(address) == 0 {
+		return false
+	}
+
+	return core.IsSmartContractOnMetachain(address[len(address)-1:], address)
+}
@@ -0,0 +1,206 @@
+package economics
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-core-go/data/transaction/relayed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ relayed.FeeHandler = (*feeComputer)(nil)
+
+func createMockArgsFeeComputer() ArgsFeeComputer {
+	return ArgsFeeComputer{
+		MinGasLimit:      50_000,
+		GasPerDataByte:   1_500,
+		MinGasPrice:      1_000_000_000,
+		GasPriceModifier: 0.01,
+	}
+}
+
+func createScCallTx() *transaction.Transaction {
+	return &transaction.Transaction{
+		RcvAddr:  []byte("receiver"),
+		Data:     []byte("hello"),
+		GasPrice: 1_000_000_000,
+		GasLimit: 200_000,
+	}
+}
+
+func TestNewFeeComputer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid min gas limit should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsFeeComputer()
+		args.MinGasLimit = 0
+		fc, err := NewFeeComputer(args)
+		assert.Equal(t, ErrInvalidMinGasLimit, err)
+		assert.True(t, check.IfNil(fc))
+	})
+	t.Run("invalid gas price modifier should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsFeeComputer()
+		args.GasPriceModifier = 0
+		fc, err := NewFeeComputer(args)
+		assert.True(t, errors.Is(err, ErrInvalidGasPriceModifier))
+		assert.True(t, check.IfNil(fc))
+
+		args.GasPriceModifier = 1.01
+		fc, err = NewFeeComputer(args)
+		assert.True(t, errors.Is(err, ErrInvalidGasPriceModifier))
+		assert.True(t, check.IfNil(fc))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		fc, err := NewFeeComputer(createMockArgsFeeComputer())
+		assert.Nil(t, err)
+		assert.False(t, check.IfNil(fc))
+		assert.Equal(t, uint64(50_000), fc.MinGasLimit())
+	})
+}
+
+func TestFeeComputer_GasComputation(t *testing.T) {
+	t.Parallel()
+
+	fc, _ := NewFeeComputer(createMockArgsFeeComputer())
+	tx := createScCallTx()
+
+	assert.Equal(t, uint64(57_500), fc.ComputeGasLimit(tx))
+	assert.Equal(t, uint64(57_500), fc.ComputeMinRequiredGasLimit(tx))
+	assert.Equal(t, uint64(10_000_000), fc.GasPriceForProcessing(tx))
+
+	tx.RcvAddr = core.ESDTSCAddress
+	assert.Equal(t, uint64(57_500)+core.MinMetaTxExtraGasCost, fc.ComputeMinRequiredGasLimit(tx))
+}
+
+func TestFeeComputer_CheckTransaction(t *testing.T) {
+	t.Parallel()
+
+	fc, _ := NewFeeComputer(createMockArgsFeeComputer())
+
+	assert.Equal(t, ErrNilTransaction, fc.CheckTransaction(nil))
+	var nilTx *transaction.Transaction
+	assert.Equal(t, ErrNilTransaction, fc.CheckTransaction(nilTx))
+
+	tx := createScCallTx()
+	assert.Nil(t, fc.CheckTransaction(tx))
+
+	tx.GasPrice--
+	assert.True(t, errors.Is(fc.CheckTransaction(tx), ErrGasPriceTooLow))
+
+	tx = createScCallTx()
+	tx.GasLimit = 57_499
+	assert.True(t, errors.Is(fc.CheckTransaction(tx), ErrInsufficientGasLimit))
+
+	tx = createScCallTx()
+	tx.RcvAddr = core.ESDTSCAddress
+	assert.True(t, errors.Is(fc.CheckTransaction(tx), ErrInsufficientGasLimit))
+}
+
+func TestFeeComputer_ComputeFees(t *testing.T) {
+	t.Parallel()
+
+	fc, _ := NewFeeComputer(createMockArgsFeeComputer())
+	tx := createScCallTx()
+
+	assert.Equal(t, big.NewInt(57_500_000_000_000), fc.ComputeMoveBalanceFee(tx))
+	assert.Equal(t, big.NewInt(1_425_000_000_000), fc.ComputeFeeForProcessing(tx, 142_500))
+	assert.Equal(t, big.NewInt(58_925_000_000_000), fc.ComputeTxFee(tx))
+
+	tx.GasLimit = 10
+	assert.Equal(t, big.NewInt(57_500_000_000_000), fc.ComputeTxFee(tx))
+}
+
+func TestFeeComputer_ComputeGasUsedAndFeeBasedOnRefundValue(t *testing.T) {
+	t.Parallel()
+
+	fc, _ := NewFeeComputer(createMockArgsFeeComputer())
+
+	t.Run("nil transaction should error", func(t *testing.T) {
+		t.Parallel()
+
+		gasUsed, fee, err := fc.ComputeGasUsedAndFeeBasedOnRefundValue(nil, big.NewInt(0))
+		assert.Equal(t, ErrNilTransaction, err)
+		assert.Zero(t, gasUsed)
+		assert.Nil(t, fee)
+
+		var nilTx *transaction.Transaction
+		_, _, err = fc.ComputeGasUsedAndFeeBasedOnRefundValue(nilTx, big.NewInt(1))
+		assert.Equal(t, ErrNilTransaction, err)
+	})
+	t.Run("negative refund should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := fc.ComputeGasUsedAndFeeBasedOnRefundValue(createScCallTx(), big.NewInt(-1))
+		assert.Equal(t, ErrNegativeRefund, err)
+	})
+	t.Run("refund higher than fee should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := fc.ComputeGasUsedAndFeeBasedOnRefundValue(createScCallTx(), big.NewInt(58_925_000_000_001))
+		assert.True(t, errors.Is(err, ErrRefundHigherThanFee))
+	})
+	t.Run("no refund consumes the whole gas limit", func(t *testing.T) {
+		t.Parallel()
+
+		gasUsed, fee, err := fc.ComputeGasUsedAndFeeBasedOnRefundValue(createScCallTx(), nil)
+		require.Nil(t, err)
+		assert.Equal(t, uint64(200_000), gasUsed)
+		assert.Equal(t, big.NewInt(58_925_000_000_000), fee)
+	})
+	t.Run("partial refund", func(t *testing.T) {
+		t.Parallel()
+
+		gasUsed, fee, err := fc.ComputeGasUsedAndFeeBasedOnRefundValue(createScCallTx(), big.NewInt(1_000_000_000_000))
+		require.Nil(t, err)
+		assert.Equal(t, uint64(100_000), gasUsed)
+		assert.Equal(t, big.NewInt(57_925_000_000_000), fee)
+	})
+	t.Run("refund of the whole processing fee", func(t *testing.T) {
+		t.Parallel()
+
+		gasUsed, fee, err := fc.ComputeGasUsedAndFeeBasedOnRefundValue(createScCallTx(), big.NewInt(1_425_000_000_000))
+		require.Nil(t, err)
+		assert.Equal(t, uint64(57_500), gasUsed)
+		assert.Equal(t, big.NewInt(57_500_000_000_000), fee)
+	})
+}
+
+func TestFeeComputer_ComputeFeeInfo(t *testing.T) {
+	t.Parallel()
+
+	fc, _ := NewFeeComputer(createMockArgsFeeComputer())
+
+	feeInfo, err := fc.ComputeFeeInfo(createScCallTx(), big.NewInt(-1))
+	assert.Nil(t, feeInfo)
+	assert.Equal(t, ErrNegativeRefund, err)
+
+	feeInfo, err = fc.ComputeFeeInfo(createScCallTx(), big.NewInt(1_000_000_000_000))
+	require.Nil(t, err)
+	assert.Equal(t, &outport.FeeInfo{
+		GasUsed:        100_000,
+		Fee:            big.NewInt(57_925_000_000_000),
+		InitialPaidFee: big.NewInt(58_925_000_000_000),
+		GasRefunded:    100_000,
+		HadRefund:      true,
+	}, feeInfo)
+
+	feeInfo, err = fc.ComputeFeeInfo(createScCallTx(), big.NewInt(0))
+	require.Nil(t, err)
+	assert.Equal(t, &outport.FeeInfo{
+		GasUsed:        200_000,
+		Fee:            big.NewInt(58_925_000_000_000),
+		InitialPaidFee: big.NewInt(58_925_000_000_000),
+	}, feeInfo)
+}
@@ -0,0 +1,27 @@
+package guardians
+
+import "errors"
+
+// ErrActiveGuardianNotFound signals that no active guardian was found for the provided epoch
+var ErrActiveGuardianNotFound = errors.New("active guardian not found")
+
+// ErrPendingGuardianNotFound signals that no pending guardian was found for the provided epoch
+var ErrPendingGuardianNotFound = errors.New("pending guardian not found")
+
+// ErrNilTransaction signals that a nil transaction has been provided
+var ErrNilTransaction = errors.New("nil transaction")
+
+// ErrNilPubkeyConverter signals that a nil public key converter has been provided
+var ErrNilPubkeyConverter = errors.New("nil public key converter")
+
+// ErrGuardedTransactionNotExpected signals that a guarded transaction was sent from an account that is not guarded
+var ErrGuardedTransactionNotExpected = errors.New("guarded transaction not expected")
+
+// ErrMissingGuardianOption signals that a transaction sent from a guarded account does not have the guardian option set
+var ErrMissingGuardianOption = errors.New("guardian option not set on transaction from guarded account")
+
+// ErrGuardianMismatch signals that the transaction's guardian is not the account's active guardian
+var ErrGuardianMismatch = errors.New("transaction guardian does not match the active guardian")
+
+// ErrMissingGuardianSignature signals that a guarded transaction does not have the guardian signature set
+var ErrMissingGuardianSignature = errors.New("missing guardian signature")
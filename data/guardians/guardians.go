@@ -1,2 +1,140 @@
 //go:generate protoc -I=. -I=$GOPATH/src -I=$GOPATH/src/github.com/multiversx/protobuf/protobuf --gogoslick_out=. guardians.proto
 package guardians
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/api"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+)
+
+// GetActiveGuardian returns the guardian active in the provided epoch. If more guardians are active, the one
+// with the most recent activation epoch is returned
+func (gs *Guardians) GetActiveGuardian(epoch uint32) (*Guardian, error) {
+	var selectedGuardian *Guardian
+	for _, guardian := range gs.GetSlice() {
+		if guardian == nil || guardian.ActivationEpoch > epoch {
+			continue
+		}
+		if selectedGuardian == nil || selectedGuardian.ActivationEpoch < guardian.ActivationEpoch {
+			selectedGuardian = guardian
+		}
+	}
+
+	if selectedGuardian == nil {
+		return nil, ErrActiveGuardianNotFound
+	}
+
+	return selectedGuardian, nil
+}
+
+// GetPendingGuardian returns the guardian that will become active in a future epoch. If more guardians are
+// pending, the one with the most recent activation epoch is returned
+func (gs *Guardians) GetPendingGuardian(epoch uint32) (*Guardian, error) {
+	var selectedGuardian *Guardian
+	for _, guardian := range gs.GetSlice() {
+		if guardian == nil || guardian.ActivationEpoch <= epoch {
+			continue
+		}
+		if selectedGuardian == nil || selectedGuardian.ActivationEpoch < guardian.ActivationEpoch {
+			selectedGuardian = guardian
+		}
+	}
+
+	if selectedGuardian == nil {
+		return nil, ErrPendingGuardianNotFound
+	}
+
+	return selectedGuardian, nil
+}
+
+// CheckGuardedTransaction verifies the provided transaction against the account's guarded state and the guardian
+// active in the provided epoch. A guarded account can send unguarded transactions only for setting a new guardian
+func (gs *Guardians) CheckGuardedTransaction(tx *transaction.Transaction, isAccountGuarded bool, epoch uint32) error {
+	if tx == nil {
+		return ErrNilTransaction
+	}
+
+	isGuardedTx := tx.Version > core.InitialVersionOfTransaction && tx.HasOptionGuardianSet()
+	if !isAccountGuarded {
+		if isGuardedTx {
+			return ErrGuardedTransactionNotExpected
+		}
+
+		return nil
+	}
+
+	if !isGuardedTx {
+		if isSetGuardianCall(tx.Data) {
+			return nil
+		}
+
+		return ErrMissingGuardianOption
+	}
+
+	activeGuardian, err := gs.GetActiveGuardian(epoch)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(activeGuardian.Address, tx.GuardianAddr) {
+		return ErrGuardianMismatch
+	}
+	if len(tx.GuardianSignature) == 0 {
+		return ErrMissingGuardianSignature
+	}
+
+	return nil
+}
+
+// ToApiGuardianData converts the guardians into their API representation, as seen in the provided epoch
+func (gs *Guardians) ToApiGuardianData(
+	isAccountGuarded bool,
+	epoch uint32,
+	pubKeyConverter core.PubkeyConverter,
+) (*api.GuardianData, error) {
+	if check.IfNil(pubKeyConverter) {
+		return nil, ErrNilPubkeyConverter
+	}
+
+	guardianData := &api.GuardianData{
+		Guarded: isAccountGuarded,
+	}
+
+	activeGuardian, err := gs.GetActiveGuardian(epoch)
+	if err == nil {
+		guardianData.ActiveGuardian, err = convertGuardian(activeGuardian, pubKeyConverter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pendingGuardian, err := gs.GetPendingGuardian(epoch)
+	if err == nil {
+		guardianData.PendingGuardian, err = convertGuardian(pendingGuardian, pubKeyConverter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return guardianData, nil
+}
+
+func convertGuardian(guardian *Guardian, pubKeyConverter core.PubkeyConverter) (*api.Guardian, error) {
+	address, err := pubKeyConverter.Encode(guardian.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.Guardian{
+		Address:         address,
+		ActivationEpoch: guardian.ActivationEpoch,
+		ServiceUID:      string(guardian.ServiceUID),
+	}, nil
+}
+
+func isSetGuardianCall(txData []byte) bool {
+	return strings.HasPrefix(string(txData)+"@", core.BuiltInFunctionSetGuardian+"@")
+}
@@ -0,0 +1,201 @@
+package guardians
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/api"
+	"github.com/multiversx/mx-chain-core-go/data/mock"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createGuardians() *Guardians {
+	return &Guardians{
+		Slice: []*Guardian{
+			{Address: []byte("old guardian"), ActivationEpoch: 10, ServiceUID: []byte("uid1")},
+			{Address: []byte("new guardian"), ActivationEpoch: 20, ServiceUID: []byte("uid2")},
+		},
+	}
+}
+
+func createGuardedTx(guardian []byte) *transaction.Transaction {
+	return &transaction.Transaction{
+		Version:           2,
+		Options:           transaction.MaskGuardedTransaction,
+		GuardianAddr:      guardian,
+		GuardianSignature: []byte("signature"),
+	}
+}
+
+func TestGuardians_GetActiveGuardian(t *testing.T) {
+	t.Parallel()
+
+	gs := createGuardians()
+
+	guardian, err := gs.GetActiveGuardian(9)
+	assert.Nil(t, guardian)
+	assert.Equal(t, ErrActiveGuardianNotFound, err)
+
+	guardian, err = gs.GetActiveGuardian(10)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("old guardian"), guardian.Address)
+
+	guardian, err = gs.GetActiveGuardian(25)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("new guardian"), guardian.Address)
+
+	var nilGuardians *Guardians
+	guardian, err = nilGuardians.GetActiveGuardian(25)
+	assert.Nil(t, guardian)
+	assert.Equal(t, ErrActiveGuardianNotFound, err)
+
+	gs.Slice = append(gs.Slice, nil)
+	guardian, err = gs.GetActiveGuardian(25)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("new guardian"), guardian.Address)
+}
+
+func TestGuardians_GetPendingGuardian(t *testing.T) {
+	t.Parallel()
+
+	gs := createGuardians()
+
+	guardian, err := gs.GetPendingGuardian(15)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("new guardian"), guardian.Address)
+
+	guardian, err = gs.GetPendingGuardian(20)
+	assert.Nil(t, guardian)
+	assert.Equal(t, ErrPendingGuardianNotFound, err)
+}
+
+func TestGuardians_CheckGuardedTransaction(t *testing.T) {
+	t.Parallel()
+
+	gs := createGuardians()
+
+	t.Run("nil transaction should error", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, ErrNilTransaction, gs.CheckGuardedTransaction(nil, true, 25))
+	})
+	t.Run("account not guarded", func(t *testing.T) {
+		t.Parallel()
+
+		err := gs.CheckGuardedTransaction(&transaction.Transaction{Version: 2}, false, 25)
+		assert.Nil(t, err)
+
+		err = gs.CheckGuardedTransaction(createGuardedTx([]byte("new guardian")), false, 25)
+		assert.Equal(t, ErrGuardedTransactionNotExpected, err)
+	})
+	t.Run("guardian option on initial version is ignored", func(t *testing.T) {
+		t.Parallel()
+
+		tx := createGuardedTx([]byte("new guardian"))
+		tx.Version = 1
+		err := gs.CheckGuardedTransaction(tx, false, 25)
+		assert.Nil(t, err)
+	})
+	t.Run("unguarded transaction from guarded account", func(t *testing.T) {
+		t.Parallel()
+
+		err := gs.CheckGuardedTransaction(&transaction.Transaction{Version: 2}, true, 25)
+		assert.Equal(t, ErrMissingGuardianOption, err)
+
+		tx := &transaction.Transaction{Version: 2, Data: []byte("SetGuardian@6775617264@756964")}
+		err = gs.CheckGuardedTransaction(tx, true, 25)
+		assert.Nil(t, err)
+
+		tx.Data = []byte("SetGuardianX@01")
+		err = gs.CheckGuardedTransaction(tx, true, 25)
+		assert.Equal(t, ErrMissingGuardianOption, err)
+	})
+	t.Run("no active guardian", func(t *testing.T) {
+		t.Parallel()
+
+		err := gs.CheckGuardedTransaction(createGuardedTx([]byte("old guardian")), true, 5)
+		assert.Equal(t, ErrActiveGuardianNotFound, err)
+	})
+	t.Run("pending guardian is not accepted", func(t *testing.T) {
+		t.Parallel()
+
+		err := gs.CheckGuardedTransaction(createGuardedTx([]byte("new guardian")), true, 15)
+		assert.Equal(t, ErrGuardianMismatch, err)
+	})
+	t.Run("missing guardian signature", func(t *testing.T) {
+		t.Parallel()
+
+		tx := createGuardedTx([]byte("old guardian"))
+		tx.GuardianSignature = nil
+		err := gs.CheckGuardedTransaction(tx, true, 15)
+		assert.Equal(t, ErrMissingGuardianSignature, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		err := gs.CheckGuardedTransaction(createGuardedTx([]byte("old guardian")), true, 15)
+		assert.Nil(t, err)
+
+		err = gs.CheckGuardedTransaction(createGuardedTx([]byte("new guardian")), true, 20)
+		assert.Nil(t, err)
+	})
+}
+
+func TestGuardians_ToApiGuardianData(t *testing.T) {
+	t.Parallel()
+
+	gs := createGuardians()
+	pkConverter := &mock.PubkeyConverterStub{
+		EncodeCalled: func(pkBytes []byte) (string, error) {
+			return "erd-" + string(pkBytes), nil
+		},
+	}
+
+	t.Run("nil pub key converter should error", func(t *testing.T) {
+		t.Parallel()
+
+		guardianData, err := gs.ToApiGuardianData(true, 15, nil)
+		assert.Nil(t, guardianData)
+		assert.Equal(t, ErrNilPubkeyConverter, err)
+	})
+	t.Run("encode error should error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		guardianData, err := gs.ToApiGuardianData(true, 15, &mock.PubkeyConverterStub{
+			EncodeCalled: func(pkBytes []byte) (string, error) {
+				return "", expectedErr
+			},
+		})
+		assert.Nil(t, guardianData)
+		assert.Equal(t, expectedErr, err)
+	})
+	t.Run("active and pending guardians", func(t *testing.T) {
+		t.Parallel()
+
+		guardianData, err := gs.ToApiGuardianData(true, 15, pkConverter)
+		require.Nil(t, err)
+		assert.Equal(t, &api.GuardianData{
+			ActiveGuardian: &api.Guardian{
+				Address:         "erd-old guardian",
+				ActivationEpoch: 10,
+				ServiceUID:      "uid1",
+			},
+			PendingGuardian: &api.Guardian{
+				Address:         "erd-new guardian",
+				ActivationEpoch: 20,
+				ServiceUID:      "uid2",
+			},
+			Guarded: true,
+		}, guardianData)
+	})
+	t.Run("no guardians", func(t *testing.T) {
+		t.Parallel()
+
+		guardianData, err := (&Guardians{}).ToApiGuardianData(false, 15, pkConverter)
+		require.Nil(t, err)
+		assert.Equal(t, &api.GuardianData{}, guardianData)
+	})
+}
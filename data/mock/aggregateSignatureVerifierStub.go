@@ -0,0 +1,20 @@
+package mock
+
+// AggregateSignatureVerifierStub -
+type AggregateSignatureVerifierStub struct {
+	VerifyAggregatedSignatureCalled func(publicKeys [][]byte, message []byte, aggregatedSignature []byte) error
+}
+
+// VerifyAggregatedSignature -
+func (stub *AggregateSignatureVerifierStub) VerifyAggregatedSignature(publicKeys [][]byte, message []byte, aggregatedSignature []byte) error {
+	if stub.VerifyAggregatedSignatureCalled != nil {
+		return stub.VerifyAggregatedSignatureCalled(publicKeys, message, aggregatedSignature)
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (stub *AggregateSignatureVerifierStub) IsInterfaceNil() bool {
+	return stub == nil
+}
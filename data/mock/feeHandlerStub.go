@@ -0,0 +1,32 @@
+package mock
+
+import "github.com/multiversx/mx-chain-core-go/data"
+
+// FeeHandlerStub -
+type FeeHandlerStub struct {
+	ComputeGasLimitCalled func(tx data.TransactionWithFeeHandler) uint64
+	MinGasLimitCalled     func() uint64
+}
+
+// ComputeGasLimit -
+func (stub *FeeHandlerStub) ComputeGasLimit(tx data.TransactionWithFeeHandler) uint64 {
+	if stub.ComputeGasLimitCalled != nil {
+		return stub.ComputeGasLimitCalled(tx)
+	}
+
+	return 0
+}
+
+// MinGasLimit -
+func (stub *FeeHandlerStub) MinGasLimit() uint64 {
+	if stub.MinGasLimitCalled != nil {
+		return stub.MinGasLimitCalled()
+	}
+
+	return 0
+}
+
+// IsInterfaceNil -
+func (stub *FeeHandlerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
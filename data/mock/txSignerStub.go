@@ -0,0 +1,30 @@
+package mock
+
+// TxSignerStub -
+type TxSignerStub struct {
+	SignCalled      func(message []byte) ([]byte, error)
+	PublicKeyCalled func() []byte
+}
+
+// Sign -
+func (stub *TxSignerStub) Sign(message []byte) ([]byte, error) {
+	if stub.SignCalled != nil {
+		return stub.SignCalled(message)
+	}
+
+	return make([]byte, 0), nil
+}
+
+// PublicKey -
+func (stub *TxSignerStub) PublicKey() []byte {
+	if stub.PublicKeyCalled != nil {
+		return stub.PublicKeyCalled()
+	}
+
+	return make([]byte, 0)
+}
+
+// IsInterfaceNil -
+func (stub *TxSignerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
@@ -11,3 +11,87 @@ var errCannotCastBlockBody = errors.New("cannot cast block body")
 var errNilHeaderProof = errors.New("nil header proof")
 
 var errCannotCastHeaderProof = errors.New("cannot cast header proof")
+
+// ErrNilMarshaller signals that a nil marshaller has been provided
+var ErrNilMarshaller = errors.New("nil marshaller")
+
+// ErrNilHasher signals that a nil hasher has been provided
+var ErrNilHasher = errors.New("nil hasher")
+
+// ErrNilHeaderDataWithBody signals that nil header data with body has been provided
+var ErrNilHeaderDataWithBody = errors.New("nil header data with body")
+
+// ErrNilHeader signals that a nil header has been provided
+var ErrNilHeader = errors.New("nil header")
+
+// ErrNilOutportBlock signals that a nil outport block has been provided
+var ErrNilOutportBlock = errors.New("nil outport block")
+
+// ErrNilBlockData signals that the outport block has no block data
+var ErrNilBlockData = errors.New("nil block data")
+
+// ErrNilTransactionPool signals that the outport block has no transaction pool
+var ErrNilTransactionPool = errors.New("nil transaction pool")
+
+// ErrHeaderHashMismatch signals that the header hash does not match the hash of the header bytes
+var ErrHeaderHashMismatch = errors.New("header hash mismatch")
+
+// ErrShardIDMismatch signals that the outport block and its block data have different shard IDs
+var ErrShardIDMismatch = errors.New("shard ID mismatch")
+
+// ErrProofMismatch signals that the header proof does not reference the block header
+var ErrProofMismatch = errors.New("header proof does not match the header")
+
+// ErrInvalidHexHash signals that a transaction pool key is not a hex encoded hash
+var ErrInvalidHexHash = errors.New("invalid hex hash")
+
+// ErrNilPoolEntry signals that a transaction pool entry is nil
+var ErrNilPoolEntry = errors.New("nil transaction pool entry")
+
+// ErrTransactionNotInBlock signals that a transaction from the pool is not referenced by any block miniBlock
+var ErrTransactionNotInBlock = errors.New("transaction not referenced by the block miniBlocks")
+
+// ErrDuplicatedExecutionOrder signals that two transactions have the same execution order
+var ErrDuplicatedExecutionOrder = errors.New("duplicated execution order")
+
+// ErrLogWithoutTransaction signals that a log references a transaction which is not in the transaction pool
+var ErrLogWithoutTransaction = errors.New("log references a transaction missing from the pool")
+
+// ErrReceiptWithoutTransaction signals that a receipt references a transaction which is not in the transaction pool
+var ErrReceiptWithoutTransaction = errors.New("receipt references a transaction missing from the pool")
+
+// ErrInvalidSignersIndexes signals that the signers indexes are not strictly increasing
+var ErrInvalidSignersIndexes = errors.New("invalid signers indexes")
+
+// ErrEmptyFilterRules signals that no filter rule has been provided
+var ErrEmptyFilterRules = errors.New("empty filter rules")
+
+// ErrInvalidFilterAddress signals that a filter rule holds an address which cannot be decoded
+var ErrInvalidFilterAddress = errors.New("invalid filter address")
+
+// ErrNilHeaderDecoder signals that a nil header decoder has been provided
+var ErrNilHeaderDecoder = errors.New("nil header decoder")
+
+// ErrNilFinalizedBlockHandler signals that a nil finalized block handler has been provided
+var ErrNilFinalizedBlockHandler = errors.New("nil finalized block handler")
+
+// ErrInvalidMaxNonFinalBlocks signals that an invalid maximum number of non-final blocks has been provided
+var ErrInvalidMaxNonFinalBlocks = errors.New("invalid maximum number of non-final blocks")
+
+// ErrNilFinalizedBlock signals that a nil finalized block has been provided
+var ErrNilFinalizedBlock = errors.New("nil finalized block")
+
+// ErrBlockNonceGap signals that a block does not follow the last tracked block of its shard
+var ErrBlockNonceGap = errors.New("block nonce gap")
+
+// ErrForkDetected signals that a block conflicts with the blocks already tracked for its shard
+var ErrForkDetected = errors.New("fork detected")
+
+// ErrUnknownBlock signals that the referenced block is not tracked
+var ErrUnknownBlock = errors.New("unknown block")
+
+// ErrRevertFinalizedBlock signals an attempt to revert a finalized block
+var ErrRevertFinalizedBlock = errors.New("cannot revert a finalized block")
+
+// ErrTooManyNonFinalBlocks signals that the maximum number of non-final blocks of a shard has been reached
+var ErrTooManyNonFinalBlocks = errors.New("too many non-final blocks")
@@ -0,0 +1,319 @@
+package outport
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+)
+
+// ArgsFinalityTracker holds the components needed to create a new finality tracker
+type ArgsFinalityTracker struct {
+	HeaderDecoder HeaderDecoder
+	// FinalizedBlockHandler is called for every finalized block, in nonce order for each shard. It is called while
+	// the tracker is locked, so it must not call back into the tracker
+	FinalizedBlockHandler     func(outportBlock *OutportBlock)
+	MaxNonFinalBlocksPerShard int
+}
+
+type trackedBlock struct {
+	nonce        uint64
+	hash         []byte
+	prevHash     []byte
+	outportBlock *OutportBlock
+}
+
+type shardFinalityState struct {
+	lastFinalized *trackedBlock
+	nonFinal      []*trackedBlock
+}
+
+// finalityTracker buffers the non-final outport blocks of each shard until their finality is confirmed, either by
+// the HighestFinalBlockNonce and HighestFinalBlockHash of a newer block or by a finalized block notification.
+// Reverted blocks are dropped from the buffer, while blocks which do not extend the chain of their shard are
+// rejected as gaps or forks
+type finalityTracker struct {
+	headerDecoder             HeaderDecoder
+	finalizedBlockHandler     func(outportBlock *OutportBlock)
+	maxNonFinalBlocksPerShard int
+
+	mut    sync.Mutex
+	shards map[uint32]*shardFinalityState
+}
+
+// NewFinalityTracker creates a new finality tracker
+func NewFinalityTracker(args ArgsFinalityTracker) (*finalityTracker, error) {
+	if check.IfNil(args.HeaderDecoder) {
+		return nil, ErrNilHeaderDecoder
+	}
+	if args.FinalizedBlockHandler == nil {
+		return nil, ErrNilFinalizedBlockHandler
+	}
+	if args.MaxNonFinalBlocksPerShard < 1 {
+		return nil, fmt.Errorf("%w, %d", ErrInvalidMaxNonFinalBlocks, args.MaxNonFinalBlocksPerShard)
+	}
+
+	return &finalityTracker{
+		headerDecoder:             args.HeaderDecoder,
+		finalizedBlockHandler:     args.FinalizedBlockHandler,
+		maxNonFinalBlocksPerShard: args.MaxNonFinalBlocksPerShard,
+		shards:                    make(map[uint32]*shardFinalityState),
+	}, nil
+}
+
+// AddBlock buffers the provided block as non-final and then finalizes the blocks of its shard up to the highest
+// final block nonce it carries. A block already tracked is ignored, so the blocks can be safely delivered more than
+// once. The block must extend the last tracked block of its shard, otherwise ErrBlockNonceGap or ErrForkDetected is
+// returned and the tracker state is left unchanged
+func (ft *finalityTracker) AddBlock(outportBlock *OutportBlock) error {
+	tracked, err := ft.createTrackedBlock(outportBlock)
+	if err != nil {
+		return err
+	}
+
+	ft.mut.Lock()
+	defer ft.mut.Unlock()
+
+	state := ft.getShardState(outportBlock.ShardID)
+	if state.isTracked(tracked) {
+		return nil
+	}
+
+	err = state.checkExtendsChain(tracked)
+	if err != nil {
+		return fmt.Errorf("%w, shard %d", err, outportBlock.ShardID)
+	}
+
+	highestFinalNonce := outportBlock.HighestFinalBlockNonce
+	highestFinalHash := outportBlock.HighestFinalBlockHash
+	if len(highestFinalHash) > 0 {
+		err = state.checkFinalBlockHash(highestFinalNonce, highestFinalHash)
+		if err != nil {
+			return fmt.Errorf("%w, shard %d", err, outportBlock.ShardID)
+		}
+	}
+	if state.countNonFinalAfterNonce(highestFinalNonce, tracked) > ft.maxNonFinalBlocksPerShard {
+		return fmt.Errorf("%w, shard %d, maximum %d", ErrTooManyNonFinalBlocks, outportBlock.ShardID, ft.maxNonFinalBlocksPerShard)
+	}
+
+	state.nonFinal = append(state.nonFinal, tracked)
+	ft.finalizeUpToNonce(state, highestFinalNonce)
+
+	return nil
+}
+
+func (ft *finalityTracker) createTrackedBlock(outportBlock *OutportBlock) (*trackedBlock, error) {
+	if outportBlock == nil {
+		return nil, ErrNilOutportBlock
+	}
+	blockData := outportBlock.BlockData
+	if blockData == nil {
+		return nil, ErrNilBlockData
+	}
+
+	header, _, err := ft.headerDecoder.Decode(blockData.HeaderBytes, core.HeaderType(blockData.HeaderType))
+	if err != nil {
+		return nil, err
+	}
+
+	return &trackedBlock{
+		nonce:        header.GetNonce(),
+		hash:         blockData.HeaderHash,
+		prevHash:     header.GetPrevHash(),
+		outportBlock: outportBlock,
+	}, nil
+}
+
+// Revert drops the reverted block, along with all the non-final blocks built on top of it
+func (ft *finalityTracker) Revert(blockData *BlockData) error {
+	if blockData == nil {
+		return ErrNilBlockData
+	}
+
+	ft.mut.Lock()
+	defer ft.mut.Unlock()
+
+	state := ft.getShardState(blockData.ShardID)
+	index := state.getNonFinalIndex(blockData.HeaderHash)
+	if index >= 0 {
+		state.nonFinal = state.nonFinal[:index]
+		return nil
+	}
+
+	if state.lastFinalized != nil && bytes.Equal(state.lastFinalized.hash, blockData.HeaderHash) {
+		return fmt.Errorf("%w, shard %d, hash %s", ErrRevertFinalizedBlock, blockData.ShardID, hex.EncodeToString(blockData.HeaderHash))
+	}
+
+	return fmt.Errorf("%w, shard %d, hash %s", ErrUnknownBlock, blockData.ShardID, hex.EncodeToString(blockData.HeaderHash))
+}
+
+// Finalize finalizes the referenced block, along with all the older non-final blocks of its shard
+func (ft *finalityTracker) Finalize(finalizedBlock *FinalizedBlock) error {
+	if finalizedBlock == nil {
+		return ErrNilFinalizedBlock
+	}
+
+	ft.mut.Lock()
+	defer ft.mut.Unlock()
+
+	state := ft.getShardState(finalizedBlock.ShardID)
+	index := state.getNonFinalIndex(finalizedBlock.HeaderHash)
+	if index >= 0 {
+		ft.finalizeUpToNonce(state, state.nonFinal[index].nonce)
+		return nil
+	}
+
+	if state.lastFinalized != nil && bytes.Equal(state.lastFinalized.hash, finalizedBlock.HeaderHash) {
+		return nil
+	}
+
+	return fmt.Errorf("%w, shard %d, hash %s", ErrUnknownBlock, finalizedBlock.ShardID, hex.EncodeToString(finalizedBlock.HeaderHash))
+}
+
+func (ft *finalityTracker) finalizeUpToNonce(state *shardFinalityState, nonce uint64) {
+	numFinalized := 0
+	for _, tracked := range state.nonFinal {
+		if tracked.nonce > nonce {
+			break
+		}
+
+		ft.finalizedBlockHandler(tracked.outportBlock)
+		state.lastFinalized = &trackedBlock{
+			nonce: tracked.nonce,
+			hash:  tracked.hash,
+		}
+		numFinalized++
+	}
+
+	state.nonFinal = state.nonFinal[numFinalized:]
+}
+
+func (ft *finalityTracker) getShardState(shardID uint32) *shardFinalityState {
+	state, found := ft.shards[shardID]
+	if !found {
+		state = &shardFinalityState{
+			nonFinal: make([]*trackedBlock, 0),
+		}
+		ft.shards[shardID] = state
+	}
+
+	return state
+}
+
+// GetNonFinalBlocks returns the non-final blocks of the provided shard, in nonce order
+func (ft *finalityTracker) GetNonFinalBlocks(shardID uint32) []*OutportBlock {
+	ft.mut.Lock()
+	defer ft.mut.Unlock()
+
+	state, found := ft.shards[shardID]
+	if !found {
+		return make([]*OutportBlock, 0)
+	}
+
+	blocks := make([]*OutportBlock, 0, len(state.nonFinal))
+	for _, tracked := range state.nonFinal {
+		blocks = append(blocks, tracked.outportBlock)
+	}
+
+	return blocks
+}
+
+// GetLastFinalizedBlock returns the nonce and the hash of the last finalized block of the provided shard. The last
+// return value is false if no block of the shard was finalized yet
+func (ft *finalityTracker) GetLastFinalizedBlock(shardID uint32) (uint64, []byte, bool) {
+	ft.mut.Lock()
+	defer ft.mut.Unlock()
+
+	state, found := ft.shards[shardID]
+	if !found || state.lastFinalized == nil {
+		return 0, nil, false
+	}
+
+	return state.lastFinalized.nonce, state.lastFinalized.hash, true
+}
+
+func (sfs *shardFinalityState) getTip() *trackedBlock {
+	if len(sfs.nonFinal) > 0 {
+		return sfs.nonFinal[len(sfs.nonFinal)-1]
+	}
+
+	return sfs.lastFinalized
+}
+
+func (sfs *shardFinalityState) getNonFinalIndex(hash []byte) int {
+	for i, tracked := range sfs.nonFinal {
+		if bytes.Equal(tracked.hash, hash) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (sfs *shardFinalityState) isTracked(tracked *trackedBlock) bool {
+	if sfs.getNonFinalIndex(tracked.hash) >= 0 {
+		return true
+	}
+
+	return sfs.lastFinalized != nil && bytes.Equal(sfs.lastFinalized.hash, tracked.hash)
+}
+
+func (sfs *shardFinalityState) checkExtendsChain(tracked *trackedBlock) error {
+	tip := sfs.getTip()
+	if tip == nil {
+		return nil
+	}
+
+	if tracked.nonce > tip.nonce+1 {
+		return fmt.Errorf("%w, expected nonce %d, received %d", ErrBlockNonceGap, tip.nonce+1, tracked.nonce)
+	}
+	if tracked.nonce <= tip.nonce {
+		return fmt.Errorf("%w, block with nonce %d received after nonce %d", ErrForkDetected, tracked.nonce, tip.nonce)
+	}
+	if !bytes.Equal(tracked.prevHash, tip.hash) {
+		return fmt.Errorf("%w, nonce %d does not extend %s", ErrForkDetected, tracked.nonce, hex.EncodeToString(tip.hash))
+	}
+
+	return nil
+}
+
+// countNonFinalAfterNonce returns the number of blocks left non-final if the new block is added and the blocks up to
+// the provided nonce are finalized
+func (sfs *shardFinalityState) countNonFinalAfterNonce(nonce uint64, newBlock *trackedBlock) int {
+	count := 0
+	if newBlock.nonce > nonce {
+		count++
+	}
+	for _, tracked := range sfs.nonFinal {
+		if tracked.nonce > nonce {
+			count++
+		}
+	}
+
+	return count
+}
+
+// checkFinalBlockHash verifies that the tracked block with the provided nonce, if any, has the provided hash
+func (sfs *shardFinalityState) checkFinalBlockHash(nonce uint64, hash []byte) error {
+	blocks := append([]*trackedBlock{sfs.lastFinalized}, sfs.nonFinal...)
+	for _, tracked := range blocks {
+		if tracked == nil || tracked.nonce != nonce {
+			continue
+		}
+
+		if !bytes.Equal(tracked.hash, hash) {
+			return fmt.Errorf("%w, final block with nonce %d is %s, tracked %s",
+				ErrForkDetected, nonce, hex.EncodeToString(hash), hex.EncodeToString(tracked.hash))
+		}
+	}
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ft *finalityTracker) IsInterfaceNil() bool {
+	return ft == nil
+}
@@ -0,0 +1,221 @@
+package outport
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/core/mock"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type finalizedBlocksCollector struct {
+	hashes []string
+}
+
+func (fbc *finalizedBlocksCollector) handle(outportBlock *OutportBlock) {
+	fbc.hashes = append(fbc.hashes, string(outportBlock.BlockData.HeaderHash))
+}
+
+func createMockArgsFinalityTracker(collector *finalizedBlocksCollector) ArgsFinalityTracker {
+	headerDecoder, _ := block.NewHeaderDecoder(&mock.MarshalizerMock{})
+
+	return ArgsFinalityTracker{
+		HeaderDecoder:             headerDecoder,
+		FinalizedBlockHandler:     collector.handle,
+		MaxNonFinalBlocksPerShard: 10,
+	}
+}
+
+// createTrackedOutportBlock creates a block of shard 1 with the hash "<prefix><nonce>" on top of prevHash
+func createTrackedOutportBlock(t *testing.T, prefix string, nonce uint64, prevHash string, highestFinalNonce uint64) *OutportBlock {
+	header := &block.HeaderV2{Header: &block.Header{Nonce: nonce, ShardID: 1, PrevHash: []byte(prevHash)}}
+	headerBytes, err := (&mock.MarshalizerMock{}).Marshal(header)
+	require.Nil(t, err)
+
+	return &OutportBlock{
+		ShardID: 1,
+		BlockData: &BlockData{
+			ShardID:     1,
+			HeaderBytes: headerBytes,
+			HeaderType:  string(core.ShardHeaderV2),
+			HeaderHash:  []byte(fmt.Sprintf("%s%d", prefix, nonce)),
+		},
+		HighestFinalBlockNonce: highestFinalNonce,
+	}
+}
+
+func TestNewFinalityTracker(t *testing.T) {
+	t.Parallel()
+
+	collector := &finalizedBlocksCollector{}
+
+	args := createMockArgsFinalityTracker(collector)
+	args.HeaderDecoder = nil
+	ft, err := NewFinalityTracker(args)
+	assert.Equal(t, ErrNilHeaderDecoder, err)
+	assert.True(t, check.IfNil(ft))
+
+	args = createMockArgsFinalityTracker(collector)
+	args.FinalizedBlockHandler = nil
+	_, err = NewFinalityTracker(args)
+	assert.Equal(t, ErrNilFinalizedBlockHandler, err)
+
+	args = createMockArgsFinalityTracker(collector)
+	args.MaxNonFinalBlocksPerShard = 0
+	_, err = NewFinalityTracker(args)
+	assert.True(t, errors.Is(err, ErrInvalidMaxNonFinalBlocks))
+
+	ft, err = NewFinalityTracker(createMockArgsFinalityTracker(collector))
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(ft))
+}
+
+func TestFinalityTracker_AddBlock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid blocks should error", func(t *testing.T) {
+		t.Parallel()
+
+		ft, _ := NewFinalityTracker(createMockArgsFinalityTracker(&finalizedBlocksCollector{}))
+		assert.Equal(t, ErrNilOutportBlock, ft.AddBlock(nil))
+		assert.Equal(t, ErrNilBlockData, ft.AddBlock(&OutportBlock{}))
+		assert.Equal(t, block.ErrEmptyHeaderBytes, ft.AddBlock(&OutportBlock{BlockData: &BlockData{}}))
+	})
+	t.Run("blocks should be finalized in order by the highest final nonce", func(t *testing.T) {
+		t.Parallel()
+
+		collector := &finalizedBlocksCollector{}
+		ft, _ := NewFinalityTracker(createMockArgsFinalityTracker(collector))
+
+		require.Nil(t, ft.AddBlock(createTrackedOutportBlock(t, "h", 10, "h9", 9)))
+		require.Nil(t, ft.AddBlock(createTrackedOutportBlock(t, "h", 11, "h10", 9)))
+		require.Nil(t, ft.AddBlock(createTrackedOutportBlock(t, "h", 12, "h11", 9)))
+		assert.Empty(t, collector.hashes)
+		assert.Len(t, ft.GetNonFinalBlocks(1), 3)
+		_, _, found := ft.GetLastFinalizedBlock(1)
+		assert.False(t, found)
+
+		require.Nil(t, ft.AddBlock(createTrackedOutportBlock(t, "h", 13, "h12", 11)))
+		assert.Equal(t, []string{"h10", "h11"}, collector.hashes)
+		assert.Len(t, ft.GetNonFinalBlocks(1), 2)
+		nonce, hash, found := ft.GetLastFinalizedBlock(1)
+		assert.True(t, found)
+		assert.Equal(t, uint64(11), nonce)
+		assert.Equal(t, []byte("h11"), hash)
+		assert.Empty(t, ft.GetNonFinalBlocks(0))
+	})
+	t.Run("already tracked block should be ignored", func(t *testing.T) {
+		t.Parallel()
+
+		collector := &finalizedBlocksCollector{}
+		ft, _ := NewFinalityTracker(createMockArgsFinalityTracker(collector))
+
+		require.Nil(t, ft.AddBlock(createTrackedOutportBlock(t, "h", 10, "h9", 0)))
+		require.Nil(t, ft.AddBlock(createTrackedOutportBlock(t, "h", 11, "h10", 10)))
+		require.Nil(t, ft.AddBlock(createTrackedOutportBlock(t, "h", 10, "h9", 0)))
+		require.Nil(t, ft.AddBlock(createTrackedOutportBlock(t, "h", 11, "h10", 10)))
+
+		assert.Equal(t, []string{"h10"}, collector.hashes)
+		assert.Len(t, ft.GetNonFinalBlocks(1), 1)
+	})
+	t.Run("gap should error", func(t *testing.T) {
+		t.Parallel()
+
+		ft, _ := NewFinalityTracker(createMockArgsFinalityTracker(&finalizedBlocksCollector{}))
+
+		require.Nil(t, ft.AddBlock(createTrackedOutportBlock(t, "h", 10, "h9", 0)))
+		err := ft.AddBlock(createTrackedOutportBlock(t, "h", 12, "h11", 0))
+		assert.True(t, errors.Is(err, ErrBlockNonceGap))
+		assert.Len(t, ft.GetNonFinalBlocks(1), 1)
+	})
+	t.Run("forks should error", func(t *testing.T) {
+		t.Parallel()
+
+		ft, _ := NewFinalityTracker(createMockArgsFinalityTracker(&finalizedBlocksCollector{}))
+
+		require.Nil(t, ft.AddBlock(createTrackedOutportBlock(t, "h", 10, "h9", 0)))
+		require.Nil(t, ft.AddBlock(createTrackedOutportBlock(t, "h", 11, "h10", 0)))
+
+		err := ft.AddBlock(createTrackedOutportBlock(t, "f", 11, "h10", 0))
+		assert.True(t, errors.Is(err, ErrForkDetected))
+		err = ft.AddBlock(createTrackedOutportBlock(t, "f", 12, "f11", 0))
+		assert.True(t, errors.Is(err, ErrForkDetected))
+
+		forkedFinal := createTrackedOutportBlock(t, "h", 12, "h11", 10)
+		forkedFinal.HighestFinalBlockHash = []byte("f10")
+		err = ft.AddBlock(forkedFinal)
+		assert.True(t, errors.Is(err, ErrForkDetected))
+		assert.Len(t, ft.GetNonFinalBlocks(1), 2)
+	})
+	t.Run("too many non-final blocks should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsFinalityTracker(&finalizedBlocksCollector{})
+		args.MaxNonFinalBlocksPerShard = 2
+		ft, _ := NewFinalityTracker(args)
+
+		require.Nil(t, ft.AddBlock(createTrackedOutportBlock(t, "h", 10, "h9", 0)))
+		require.Nil(t, ft.AddBlock(createTrackedOutportBlock(t, "h", 11, "h10", 0)))
+		err := ft.AddBlock(createTrackedOutportBlock(t, "h", 12, "h11", 0))
+		assert.True(t, errors.Is(err, ErrTooManyNonFinalBlocks))
+
+		require.Nil(t, ft.AddBlock(createTrackedOutportBlock(t, "h", 12, "h11", 11)))
+	})
+}
+
+func TestFinalityTracker_Revert(t *testing.T) {
+	t.Parallel()
+
+	collector := &finalizedBlocksCollector{}
+	ft, _ := NewFinalityTracker(createMockArgsFinalityTracker(collector))
+
+	assert.Equal(t, ErrNilBlockData, ft.Revert(nil))
+
+	require.Nil(t, ft.AddBlock(createTrackedOutportBlock(t, "h", 10, "h9", 0)))
+	require.Nil(t, ft.AddBlock(createTrackedOutportBlock(t, "h", 11, "h10", 10)))
+	require.Nil(t, ft.AddBlock(createTrackedOutportBlock(t, "h", 12, "h11", 10)))
+	require.Nil(t, ft.AddBlock(createTrackedOutportBlock(t, "h", 13, "h12", 10)))
+
+	require.Nil(t, ft.Revert(&BlockData{ShardID: 1, HeaderHash: []byte("h12")}))
+	assert.Len(t, ft.GetNonFinalBlocks(1), 1)
+
+	err := ft.Revert(&BlockData{ShardID: 1, HeaderHash: []byte("h12")})
+	assert.True(t, errors.Is(err, ErrUnknownBlock))
+	err = ft.Revert(&BlockData{ShardID: 1, HeaderHash: []byte("h10")})
+	assert.True(t, errors.Is(err, ErrRevertFinalizedBlock))
+
+	require.Nil(t, ft.AddBlock(createTrackedOutportBlock(t, "f", 12, "h11", 11)))
+	assert.Equal(t, []string{"h10", "h11"}, collector.hashes)
+	assert.Equal(t, "f12", string(ft.GetNonFinalBlocks(1)[0].BlockData.HeaderHash))
+}
+
+func TestFinalityTracker_Finalize(t *testing.T) {
+	t.Parallel()
+
+	collector := &finalizedBlocksCollector{}
+	ft, _ := NewFinalityTracker(createMockArgsFinalityTracker(collector))
+
+	assert.Equal(t, ErrNilFinalizedBlock, ft.Finalize(nil))
+
+	require.Nil(t, ft.AddBlock(createTrackedOutportBlock(t, "h", 10, "h9", 0)))
+	require.Nil(t, ft.AddBlock(createTrackedOutportBlock(t, "h", 11, "h10", 0)))
+	require.Nil(t, ft.AddBlock(createTrackedOutportBlock(t, "h", 12, "h11", 0)))
+
+	require.Nil(t, ft.Finalize(&FinalizedBlock{ShardID: 1, HeaderHash: []byte("h11")}))
+	assert.Equal(t, []string{"h10", "h11"}, collector.hashes)
+	require.Nil(t, ft.Finalize(&FinalizedBlock{ShardID: 1, HeaderHash: []byte("h11")}))
+	assert.Len(t, collector.hashes, 2)
+
+	err := ft.Finalize(&FinalizedBlock{ShardID: 1, HeaderHash: []byte("h10")})
+	assert.True(t, errors.Is(err, ErrUnknownBlock))
+	err = ft.Finalize(&FinalizedBlock{ShardID: 0, HeaderHash: []byte("h12")})
+	assert.True(t, errors.Is(err, ErrUnknownBlock))
+
+	err = ft.AddBlock(createTrackedOutportBlock(t, "f", 12, "h11", 0))
+	assert.True(t, errors.Is(err, ErrForkDetected))
+}
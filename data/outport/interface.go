@@ -0,0 +1,20 @@
+package outport
+
+import (
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data"
+)
+
+// FeeInfoComputer defines the behavior of a component able to compute the fee info of a transaction
+type FeeInfoComputer interface {
+	ComputeFeeInfo(tx data.TransactionWithFeeHandler, refundValue *big.Int) (*FeeInfo, error)
+	IsInterfaceNil() bool
+}
+
+// HeaderDecoder defines the behavior of a component able to decode the header bytes
+type HeaderDecoder interface {
+	Decode(headerBytes []byte, headerTypeHint core.HeaderType) (data.HeaderHandler, core.HeaderType, error)
+	IsInterfaceNil() bool
+}
@@ -0,0 +1,325 @@
+package outport
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/alteredAccount"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-core-go/data/receipt"
+	"github.com/multiversx/mx-chain-core-go/data/rewardTx"
+	"github.com/multiversx/mx-chain-core-go/data/smartContractResult"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-core-go/hashing"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+)
+
+const okReturnCodePrefix = "@6f6b"
+
+// ArgsOutportBlockBuilder holds the components needed to create a new outport block builder
+type ArgsOutportBlockBuilder struct {
+	Marshaller     marshal.Marshalizer
+	Hasher         hashing.Hasher
+	NumberOfShards uint32
+	// FeeInfoComputer is optional, when missing the fee info of the transactions is left empty
+	FeeInfoComputer FeeInfoComputer
+}
+
+// ArgsBuildOutportBlock holds the protocol objects from which an outport block is assembled. All the maps are keyed
+// by the raw hashes, the builder being the one to hex encode them
+type ArgsBuildOutportBlock struct {
+	HeaderDataWithBody                         *HeaderDataWithBody
+	Transactions                               map[string]*transaction.Transaction
+	SmartContractResults                       map[string]*smartContractResult.SmartContractResult
+	Rewards                                    map[string]*rewardTx.RewardTx
+	Receipts                                   map[string]*receipt.Receipt
+	InvalidTxs                                 map[string]*transaction.Transaction
+	Logs                                       map[string]*transaction.Log
+	AlteredAccounts                            map[string]*alteredAccount.AlteredAccount
+	ScheduledExecutedSCRsHashesPrevBlock       [][]byte
+	ScheduledExecutedInvalidTxsHashesPrevBlock [][]byte
+	NotarizedHeadersHashes                     [][]byte
+	// SignersIndexes are derived from the header proof bitmap, using the ConsensusSize, when not provided
+	SignersIndexes         []uint64
+	ConsensusSize          int
+	HighestFinalBlockNonce uint64
+	HighestFinalBlockHash  []byte
+	LeaderIndex            uint64
+	LeaderBLSKey           []byte
+}
+
+type executionOrderSetter interface {
+	SetExecutionOrder(order uint32)
+}
+
+type outportBlockBuilder struct {
+	marshaller      marshal.Marshalizer
+	hasher          hashing.Hasher
+	numberOfShards  uint32
+	feeInfoComputer FeeInfoComputer
+}
+
+// NewOutportBlockBuilder creates a new outport block builder
+func NewOutportBlockBuilder(args ArgsOutportBlockBuilder) (*outportBlockBuilder, error) {
+	if check.IfNil(args.Marshaller) {
+		return nil, ErrNilMarshaller
+	}
+	if check.IfNil(args.Hasher) {
+		return nil, ErrNilHasher
+	}
+
+	return &outportBlockBuilder{
+		marshaller:      args.Marshaller,
+		hasher:          args.Hasher,
+		numberOfShards:  args.NumberOfShards,
+		feeInfoComputer: args.FeeInfoComputer,
+	}, nil
+}
+
+// Build assembles the outport block from the provided protocol objects. The execution order of the transactions
+// follows their position in the body miniBlocks, followed by the intra shard miniBlocks
+func (obb *outportBlockBuilder) Build(args ArgsBuildOutportBlock) (*OutportBlock, error) {
+	blockData, err := obb.createBlockData(args.HeaderDataWithBody)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := obb.createTransactionPool(args)
+	if err != nil {
+		return nil, err
+	}
+	setExecutionOrder(pool, blockData)
+
+	signersIndexes := args.SignersIndexes
+	if len(signersIndexes) == 0 && blockData.HeaderProof != nil && args.ConsensusSize > 0 {
+		for _, index := range block.GetSignersIndexes(blockData.HeaderProof.GetPubKeysBitmap(), args.ConsensusSize) {
+			signersIndexes = append(signersIndexes, uint64(index))
+		}
+	}
+
+	return &OutportBlock{
+		ShardID:                blockData.ShardID,
+		BlockData:              blockData,
+		TransactionPool:        pool,
+		HeaderGasConsumption:   &HeaderGasConsumption{},
+		AlteredAccounts:        args.AlteredAccounts,
+		NotarizedHeadersHashes: encodeHashes(args.NotarizedHeadersHashes),
+		NumberOfShards:         obb.numberOfShards,
+		SignersIndexes:         signersIndexes,
+		HighestFinalBlockNonce: args.HighestFinalBlockNonce,
+		HighestFinalBlockHash:  args.HighestFinalBlockHash,
+		LeaderIndex:            args.LeaderIndex,
+		LeaderBLSKey:           args.LeaderBLSKey,
+	}, nil
+}
+
+func (obb *outportBlockBuilder) createBlockData(headerData *HeaderDataWithBody) (*BlockData, error) {
+	if headerData == nil {
+		return nil, ErrNilHeaderDataWithBody
+	}
+	if check.IfNil(headerData.Header) {
+		return nil, ErrNilHeader
+	}
+
+	headerBytes, headerType, err := GetHeaderBytesAndType(obb.marshaller, headerData.Header)
+	if err != nil {
+		return nil, err
+	}
+	body, err := GetBody(headerData.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	headerHash := obb.hasher.Compute(string(headerBytes))
+	if len(headerData.HeaderHash) > 0 && !bytes.Equal(headerData.HeaderHash, headerHash) {
+		return nil, fmt.Errorf("%w, provided %s, computed %s",
+			ErrHeaderHashMismatch, hex.EncodeToString(headerData.HeaderHash), hex.EncodeToString(headerHash))
+	}
+
+	var headerProof *block.HeaderProof
+	if !check.IfNilReflect(headerData.HeaderProof) {
+		headerProof, err = GetHeaderProof(headerData.HeaderProof)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &BlockData{
+		ShardID:              headerData.Header.GetShardID(),
+		HeaderBytes:          headerBytes,
+		HeaderType:           string(headerType),
+		HeaderHash:           headerHash,
+		Body:                 body,
+		IntraShardMiniBlocks: headerData.IntraShardMiniBlocks,
+		HeaderProof:          headerProof,
+		TimestampMs:          headerData.Header.GetTimeStamp() * 1000,
+	}, nil
+}
+
+func (obb *outportBlockBuilder) createTransactionPool(args ArgsBuildOutportBlock) (*TransactionPool, error) {
+	pool := &TransactionPool{
+		Transactions:         make(map[string]*TxInfo, len(args.Transactions)),
+		SmartContractResults: make(map[string]*SCRInfo, len(args.SmartContractResults)),
+		Rewards:              make(map[string]*RewardInfo, len(args.Rewards)),
+		Receipts:             make(map[string]*receipt.Receipt, len(args.Receipts)),
+		InvalidTxs:           make(map[string]*TxInfo, len(args.InvalidTxs)),
+		Logs:                 make([]*LogData, 0, len(args.Logs)),
+
+		ScheduledExecutedSCRSHashesPrevBlock:       encodeHashes(args.ScheduledExecutedSCRsHashesPrevBlock),
+		ScheduledExecutedInvalidTxsHashesPrevBlock: encodeHashes(args.ScheduledExecutedInvalidTxsHashesPrevBlock),
+	}
+
+	scrsByPrevTxHash := groupSCRsByPrevTxHash(args.SmartContractResults)
+	for txHash, tx := range args.Transactions {
+		feeInfo, err := obb.computeFeeInfo(tx, computeRefundValue(tx, scrsByPrevTxHash[txHash]))
+		if err != nil {
+			return nil, err
+		}
+		pool.Transactions[hex.EncodeToString([]byte(txHash))] = &TxInfo{Transaction: tx, FeeInfo: feeInfo}
+	}
+	for txHash, tx := range args.InvalidTxs {
+		feeInfo, err := obb.computeFeeInfo(tx, nil)
+		if err != nil {
+			return nil, err
+		}
+		pool.InvalidTxs[hex.EncodeToString([]byte(txHash))] = &TxInfo{Transaction: tx, FeeInfo: feeInfo}
+	}
+	for scrHash, scr := range args.SmartContractResults {
+		pool.SmartContractResults[hex.EncodeToString([]byte(scrHash))] = &SCRInfo{SmartContractResult: scr, FeeInfo: newEmptyFeeInfo()}
+	}
+	for rewardHash, reward := range args.Rewards {
+		pool.Rewards[hex.EncodeToString([]byte(rewardHash))] = &RewardInfo{Reward: reward}
+	}
+	for receiptHash, rec := range args.Receipts {
+		pool.Receipts[hex.EncodeToString([]byte(receiptHash))] = rec
+	}
+	for txHash, log := range args.Logs {
+		pool.Logs = append(pool.Logs, &LogData{TxHash: hex.EncodeToString([]byte(txHash)), Log: log})
+	}
+	sort.Slice(pool.Logs, func(i, j int) bool {
+		return pool.Logs[i].TxHash < pool.Logs[j].TxHash
+	})
+
+	return pool, nil
+}
+
+func (obb *outportBlockBuilder) computeFeeInfo(tx *transaction.Transaction, refundValue *big.Int) (*FeeInfo, error) {
+	if check.IfNil(obb.feeInfoComputer) || tx == nil {
+		return newEmptyFeeInfo(), nil
+	}
+
+	return obb.feeInfoComputer.ComputeFeeInfo(tx, refundValue)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (obb *outportBlockBuilder) IsInterfaceNil() bool {
+	return obb == nil
+}
+
+func groupSCRsByPrevTxHash(scrs map[string]*smartContractResult.SmartContractResult) map[string][]*smartContractResult.SmartContractResult {
+	scrsByPrevTxHash := make(map[string][]*smartContractResult.SmartContractResult)
+	for _, scr := range scrs {
+		if scr == nil {
+			continue
+		}
+
+		scrsByPrevTxHash[string(scr.PrevTxHash)] = append(scrsByPrevTxHash[string(scr.PrevTxHash)], scr)
+	}
+
+	return scrsByPrevTxHash
+}
+
+// computeRefundValue sums the values of the smart contract results which return the unused gas of the provided
+// transaction to its sender. The provided smart contract results should be the ones generated by the transaction
+func computeRefundValue(tx *transaction.Transaction, scrs []*smartContractResult.SmartContractResult) *big.Int {
+	refundValue := big.NewInt(0)
+	if tx == nil {
+		return refundValue
+	}
+
+	for _, scr := range scrs {
+		isRefund := bytes.Equal(scr.RcvAddr, tx.SndAddr) &&
+			scr.Nonce == tx.Nonce+1 &&
+			bytes.HasPrefix(scr.Data, []byte(okReturnCodePrefix)) &&
+			scr.Value != nil && scr.Value.Sign() > 0
+		if isRefund {
+			refundValue.Add(refundValue, scr.Value)
+		}
+	}
+
+	return refundValue
+}
+
+func setExecutionOrder(pool *TransactionPool, blockData *BlockData) {
+	miniBlocks := make([]*block.MiniBlock, 0)
+	if blockData.Body != nil {
+		miniBlocks = append(miniBlocks, blockData.Body.MiniBlocks...)
+	}
+	miniBlocks = append(miniBlocks, blockData.IntraShardMiniBlocks...)
+
+	ordered := make(map[string]struct{})
+	order := uint32(0)
+	for _, miniBlock := range miniBlocks {
+		if miniBlock == nil {
+			continue
+		}
+
+		for _, txHash := range miniBlock.TxHashes {
+			hexHash := hex.EncodeToString(txHash)
+			_, alreadyOrdered := ordered[hexHash]
+			if alreadyOrdered {
+				continue
+			}
+
+			entry := getExecutionOrderSetter(pool, hexHash)
+			if entry == nil {
+				continue
+			}
+
+			entry.SetExecutionOrder(order)
+			ordered[hexHash] = struct{}{}
+			order++
+		}
+	}
+}
+
+func getExecutionOrderSetter(pool *TransactionPool, hexHash string) executionOrderSetter {
+	if txInfo, found := pool.Transactions[hexHash]; found {
+		return txInfo
+	}
+	if scrInfo, found := pool.SmartContractResults[hexHash]; found {
+		return scrInfo
+	}
+	if rewardInfo, found := pool.Rewards[hexHash]; found {
+		return rewardInfo
+	}
+	if txInfo, found := pool.InvalidTxs[hexHash]; found {
+		return txInfo
+	}
+
+	return nil
+}
+
+func newEmptyFeeInfo() *FeeInfo {
+	return &FeeInfo{
+		Fee:            big.NewInt(0),
+		InitialPaidFee: big.NewInt(0),
+	}
+}
+
+func encodeHashes(hashes [][]byte) []string {
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	encoded := make([]string, 0, len(hashes))
+	for _, hash := range hashes {
+		encoded = append(encoded, hex.EncodeToString(hash))
+	}
+
+	return encoded
+}
@@ -0,0 +1,203 @@
+package outport
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/core/mock"
+	"github.com/multiversx/mx-chain-core-go/data"
+	"github.com/multiversx/mx-chain-core-go/data/alteredAccount"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-core-go/data/receipt"
+	"github.com/multiversx/mx-chain-core-go/data/rewardTx"
+	"github.com/multiversx/mx-chain-core-go/data/smartContractResult"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type feeInfoComputerStub struct {
+	ComputeFeeInfoCalled func(tx data.TransactionWithFeeHandler, refundValue *big.Int) (*FeeInfo, error)
+}
+
+func (fics *feeInfoComputerStub) ComputeFeeInfo(tx data.TransactionWithFeeHandler, refundValue *big.Int) (*FeeInfo, error) {
+	if fics.ComputeFeeInfoCalled != nil {
+		return fics.ComputeFeeInfoCalled(tx, refundValue)
+	}
+
+	return &FeeInfo{}, nil
+}
+
+func (fics *feeInfoComputerStub) IsInterfaceNil() bool {
+	return fics == nil
+}
+
+func createMockArgsOutportBlockBuilder() ArgsOutportBlockBuilder {
+	return ArgsOutportBlockBuilder{
+		Marshaller:     &mock.MarshalizerMock{},
+		Hasher:         &mock.HasherMock{},
+		NumberOfShards: 3,
+	}
+}
+
+func createArgsBuildOutportBlock() ArgsBuildOutportBlock {
+	tx := &transaction.Transaction{Nonce: 4, SndAddr: []byte("alice"), RcvAddr: []byte("contract"), Value: big.NewInt(0)}
+
+	return ArgsBuildOutportBlock{
+		HeaderDataWithBody: &HeaderDataWithBody{
+			Header: &block.HeaderV2{Header: &block.Header{Nonce: 10, ShardID: 1, TimeStamp: 100}},
+			Body: &block.Body{
+				MiniBlocks: []*block.MiniBlock{
+					{TxHashes: [][]byte{[]byte("tx2"), []byte("tx1")}, Type: block.TxBlock},
+					{TxHashes: [][]byte{[]byte("reward")}, Type: block.RewardsBlock},
+					{TxHashes: [][]byte{[]byte("invalid")}, Type: block.InvalidBlock},
+				},
+			},
+			IntraShardMiniBlocks: []*block.MiniBlock{
+				{TxHashes: [][]byte{[]byte("scr"), []byte("tx1")}, Type: block.SmartContractResultBlock},
+			},
+		},
+		Transactions: map[string]*transaction.Transaction{
+			"tx1": tx,
+			"tx2": {Nonce: 1, Value: big.NewInt(0)},
+		},
+		SmartContractResults: map[string]*smartContractResult.SmartContractResult{
+			"scr": {Nonce: 5, PrevTxHash: []byte("tx1"), RcvAddr: []byte("alice"), Data: []byte("@6f6b"), Value: big.NewInt(7)},
+		},
+		Rewards:    map[string]*rewardTx.RewardTx{"reward": {Value: big.NewInt(1)}},
+		InvalidTxs: map[string]*transaction.Transaction{"invalid": {Value: big.NewInt(0)}},
+		Receipts:   map[string]*receipt.Receipt{"receipt": {TxHash: []byte("tx2")}},
+		Logs: map[string]*transaction.Log{
+			"tx1": {Address: []byte("contract")},
+			"scr": {Address: []byte("alice")},
+		},
+		AlteredAccounts:        map[string]*alteredAccount.AlteredAccount{"alice": {Address: "alice"}},
+		NotarizedHeadersHashes: [][]byte{{0x01}},
+		LeaderBLSKey:           []byte("leader"),
+	}
+}
+
+func TestNewOutportBlockBuilder(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsOutportBlockBuilder()
+	args.Marshaller = nil
+	builder, err := NewOutportBlockBuilder(args)
+	assert.Equal(t, ErrNilMarshaller, err)
+	assert.True(t, check.IfNil(builder))
+
+	args = createMockArgsOutportBlockBuilder()
+	args.Hasher = nil
+	builder, err = NewOutportBlockBuilder(args)
+	assert.Equal(t, ErrNilHasher, err)
+	assert.True(t, check.IfNil(builder))
+
+	builder, err = NewOutportBlockBuilder(createMockArgsOutportBlockBuilder())
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(builder))
+}
+
+func TestOutportBlockBuilder_Build(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid header data should error", func(t *testing.T) {
+		t.Parallel()
+
+		builder, _ := NewOutportBlockBuilder(createMockArgsOutportBlockBuilder())
+
+		args := createArgsBuildOutportBlock()
+		args.HeaderDataWithBody = nil
+		_, err := builder.Build(args)
+		assert.Equal(t, ErrNilHeaderDataWithBody, err)
+
+		args = createArgsBuildOutportBlock()
+		args.HeaderDataWithBody.Header = nil
+		_, err = builder.Build(args)
+		assert.Equal(t, ErrNilHeader, err)
+
+		args = createArgsBuildOutportBlock()
+		args.HeaderDataWithBody.Body = nil
+		_, err = builder.Build(args)
+		assert.Equal(t, errNilBodyHandler, err)
+
+		args = createArgsBuildOutportBlock()
+		args.HeaderDataWithBody.HeaderHash = []byte("wrong hash")
+		_, err = builder.Build(args)
+		assert.True(t, errors.Is(err, ErrHeaderHashMismatch))
+	})
+	t.Run("fee info computer error should be returned", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		builderArgs := createMockArgsOutportBlockBuilder()
+		builderArgs.FeeInfoComputer = &feeInfoComputerStub{
+			ComputeFeeInfoCalled: func(tx data.TransactionWithFeeHandler, refundValue *big.Int) (*FeeInfo, error) {
+				return nil, expectedErr
+			},
+		}
+		builder, _ := NewOutportBlockBuilder(builderArgs)
+
+		outportBlock, err := builder.Build(createArgsBuildOutportBlock())
+		assert.Nil(t, outportBlock)
+		assert.Equal(t, expectedErr, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		refundValues := make(map[uint64]*big.Int)
+		builderArgs := createMockArgsOutportBlockBuilder()
+		builderArgs.FeeInfoComputer = &feeInfoComputerStub{
+			ComputeFeeInfoCalled: func(tx data.TransactionWithFeeHandler, refundValue *big.Int) (*FeeInfo, error) {
+				refundValues[tx.(*transaction.Transaction).Nonce] = refundValue
+				return &FeeInfo{GasUsed: 10}, nil
+			},
+		}
+		builder, _ := NewOutportBlockBuilder(builderArgs)
+
+		args := createArgsBuildOutportBlock()
+		args.HeaderDataWithBody.HeaderProof = &block.HeaderProof{PubKeysBitmap: []byte{0b00000101}}
+		args.ConsensusSize = 3
+		outportBlock, err := builder.Build(args)
+		require.Nil(t, err)
+
+		headerBytes, _ := builderArgs.Marshaller.Marshal(args.HeaderDataWithBody.Header)
+		blockData := outportBlock.BlockData
+		assert.Equal(t, uint32(1), outportBlock.ShardID)
+		assert.Equal(t, uint32(3), outportBlock.NumberOfShards)
+		assert.Equal(t, headerBytes, blockData.HeaderBytes)
+		assert.Equal(t, string(core.ShardHeaderV2), blockData.HeaderType)
+		assert.Equal(t, builderArgs.Hasher.Compute(string(headerBytes)), blockData.HeaderHash)
+		assert.Equal(t, uint64(100000), blockData.TimestampMs)
+		assert.Equal(t, []uint64{0, 2}, outportBlock.SignersIndexes)
+		assert.Equal(t, []string{"01"}, outportBlock.NotarizedHeadersHashes)
+		assert.Equal(t, args.AlteredAccounts, outportBlock.AlteredAccounts)
+
+		pool := outportBlock.TransactionPool
+		hexHash := func(hash string) string {
+			return hex.EncodeToString([]byte(hash))
+		}
+		assert.Equal(t, uint32(0), pool.Transactions[hexHash("tx2")].ExecutionOrder)
+		assert.Equal(t, uint32(1), pool.Transactions[hexHash("tx1")].ExecutionOrder)
+		assert.Equal(t, uint32(2), pool.Rewards[hexHash("reward")].ExecutionOrder)
+		assert.Equal(t, uint32(3), pool.InvalidTxs[hexHash("invalid")].ExecutionOrder)
+		assert.Equal(t, uint32(4), pool.SmartContractResults[hexHash("scr")].ExecutionOrder)
+		assert.Equal(t, uint64(10), pool.Transactions[hexHash("tx1")].FeeInfo.GasUsed)
+		assert.Equal(t, big.NewInt(0), pool.SmartContractResults[hexHash("scr")].FeeInfo.Fee)
+		assert.Equal(t, big.NewInt(7), refundValues[4])
+		assert.Equal(t, big.NewInt(0), refundValues[1])
+		assert.NotNil(t, pool.Receipts[hexHash("receipt")])
+		require.Equal(t, 2, len(pool.Logs))
+		assert.Equal(t, hexHash("scr"), pool.Logs[0].TxHash)
+		assert.Equal(t, hexHash("tx1"), pool.Logs[1].TxHash)
+
+		validator, _ := NewOutportBlockValidator(ArgsOutportBlockValidator{Hasher: builderArgs.Hasher})
+		assert.True(t, errors.Is(validator.Validate(outportBlock), ErrProofMismatch))
+
+		outportBlock.BlockData.HeaderProof.HeaderHash = outportBlock.BlockData.HeaderHash
+		assert.Nil(t, validator.Validate(outportBlock))
+	})
+}
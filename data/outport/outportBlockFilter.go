@@ -0,0 +1,347 @@
+package outport
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/alteredAccount"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-core-go/data/receipt"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+)
+
+// FilterRules holds the rules an outport block filter selects the data with. An entry is selected when it matches
+// any of the rules
+type FilterRules struct {
+	// Addresses are encoded with the filter's pubkey converter. They are matched against the senders and the
+	// receivers of the transactions, the addresses and the topics of the log events and the altered accounts
+	Addresses []string
+	// TokenIdentifiers are matched against the topics of the log events and the tokens of the altered accounts
+	TokenIdentifiers []string
+	// EventIdentifiers are matched against the identifiers of the log events
+	EventIdentifiers []string
+	// MiniBlockTypes are matched against the types of the miniBlocks holding the transactions
+	MiniBlockTypes []block.Type
+}
+
+// ArgsOutportBlockFilter holds the components needed to create a new outport block filter
+type ArgsOutportBlockFilter struct {
+	PubkeyConverter core.PubkeyConverter
+	Rules           FilterRules
+}
+
+type outportBlockFilter struct {
+	addresses        map[string]struct{}
+	encodedAddresses map[string]struct{}
+	tokens           map[string]struct{}
+	eventIdentifiers map[string]struct{}
+	miniBlockTypes   map[block.Type]struct{}
+}
+
+// NewOutportBlockFilter creates a new outport block filter
+func NewOutportBlockFilter(args ArgsOutportBlockFilter) (*outportBlockFilter, error) {
+	if check.IfNil(args.PubkeyConverter) {
+		return nil, core.ErrNilPubkeyConverter
+	}
+
+	rules := args.Rules
+	numRules := len(rules.Addresses) + len(rules.TokenIdentifiers) + len(rules.EventIdentifiers) + len(rules.MiniBlockTypes)
+	if numRules == 0 {
+		return nil, ErrEmptyFilterRules
+	}
+
+	obf := &outportBlockFilter{
+		addresses:        make(map[string]struct{}, len(rules.Addresses)),
+		encodedAddresses: make(map[string]struct{}, len(rules.Addresses)),
+		tokens:           make(map[string]struct{}, len(rules.TokenIdentifiers)),
+		eventIdentifiers: make(map[string]struct{}, len(rules.EventIdentifiers)),
+		miniBlockTypes:   make(map[block.Type]struct{}, len(rules.MiniBlockTypes)),
+	}
+
+	for _, address := range rules.Addresses {
+		pubKey, err := args.PubkeyConverter.Decode(address)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s: %s", ErrInvalidFilterAddress, address, err.Error())
+		}
+		obf.addresses[string(pubKey)] = struct{}{}
+		obf.encodedAddresses[address] = struct{}{}
+	}
+	for _, token := range rules.TokenIdentifiers {
+		obf.tokens[token] = struct{}{}
+	}
+	for _, identifier := range rules.EventIdentifiers {
+		obf.eventIdentifiers[identifier] = struct{}{}
+	}
+	for _, miniBlockType := range rules.MiniBlockTypes {
+		obf.miniBlockTypes[miniBlockType] = struct{}{}
+	}
+
+	return obf, nil
+}
+
+// Filter returns a pruned copy of the provided outport block, holding only the matching transactions, smart contract
+// results, rewards, invalid transactions and altered accounts. A transaction is kept together with all the smart
+// contract results it generated, along with their logs and receipts, so the references inside the pruned transaction
+// pool remain consistent. The block data is left untouched, as it must match the header. The provided outport block
+// is not altered
+func (obf *outportBlockFilter) Filter(outportBlock *OutportBlock) (*OutportBlock, error) {
+	if outportBlock == nil {
+		return nil, ErrNilOutportBlock
+	}
+
+	filteredBlock := *outportBlock
+	filteredBlock.TransactionPool = obf.filterTransactionPool(outportBlock.TransactionPool, outportBlock.BlockData)
+	filteredBlock.AlteredAccounts = obf.filterAlteredAccounts(outportBlock.AlteredAccounts)
+
+	return &filteredBlock, nil
+}
+
+func (obf *outportBlockFilter) filterTransactionPool(pool *TransactionPool, blockData *BlockData) *TransactionPool {
+	if pool == nil {
+		return nil
+	}
+
+	families := getTransactionFamilies(pool)
+	matchedFamilies := obf.getMatchedFamilies(pool, families, getMiniBlockTypes(blockData))
+	isKept := func(hexHash string) bool {
+		_, found := matchedFamilies[getFamily(families, hexHash)]
+		return found
+	}
+
+	filteredPool := &TransactionPool{
+		Transactions:         make(map[string]*TxInfo),
+		SmartContractResults: make(map[string]*SCRInfo),
+		Rewards:              make(map[string]*RewardInfo),
+		Receipts:             make(map[string]*receipt.Receipt),
+		InvalidTxs:           make(map[string]*TxInfo),
+		Logs:                 make([]*LogData, 0),
+
+		ScheduledExecutedSCRSHashesPrevBlock:       pool.ScheduledExecutedSCRSHashesPrevBlock,
+		ScheduledExecutedInvalidTxsHashesPrevBlock: pool.ScheduledExecutedInvalidTxsHashesPrevBlock,
+	}
+	for hexHash, txInfo := range pool.Transactions {
+		if isKept(hexHash) {
+			filteredPool.Transactions[hexHash] = txInfo
+		}
+	}
+	for hexHash, scrInfo := range pool.SmartContractResults {
+		if isKept(hexHash) {
+			filteredPool.SmartContractResults[hexHash] = scrInfo
+		}
+	}
+	for hexHash, rewardInfo := range pool.Rewards {
+		if isKept(hexHash) {
+			filteredPool.Rewards[hexHash] = rewardInfo
+		}
+	}
+	for hexHash, txInfo := range pool.InvalidTxs {
+		if isKept(hexHash) {
+			filteredPool.InvalidTxs[hexHash] = txInfo
+		}
+	}
+	for receiptHash, rec := range pool.Receipts {
+		if rec != nil && isKept(hex.EncodeToString(rec.TxHash)) {
+			filteredPool.Receipts[receiptHash] = rec
+		}
+	}
+	for _, logData := range pool.Logs {
+		if logData != nil && isKept(logData.TxHash) {
+			filteredPool.Logs = append(filteredPool.Logs, logData)
+		}
+	}
+
+	return filteredPool
+}
+
+func (obf *outportBlockFilter) getMatchedFamilies(
+	pool *TransactionPool,
+	families map[string]string,
+	miniBlockTypes map[string]block.Type,
+) map[string]struct{} {
+	matchedFamilies := make(map[string]struct{})
+	markIfMatched := func(hexHash string, addresses ...[]byte) {
+		if obf.matchesMiniBlockType(miniBlockTypes, hexHash) || obf.matchesAnyAddress(addresses...) {
+			matchedFamilies[getFamily(families, hexHash)] = struct{}{}
+		}
+	}
+
+	for hexHash, txInfo := range pool.Transactions {
+		tx := txInfo.GetTransaction()
+		markIfMatched(hexHash, tx.GetSndAddr(), tx.GetRcvAddr())
+	}
+	for hexHash, scrInfo := range pool.SmartContractResults {
+		scr := scrInfo.GetSmartContractResult()
+		markIfMatched(hexHash, scr.GetSndAddr(), scr.GetRcvAddr(), scr.GetOriginalSender())
+	}
+	for hexHash, rewardInfo := range pool.Rewards {
+		markIfMatched(hexHash, rewardInfo.GetReward().GetRcvAddr())
+	}
+	for hexHash, txInfo := range pool.InvalidTxs {
+		tx := txInfo.GetTransaction()
+		markIfMatched(hexHash, tx.GetSndAddr(), tx.GetRcvAddr())
+	}
+	for _, logData := range pool.Logs {
+		if obf.matchesLog(logData.GetLog()) {
+			matchedFamilies[getFamily(families, logData.GetTxHash())] = struct{}{}
+		}
+	}
+
+	return matchedFamilies
+}
+
+func (obf *outportBlockFilter) matchesMiniBlockType(miniBlockTypes map[string]block.Type, hexHash string) bool {
+	miniBlockType, found := miniBlockTypes[hexHash]
+	if !found {
+		return false
+	}
+
+	_, matches := obf.miniBlockTypes[miniBlockType]
+	return matches
+}
+
+func (obf *outportBlockFilter) matchesAnyAddress(addresses ...[]byte) bool {
+	for _, address := range addresses {
+		if len(address) == 0 {
+			continue
+		}
+
+		_, matches := obf.addresses[string(address)]
+		if matches {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (obf *outportBlockFilter) matchesLog(log *transaction.Log) bool {
+	if log == nil {
+		return false
+	}
+	if obf.matchesAnyAddress(log.Address) {
+		return true
+	}
+
+	for _, event := range log.Events {
+		if obf.matchesEvent(event) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (obf *outportBlockFilter) matchesEvent(event *transaction.Event) bool {
+	if event == nil {
+		return false
+	}
+
+	_, matches := obf.eventIdentifiers[string(event.Identifier)]
+	if matches || obf.matchesAnyAddress(event.Address) {
+		return true
+	}
+
+	for _, topic := range event.Topics {
+		_, isToken := obf.tokens[string(topic)]
+		if isToken || obf.matchesAnyAddress(topic) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (obf *outportBlockFilter) filterAlteredAccounts(accounts map[string]*alteredAccount.AlteredAccount) map[string]*alteredAccount.AlteredAccount {
+	if accounts == nil {
+		return nil
+	}
+
+	filteredAccounts := make(map[string]*alteredAccount.AlteredAccount)
+	for key, account := range accounts {
+		if account == nil {
+			continue
+		}
+
+		_, addressMatches := obf.encodedAddresses[account.Address]
+		if addressMatches {
+			filteredAccounts[key] = account
+			continue
+		}
+
+		matchingTokens := obf.filterTokens(account.Tokens)
+		if len(matchingTokens) == 0 {
+			continue
+		}
+
+		filteredAccount := *account
+		filteredAccount.Tokens = matchingTokens
+		filteredAccounts[key] = &filteredAccount
+	}
+
+	return filteredAccounts
+}
+
+func (obf *outportBlockFilter) filterTokens(tokens []*alteredAccount.AccountTokenData) []*alteredAccount.AccountTokenData {
+	matchingTokens := make([]*alteredAccount.AccountTokenData, 0)
+	for _, token := range tokens {
+		if token == nil {
+			continue
+		}
+
+		_, matches := obf.tokens[token.Identifier]
+		if matches {
+			matchingTokens = append(matchingTokens, token)
+		}
+	}
+
+	return matchingTokens
+}
+
+// getTransactionFamilies maps each smart contract result to the transaction which originated it
+func getTransactionFamilies(pool *TransactionPool) map[string]string {
+	families := make(map[string]string, len(pool.SmartContractResults))
+	for hexHash, scrInfo := range pool.SmartContractResults {
+		originalTxHash := scrInfo.GetSmartContractResult().GetOriginalTxHash()
+		if len(originalTxHash) > 0 {
+			families[hexHash] = hex.EncodeToString(originalTxHash)
+		}
+	}
+
+	return families
+}
+
+func getFamily(families map[string]string, hexHash string) string {
+	family, found := families[hexHash]
+	if !found {
+		return hexHash
+	}
+
+	return family
+}
+
+func getMiniBlockTypes(blockData *BlockData) map[string]block.Type {
+	miniBlockTypes := make(map[string]block.Type)
+	if blockData == nil {
+		return miniBlockTypes
+	}
+
+	addMiniBlocksTypes(miniBlockTypes, blockData.GetBody().GetMiniBlocks())
+	addMiniBlocksTypes(miniBlockTypes, blockData.IntraShardMiniBlocks)
+
+	return miniBlockTypes
+}
+
+func addMiniBlocksTypes(miniBlockTypes map[string]block.Type, miniBlocks []*block.MiniBlock) {
+	for _, miniBlock := range miniBlocks {
+		for _, txHash := range miniBlock.GetTxHashes() {
+			miniBlockTypes[hex.EncodeToString(txHash)] = miniBlock.GetType()
+		}
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (obf *outportBlockFilter) IsInterfaceNil() bool {
+	return obf == nil
+}
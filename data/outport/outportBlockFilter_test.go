@@ -0,0 +1,261 @@
+package outport
+
+import (
+	"encoding/hex"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
+	"github.com/multiversx/mx-chain-core-go/data/alteredAccount"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-core-go/data/receipt"
+	"github.com/multiversx/mx-chain-core-go/data/rewardTx"
+	"github.com/multiversx/mx-chain-core-go/data/smartContractResult"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const filterAddressLen = 6
+
+func encodeAddress(address string) string {
+	return hex.EncodeToString([]byte(address))
+}
+
+func createMockArgsOutportBlockFilter(rules FilterRules) ArgsOutportBlockFilter {
+	converter, _ := pubkeyConverter.NewHexPubkeyConverter(filterAddressLen)
+
+	return ArgsOutportBlockFilter{
+		PubkeyConverter: converter,
+		Rules:           rules,
+	}
+}
+
+func createOutportBlockToFilter() *OutportBlock {
+	hexHash := func(hash string) string {
+		return hex.EncodeToString([]byte(hash))
+	}
+
+	return &OutportBlock{
+		ShardID: 1,
+		BlockData: &BlockData{
+			ShardID:    1,
+			HeaderHash: []byte("header"),
+			Body: &block.Body{
+				MiniBlocks: []*block.MiniBlock{
+					{TxHashes: [][]byte{[]byte("txAlice"), []byte("txBobby")}, Type: block.TxBlock},
+					{TxHashes: [][]byte{[]byte("reward")}, Type: block.RewardsBlock},
+					{TxHashes: [][]byte{[]byte("invalid")}, Type: block.InvalidBlock},
+				},
+			},
+			IntraShardMiniBlocks: []*block.MiniBlock{
+				{TxHashes: [][]byte{[]byte("scrAlice"), []byte("scrBobby")}, Type: block.SmartContractResultBlock},
+			},
+		},
+		TransactionPool: &TransactionPool{
+			Transactions: map[string]*TxInfo{
+				hexHash("txAlice"): {Transaction: &transaction.Transaction{SndAddr: []byte("alice_"), RcvAddr: []byte("scAddr")}, ExecutionOrder: 0},
+				hexHash("txBobby"): {Transaction: &transaction.Transaction{SndAddr: []byte("bobby_"), RcvAddr: []byte("carol_")}, ExecutionOrder: 1},
+			},
+			SmartContractResults: map[string]*SCRInfo{
+				hexHash("scrAlice"): {SmartContractResult: &smartContractResult.SmartContractResult{
+					SndAddr: []byte("scAddr"), RcvAddr: []byte("dave__"), OriginalTxHash: []byte("txAlice"),
+				}, ExecutionOrder: 4},
+				hexHash("scrBobby"): {SmartContractResult: &smartContractResult.SmartContractResult{
+					SndAddr: []byte("carol_"), RcvAddr: []byte("bobby_"), OriginalTxHash: []byte("txBobby"),
+				}, ExecutionOrder: 5},
+			},
+			Rewards: map[string]*RewardInfo{
+				hexHash("reward"): {Reward: &rewardTx.RewardTx{RcvAddr: []byte("erin__")}, ExecutionOrder: 2},
+			},
+			InvalidTxs: map[string]*TxInfo{
+				hexHash("invalid"): {Transaction: &transaction.Transaction{SndAddr: []byte("frank_"), RcvAddr: []byte("carol_")}, ExecutionOrder: 3},
+			},
+			Receipts: map[string]*receipt.Receipt{
+				hexHash("receiptAlice"): {TxHash: []byte("txAlice")},
+				hexHash("receiptBobby"): {TxHash: []byte("txBobby")},
+			},
+			Logs: []*LogData{
+				{TxHash: hexHash("scrAlice"), Log: &transaction.Log{
+					Address: []byte("scAddr"),
+					Events: []*transaction.Event{{
+						Identifier: []byte(core.BuiltInFunctionESDTTransfer),
+						Topics:     [][]byte{[]byte("TKN-abcdef"), {}, []byte{10}, []byte("dave__")},
+					}},
+				}},
+				{TxHash: hexHash("txBobby"), Log: &transaction.Log{
+					Address: []byte("carol_"),
+					Events:  []*transaction.Event{{Identifier: []byte(core.WriteLogIdentifier)}},
+				}},
+			},
+			ScheduledExecutedSCRSHashesPrevBlock: []string{hexHash("prevScr")},
+		},
+		AlteredAccounts: map[string]*alteredAccount.AlteredAccount{
+			encodeAddress("alice_"): {Address: encodeAddress("alice_"), Balance: "10"},
+			encodeAddress("dave__"): {
+				Address: encodeAddress("dave__"),
+				Tokens: []*alteredAccount.AccountTokenData{
+					{Identifier: "TKN-abcdef", Balance: "10"},
+					{Identifier: "OTHER-abcdef", Balance: "5"},
+				},
+			},
+			encodeAddress("bobby_"): {Address: encodeAddress("bobby_"), Balance: "3"},
+		},
+		HighestFinalBlockNonce: 7,
+	}
+}
+
+func getSortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	return sortStrings(keys)
+}
+
+func sortStrings(values []string) []string {
+	sort.Strings(values)
+	return values
+}
+
+func hexHashes(hashes ...string) []string {
+	hexHashesList := make([]string, 0, len(hashes))
+	for _, hash := range hashes {
+		hexHashesList = append(hexHashesList, hex.EncodeToString([]byte(hash)))
+	}
+
+	return sortStrings(hexHashesList)
+}
+
+func TestNewOutportBlockFilter(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsOutportBlockFilter(FilterRules{EventIdentifiers: []string{core.WriteLogIdentifier}})
+	args.PubkeyConverter = nil
+	obf, err := NewOutportBlockFilter(args)
+	assert.Equal(t, core.ErrNilPubkeyConverter, err)
+	assert.True(t, check.IfNil(obf))
+
+	obf, err = NewOutportBlockFilter(createMockArgsOutportBlockFilter(FilterRules{}))
+	assert.Equal(t, ErrEmptyFilterRules, err)
+	assert.Nil(t, obf)
+
+	_, err = NewOutportBlockFilter(createMockArgsOutportBlockFilter(FilterRules{Addresses: []string{"not hex"}}))
+	assert.True(t, errors.Is(err, ErrInvalidFilterAddress))
+
+	obf, err = NewOutportBlockFilter(createMockArgsOutportBlockFilter(FilterRules{Addresses: []string{encodeAddress("alice_")}}))
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(obf))
+}
+
+func TestOutportBlockFilter_Filter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil outport block should error", func(t *testing.T) {
+		t.Parallel()
+
+		obf, _ := NewOutportBlockFilter(createMockArgsOutportBlockFilter(FilterRules{EventIdentifiers: []string{core.WriteLogIdentifier}}))
+		filtered, err := obf.Filter(nil)
+		assert.Equal(t, ErrNilOutportBlock, err)
+		assert.Nil(t, filtered)
+	})
+	t.Run("filter by address should keep the whole transaction family", func(t *testing.T) {
+		t.Parallel()
+
+		obf, _ := NewOutportBlockFilter(createMockArgsOutportBlockFilter(FilterRules{Addresses: []string{encodeAddress("alice_")}}))
+		outportBlock := createOutportBlockToFilter()
+		filtered, err := obf.Filter(outportBlock)
+		require.Nil(t, err)
+
+		pool := filtered.TransactionPool
+		assert.Equal(t, hexHashes("txAlice"), getSortedKeys(pool.Transactions))
+		assert.Equal(t, hexHashes("scrAlice"), getSortedKeys(pool.SmartContractResults))
+		assert.Equal(t, hexHashes("receiptAlice"), getSortedKeys(pool.Receipts))
+		assert.Empty(t, pool.Rewards)
+		assert.Empty(t, pool.InvalidTxs)
+		require.Len(t, pool.Logs, 1)
+		assert.Equal(t, hex.EncodeToString([]byte("scrAlice")), pool.Logs[0].TxHash)
+		assert.Equal(t, hexHashes("prevScr"), pool.ScheduledExecutedSCRSHashesPrevBlock)
+		assert.Equal(t, []string{encodeAddress("alice_")}, getSortedKeys(filtered.AlteredAccounts))
+
+		assert.Equal(t, outportBlock.BlockData, filtered.BlockData)
+		assert.Equal(t, uint64(7), filtered.HighestFinalBlockNonce)
+		assert.Equal(t, createOutportBlockToFilter(), outportBlock)
+	})
+	t.Run("filter by address should match the results receivers and the event topics", func(t *testing.T) {
+		t.Parallel()
+
+		obf, _ := NewOutportBlockFilter(createMockArgsOutportBlockFilter(FilterRules{Addresses: []string{encodeAddress("dave__")}}))
+		filtered, _ := obf.Filter(createOutportBlockToFilter())
+
+		assert.Equal(t, hexHashes("txAlice"), getSortedKeys(filtered.TransactionPool.Transactions))
+		assert.Equal(t, hexHashes("scrAlice"), getSortedKeys(filtered.TransactionPool.SmartContractResults))
+		require.Len(t, filtered.AlteredAccounts, 1)
+		assert.Len(t, filtered.AlteredAccounts[encodeAddress("dave__")].Tokens, 2)
+	})
+	t.Run("filter by token should match the log events and prune the accounts tokens", func(t *testing.T) {
+		t.Parallel()
+
+		obf, _ := NewOutportBlockFilter(createMockArgsOutportBlockFilter(FilterRules{TokenIdentifiers: []string{"TKN-abcdef"}}))
+		outportBlock := createOutportBlockToFilter()
+		filtered, _ := obf.Filter(outportBlock)
+
+		assert.Equal(t, hexHashes("txAlice"), getSortedKeys(filtered.TransactionPool.Transactions))
+		assert.Equal(t, hexHashes("scrAlice"), getSortedKeys(filtered.TransactionPool.SmartContractResults))
+		require.Len(t, filtered.AlteredAccounts, 1)
+		tokens := filtered.AlteredAccounts[encodeAddress("dave__")].Tokens
+		require.Len(t, tokens, 1)
+		assert.Equal(t, "TKN-abcdef", tokens[0].Identifier)
+		assert.Len(t, outportBlock.AlteredAccounts[encodeAddress("dave__")].Tokens, 2)
+	})
+	t.Run("filter by event identifier", func(t *testing.T) {
+		t.Parallel()
+
+		obf, _ := NewOutportBlockFilter(createMockArgsOutportBlockFilter(FilterRules{EventIdentifiers: []string{core.WriteLogIdentifier}}))
+		filtered, _ := obf.Filter(createOutportBlockToFilter())
+
+		pool := filtered.TransactionPool
+		assert.Equal(t, hexHashes("txBobby"), getSortedKeys(pool.Transactions))
+		assert.Equal(t, hexHashes("scrBobby"), getSortedKeys(pool.SmartContractResults))
+		assert.Equal(t, hexHashes("receiptBobby"), getSortedKeys(pool.Receipts))
+		require.Len(t, pool.Logs, 1)
+		assert.Empty(t, filtered.AlteredAccounts)
+	})
+	t.Run("filter by miniBlock type", func(t *testing.T) {
+		t.Parallel()
+
+		obf, _ := NewOutportBlockFilter(createMockArgsOutportBlockFilter(FilterRules{
+			MiniBlockTypes: []block.Type{block.RewardsBlock, block.InvalidBlock},
+		}))
+		filtered, _ := obf.Filter(createOutportBlockToFilter())
+
+		pool := filtered.TransactionPool
+		assert.Empty(t, pool.Transactions)
+		assert.Empty(t, pool.SmartContractResults)
+		assert.Equal(t, hexHashes("reward"), getSortedKeys(pool.Rewards))
+		assert.Equal(t, hexHashes("invalid"), getSortedKeys(pool.InvalidTxs))
+		assert.Empty(t, pool.Logs)
+	})
+	t.Run("filtered block should pass the validation", func(t *testing.T) {
+		t.Parallel()
+
+		outportBlock := createOutportBlockToFilter()
+		outportBlock.TransactionPool.ScheduledExecutedSCRSHashesPrevBlock = nil
+		obf, _ := NewOutportBlockFilter(createMockArgsOutportBlockFilter(FilterRules{Addresses: []string{encodeAddress("bobby_")}}))
+		filtered, _ := obf.Filter(outportBlock)
+
+		assert.Nil(t, checkTransactionPool(filtered.TransactionPool, filtered.BlockData))
+	})
+	t.Run("missing transaction pool and accounts should be kept missing", func(t *testing.T) {
+		t.Parallel()
+
+		obf, _ := NewOutportBlockFilter(createMockArgsOutportBlockFilter(FilterRules{Addresses: []string{encodeAddress("bobby_")}}))
+		filtered, err := obf.Filter(&OutportBlock{ShardID: 2})
+		require.Nil(t, err)
+		assert.Equal(t, &OutportBlock{ShardID: 2}, filtered)
+	})
+}
@@ -0,0 +1,205 @@
+package outport
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/hashing"
+)
+
+// ArgsOutportBlockValidator holds the components needed to create a new outport block validator
+type ArgsOutportBlockValidator struct {
+	Hasher hashing.Hasher
+}
+
+type outportBlockValidator struct {
+	hasher hashing.Hasher
+}
+
+// NewOutportBlockValidator creates a new outport block validator
+func NewOutportBlockValidator(args ArgsOutportBlockValidator) (*outportBlockValidator, error) {
+	if check.IfNil(args.Hasher) {
+		return nil, ErrNilHasher
+	}
+
+	return &outportBlockValidator{
+		hasher: args.Hasher,
+	}, nil
+}
+
+// Validate checks that the fields of the provided outport block are consistent with each other: the header hash and
+// the proof match the header bytes, all the pool entries are keyed by hex hashes and are referenced by the block
+// miniBlocks with distinct execution orders, while every log and receipt points to a transaction from the pool
+func (obv *outportBlockValidator) Validate(outportBlock *OutportBlock) error {
+	if outportBlock == nil {
+		return ErrNilOutportBlock
+	}
+
+	err := obv.checkBlockData(outportBlock)
+	if err != nil {
+		return err
+	}
+
+	err = checkSignersIndexes(outportBlock.SignersIndexes)
+	if err != nil {
+		return err
+	}
+
+	return checkTransactionPool(outportBlock.TransactionPool, outportBlock.BlockData)
+}
+
+func (obv *outportBlockValidator) checkBlockData(outportBlock *OutportBlock) error {
+	blockData := outportBlock.BlockData
+	if blockData == nil {
+		return ErrNilBlockData
+	}
+	if blockData.ShardID != outportBlock.ShardID {
+		return fmt.Errorf("%w, outport block shard %d, block data shard %d", ErrShardIDMismatch, outportBlock.ShardID, blockData.ShardID)
+	}
+
+	headerHash := obv.hasher.Compute(string(blockData.HeaderBytes))
+	if !bytes.Equal(headerHash, blockData.HeaderHash) {
+		return fmt.Errorf("%w, provided %s, computed %s",
+			ErrHeaderHashMismatch, hex.EncodeToString(blockData.HeaderHash), hex.EncodeToString(headerHash))
+	}
+
+	if blockData.HeaderProof != nil && !bytes.Equal(blockData.HeaderProof.GetHeaderHash(), blockData.HeaderHash) {
+		return fmt.Errorf("%w, proof for %s", ErrProofMismatch, hex.EncodeToString(blockData.HeaderProof.GetHeaderHash()))
+	}
+
+	return nil
+}
+
+func checkSignersIndexes(signersIndexes []uint64) error {
+	for i := 1; i < len(signersIndexes); i++ {
+		if signersIndexes[i] <= signersIndexes[i-1] {
+			return fmt.Errorf("%w, index %d after %d", ErrInvalidSignersIndexes, signersIndexes[i], signersIndexes[i-1])
+		}
+	}
+
+	return nil
+}
+
+func checkTransactionPool(pool *TransactionPool, blockData *BlockData) error {
+	if pool == nil {
+		return ErrNilTransactionPool
+	}
+
+	executionOrders := make(map[string]uint32)
+	for hexHash, txInfo := range pool.Transactions {
+		if txInfo == nil {
+			return fmt.Errorf("%w, transaction %s", ErrNilPoolEntry, hexHash)
+		}
+		executionOrders[hexHash] = txInfo.ExecutionOrder
+	}
+	for hexHash, scrInfo := range pool.SmartContractResults {
+		if scrInfo == nil {
+			return fmt.Errorf("%w, smart contract result %s", ErrNilPoolEntry, hexHash)
+		}
+		executionOrders[hexHash] = scrInfo.ExecutionOrder
+	}
+	for hexHash, rewardInfo := range pool.Rewards {
+		if rewardInfo == nil {
+			return fmt.Errorf("%w, reward %s", ErrNilPoolEntry, hexHash)
+		}
+		executionOrders[hexHash] = rewardInfo.ExecutionOrder
+	}
+	for hexHash, txInfo := range pool.InvalidTxs {
+		if txInfo == nil {
+			return fmt.Errorf("%w, invalid transaction %s", ErrNilPoolEntry, hexHash)
+		}
+		executionOrders[hexHash] = txInfo.ExecutionOrder
+	}
+
+	err := checkExecutionOrders(executionOrders, getMiniBlocksTxHashes(blockData))
+	if err != nil {
+		return err
+	}
+
+	for receiptHash, rec := range pool.Receipts {
+		err = checkHexHash(receiptHash)
+		if err != nil {
+			return err
+		}
+		if rec == nil {
+			return fmt.Errorf("%w, receipt %s", ErrNilPoolEntry, receiptHash)
+		}
+
+		_, found := executionOrders[hex.EncodeToString(rec.TxHash)]
+		if !found {
+			return fmt.Errorf("%w, receipt %s, transaction %s", ErrReceiptWithoutTransaction, receiptHash, hex.EncodeToString(rec.TxHash))
+		}
+	}
+
+	for _, logData := range pool.Logs {
+		if logData == nil {
+			return fmt.Errorf("%w, log", ErrNilPoolEntry)
+		}
+
+		_, found := executionOrders[logData.TxHash]
+		if !found {
+			return fmt.Errorf("%w, transaction %s", ErrLogWithoutTransaction, logData.TxHash)
+		}
+	}
+
+	return nil
+}
+
+func checkExecutionOrders(executionOrders map[string]uint32, miniBlocksTxHashes map[string]struct{}) error {
+	usedOrders := make(map[uint32]string, len(executionOrders))
+	for hexHash, order := range executionOrders {
+		err := checkHexHash(hexHash)
+		if err != nil {
+			return err
+		}
+
+		_, isInBlock := miniBlocksTxHashes[hexHash]
+		if !isInBlock {
+			return fmt.Errorf("%w, hash %s", ErrTransactionNotInBlock, hexHash)
+		}
+
+		otherHash, isUsed := usedOrders[order]
+		if isUsed {
+			return fmt.Errorf("%w, order %d for %s and %s", ErrDuplicatedExecutionOrder, order, hexHash, otherHash)
+		}
+		usedOrders[order] = hexHash
+	}
+
+	return nil
+}
+
+func getMiniBlocksTxHashes(blockData *BlockData) map[string]struct{} {
+	txHashes := make(map[string]struct{})
+	if blockData.Body != nil {
+		for _, miniBlock := range blockData.Body.MiniBlocks {
+			addMiniBlockTxHashes(txHashes, miniBlock.GetTxHashes())
+		}
+	}
+	for _, miniBlock := range blockData.IntraShardMiniBlocks {
+		addMiniBlockTxHashes(txHashes, miniBlock.GetTxHashes())
+	}
+
+	return txHashes
+}
+
+func addMiniBlockTxHashes(txHashes map[string]struct{}, miniBlockTxHashes [][]byte) {
+	for _, txHash := range miniBlockTxHashes {
+		txHashes[hex.EncodeToString(txHash)] = struct{}{}
+	}
+}
+
+func checkHexHash(hexHash string) error {
+	_, err := hex.DecodeString(hexHash)
+	if err != nil || len(hexHash) == 0 {
+		return fmt.Errorf("%w: %q", ErrInvalidHexHash, hexHash)
+	}
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (obv *outportBlockValidator) IsInterfaceNil() bool {
+	return obv == nil
+}
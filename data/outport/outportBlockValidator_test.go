@@ -0,0 +1,110 @@
+package outport
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/core/mock"
+	"github.com/multiversx/mx-chain-core-go/data/receipt"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createValidOutportBlock(t *testing.T) *OutportBlock {
+	builder, _ := NewOutportBlockBuilder(createMockArgsOutportBlockBuilder())
+	outportBlock, err := builder.Build(createArgsBuildOutportBlock())
+	require.Nil(t, err)
+
+	return outportBlock
+}
+
+func TestNewOutportBlockValidator(t *testing.T) {
+	t.Parallel()
+
+	validator, err := NewOutportBlockValidator(ArgsOutportBlockValidator{})
+	assert.Equal(t, ErrNilHasher, err)
+	assert.True(t, check.IfNil(validator))
+
+	validator, err = NewOutportBlockValidator(ArgsOutportBlockValidator{Hasher: &mock.HasherMock{}})
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(validator))
+}
+
+func TestOutportBlockValidator_Validate(t *testing.T) {
+	t.Parallel()
+
+	validator, _ := NewOutportBlockValidator(ArgsOutportBlockValidator{Hasher: &mock.HasherMock{}})
+	hexHash := func(hash string) string {
+		return hex.EncodeToString([]byte(hash))
+	}
+
+	t.Run("valid outport block", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Nil(t, validator.Validate(createValidOutportBlock(t)))
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, ErrNilOutportBlock, validator.Validate(nil))
+
+		outportBlock := createValidOutportBlock(t)
+		outportBlock.BlockData = nil
+		assert.Equal(t, ErrNilBlockData, validator.Validate(outportBlock))
+
+		outportBlock = createValidOutportBlock(t)
+		outportBlock.TransactionPool = nil
+		assert.Equal(t, ErrNilTransactionPool, validator.Validate(outportBlock))
+
+		outportBlock = createValidOutportBlock(t)
+		outportBlock.TransactionPool.Rewards[hexHash("reward")] = nil
+		assert.True(t, errors.Is(validator.Validate(outportBlock), ErrNilPoolEntry))
+	})
+	t.Run("block data mismatches", func(t *testing.T) {
+		t.Parallel()
+
+		outportBlock := createValidOutportBlock(t)
+		outportBlock.ShardID = 2
+		assert.True(t, errors.Is(validator.Validate(outportBlock), ErrShardIDMismatch))
+
+		outportBlock = createValidOutportBlock(t)
+		outportBlock.BlockData.HeaderBytes = []byte("other header")
+		assert.True(t, errors.Is(validator.Validate(outportBlock), ErrHeaderHashMismatch))
+	})
+	t.Run("signers indexes not increasing", func(t *testing.T) {
+		t.Parallel()
+
+		outportBlock := createValidOutportBlock(t)
+		outportBlock.SignersIndexes = []uint64{1, 3, 3}
+		assert.True(t, errors.Is(validator.Validate(outportBlock), ErrInvalidSignersIndexes))
+	})
+	t.Run("pool cross references", func(t *testing.T) {
+		t.Parallel()
+
+		outportBlock := createValidOutportBlock(t)
+		outportBlock.TransactionPool.Transactions["not hex"] = &TxInfo{ExecutionOrder: 100}
+		assert.True(t, errors.Is(validator.Validate(outportBlock), ErrInvalidHexHash))
+
+		outportBlock = createValidOutportBlock(t)
+		outportBlock.TransactionPool.Transactions[hexHash("tx3")] = &TxInfo{ExecutionOrder: 100}
+		assert.True(t, errors.Is(validator.Validate(outportBlock), ErrTransactionNotInBlock))
+
+		outportBlock = createValidOutportBlock(t)
+		outportBlock.TransactionPool.Transactions[hexHash("tx1")].ExecutionOrder = 0
+		assert.True(t, errors.Is(validator.Validate(outportBlock), ErrDuplicatedExecutionOrder))
+
+		outportBlock = createValidOutportBlock(t)
+		outportBlock.TransactionPool.Logs = append(outportBlock.TransactionPool.Logs, &LogData{
+			TxHash: hexHash("unknown"),
+			Log:    &transaction.Log{},
+		})
+		assert.True(t, errors.Is(validator.Validate(outportBlock), ErrLogWithoutTransaction))
+
+		outportBlock = createValidOutportBlock(t)
+		outportBlock.TransactionPool.Receipts[hexHash("receipt2")] = &receipt.Receipt{TxHash: []byte("unknown")}
+		assert.True(t, errors.Is(validator.Validate(outportBlock), ErrReceiptWithoutTransaction))
+	})
+}
@@ -0,0 +1,129 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"time"
+)
+
+// ArchiveVersion is the version of the archive files written by the recorder
+const ArchiveVersion uint32 = 1
+
+const archiveRecordHeaderSize = 8 + uint32Size
+
+var archiveMagic = []byte("MXOUTARC")
+
+// ArchiveRecord is a payload stored in an archive, along with the moment it was recorded
+type ArchiveRecord struct {
+	RecordedAt  time.Time
+	PayloadData *PayloadData
+}
+
+// An archive file starts with the magic bytes followed by the archive version. Each record is made of a header
+// holding the record timestamp and the body length, followed by the encoded payload data and by a crc32 checksum
+
+func writeArchiveHeader(w io.Writer) error {
+	header := make([]byte, 0, len(archiveMagic)+uint32Size)
+	header = append(header, archiveMagic...)
+	header = binary.BigEndian.AppendUint32(header, ArchiveVersion)
+
+	_, err := w.Write(header)
+	return err
+}
+
+func readArchiveHeader(r io.Reader) error {
+	header := make([]byte, len(archiveMagic)+uint32Size)
+	_, err := io.ReadFull(r, header)
+	if err != nil {
+		return fmt.Errorf("%w, cannot read the header: %s", ErrInvalidArchive, err.Error())
+	}
+	if !bytes.Equal(header[:len(archiveMagic)], archiveMagic) {
+		return fmt.Errorf("%w, unknown file format", ErrInvalidArchive)
+	}
+
+	version := binary.BigEndian.Uint32(header[len(archiveMagic):])
+	if version != ArchiveVersion {
+		return fmt.Errorf("%w %d, supported version %d", ErrUnsupportedArchiveVersion, version, ArchiveVersion)
+	}
+
+	return nil
+}
+
+func writeArchiveRecord(w io.Writer, record *ArchiveRecord) error {
+	body := encodePayloadData(record.PayloadData)
+	if len(body) > MaxFrameSize {
+		return fmt.Errorf("%w, %d bytes", ErrFrameTooLarge, len(body))
+	}
+
+	buff := make([]byte, archiveRecordHeaderSize, archiveRecordHeaderSize+len(body)+checksumSize)
+	binary.BigEndian.PutUint64(buff[:8], uint64(record.RecordedAt.UnixNano()))
+	binary.BigEndian.PutUint32(buff[8:archiveRecordHeaderSize], uint32(len(body)))
+	buff = append(buff, body...)
+	buff = binary.BigEndian.AppendUint32(buff, crc32.ChecksumIEEE(buff))
+
+	_, err := w.Write(buff)
+	return err
+}
+
+// readArchiveRecord returns io.EOF when the archive ends at a record boundary
+func readArchiveRecord(r io.Reader) (*ArchiveRecord, error) {
+	header := make([]byte, archiveRecordHeaderSize)
+	_, err := io.ReadFull(r, header)
+	if errors.Is(err, io.EOF) {
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w, truncated record header", ErrCorruptedArchive)
+	}
+
+	bodyLen := binary.BigEndian.Uint32(header[8:archiveRecordHeaderSize])
+	if bodyLen > MaxFrameSize {
+		return nil, fmt.Errorf("%w, record of %d bytes", ErrCorruptedArchive, bodyLen)
+	}
+	rest := make([]byte, int(bodyLen)+checksumSize)
+	_, err = io.ReadFull(r, rest)
+	if err != nil {
+		return nil, fmt.Errorf("%w, truncated record", ErrCorruptedArchive)
+	}
+
+	body := rest[:bodyLen]
+	checksum := crc32.ChecksumIEEE(append(header, body...))
+	if checksum != binary.BigEndian.Uint32(rest[bodyLen:]) {
+		return nil, fmt.Errorf("%w, checksum mismatch", ErrCorruptedArchive)
+	}
+
+	payloadData, err := decodePayloadData(body)
+	if err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrCorruptedArchive, err.Error())
+	}
+
+	return &ArchiveRecord{
+		RecordedAt:  time.Unix(0, int64(binary.BigEndian.Uint64(header[:8]))),
+		PayloadData: payloadData,
+	}, nil
+}
+
+// ReadArchive returns all the records stored in the provided archive
+func ReadArchive(r io.Reader) ([]*ArchiveRecord, error) {
+	err := readArchiveHeader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*ArchiveRecord, 0)
+	for {
+		record, errRead := readArchiveRecord(r)
+		if errors.Is(errRead, io.EOF) {
+			return records, nil
+		}
+		if errRead != nil {
+			return nil, errRead
+		}
+
+		records = append(records, record)
+	}
+}
@@ -0,0 +1,55 @@
+package transport
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// NetworkTCP is the network used for TCP sockets
+	NetworkTCP = "tcp"
+	// NetworkUnix is the network used for Unix domain sockets
+	NetworkUnix = "unix"
+)
+
+func checkNetworkAndAddress(network string, address string) error {
+	if network != NetworkTCP && network != NetworkUnix {
+		return ErrInvalidNetwork
+	}
+	if len(address) == 0 {
+		return ErrEmptyAddress
+	}
+
+	return nil
+}
+
+func checkPayloadData(payloadData *PayloadData) error {
+	if payloadData == nil {
+		return ErrNilPayloadData
+	}
+	if len(payloadData.Topic) == 0 {
+		return ErrEmptyTopic
+	}
+
+	return nil
+}
+
+// checkPayloadDataToSend checks that the provided payload is valid and that its encoded body fits in a frame
+func checkPayloadDataToSend(payloadData *PayloadData, maxFrameSize int) error {
+	err := checkPayloadData(payloadData)
+	if err != nil {
+		return err
+	}
+
+	size := encodedPayloadDataSize(payloadData)
+	if size > maxFrameSize {
+		return fmt.Errorf("%w, %d bytes", ErrFrameTooLarge, size)
+	}
+
+	return nil
+}
+
+// isPermanentSendError returns true if the provided send error can not be solved by re-sending the same payload
+func isPermanentSendError(err error) bool {
+	return errors.Is(err, ErrFrameTooLarge) || errors.Is(err, ErrNilPayloadData) || errors.Is(err, ErrEmptyTopic)
+}
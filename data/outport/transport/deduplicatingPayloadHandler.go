@@ -0,0 +1,177 @@
+package transport
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/multiversx/mx-chain-core-go/marshal/factory"
+)
+
+// ArgsDeduplicatingPayloadHandler holds the arguments needed to create a new deduplicating payload handler
+type ArgsDeduplicatingPayloadHandler struct {
+	PayloadHandler PayloadHandler
+	Capacity       int
+}
+
+type blockKey struct {
+	topic      string
+	shardID    uint32
+	headerHash string
+}
+
+// deduplicatingPayloadHandler wraps a payload handler and drops the block payloads already processed, as an
+// at-least-once sender might deliver them again. Block payloads are identified by the topic, the shard and the header
+// hash, while payloads of other topics are always forwarded. A processed revert forgets the reverted block, so it can
+// be saved again. The most recent Capacity keys are remembered
+type deduplicatingPayloadHandler struct {
+	payloadHandler PayloadHandler
+	capacity       int
+
+	mut         sync.Mutex
+	marshallers map[string]marshal.Marshalizer
+	seen        map[blockKey]struct{}
+	order       []blockKey
+}
+
+// NewDeduplicatingPayloadHandler creates a new deduplicating payload handler
+func NewDeduplicatingPayloadHandler(args ArgsDeduplicatingPayloadHandler) (*deduplicatingPayloadHandler, error) {
+	if check.IfNil(args.PayloadHandler) {
+		return nil, ErrNilPayloadHandler
+	}
+	if args.Capacity < 1 {
+		return nil, fmt.Errorf("%w, %d", ErrInvalidCapacity, args.Capacity)
+	}
+
+	return &deduplicatingPayloadHandler{
+		payloadHandler: args.PayloadHandler,
+		capacity:       args.Capacity,
+		marshallers:    make(map[string]marshal.Marshalizer),
+		seen:           make(map[blockKey]struct{}),
+		order:          make([]blockKey, 0, args.Capacity),
+	}, nil
+}
+
+// ProcessPayload forwards the provided payload to the wrapped handler, unless it was already processed
+func (dph *deduplicatingPayloadHandler) ProcessPayload(payloadData *PayloadData) error {
+	err := checkPayloadData(payloadData)
+	if err != nil {
+		return err
+	}
+
+	dph.mut.Lock()
+	defer dph.mut.Unlock()
+
+	key, isBlockPayload, err := dph.extractBlockKey(payloadData)
+	if err != nil {
+		return err
+	}
+	if !isBlockPayload {
+		return dph.payloadHandler.ProcessPayload(payloadData)
+	}
+
+	_, alreadyProcessed := dph.seen[key]
+	if alreadyProcessed {
+		return nil
+	}
+
+	err = dph.payloadHandler.ProcessPayload(payloadData)
+	if err != nil {
+		return err
+	}
+
+	if key.topic == outport.TopicRevertIndexedBlock {
+		dph.remove(blockKey{topic: outport.TopicSaveBlock, shardID: key.shardID, headerHash: key.headerHash})
+		return nil
+	}
+	dph.add(key)
+
+	return nil
+}
+
+func (dph *deduplicatingPayloadHandler) extractBlockKey(payloadData *PayloadData) (blockKey, bool, error) {
+	var shardID uint32
+	var headerHash []byte
+
+	switch payloadData.Topic {
+	case outport.TopicSaveBlock:
+		outportBlock := &outport.OutportBlock{}
+		err := dph.unmarshal(payloadData, outportBlock)
+		if err != nil {
+			return blockKey{}, false, err
+		}
+		shardID, headerHash = outportBlock.GetShardID(), outportBlock.GetBlockData().GetHeaderHash()
+	case outport.TopicRevertIndexedBlock:
+		blockData := &outport.BlockData{}
+		err := dph.unmarshal(payloadData, blockData)
+		if err != nil {
+			return blockKey{}, false, err
+		}
+		shardID, headerHash = blockData.GetShardID(), blockData.GetHeaderHash()
+	case outport.TopicFinalizedBlock:
+		finalizedBlock := &outport.FinalizedBlock{}
+		err := dph.unmarshal(payloadData, finalizedBlock)
+		if err != nil {
+			return blockKey{}, false, err
+		}
+		shardID, headerHash = finalizedBlock.GetShardID(), finalizedBlock.GetHeaderHash()
+	default:
+		return blockKey{}, false, nil
+	}
+
+	if len(headerHash) == 0 {
+		return blockKey{}, false, nil
+	}
+
+	return blockKey{
+		topic:      payloadData.Topic,
+		shardID:    shardID,
+		headerHash: string(headerHash),
+	}, true, nil
+}
+
+func (dph *deduplicatingPayloadHandler) unmarshal(payloadData *PayloadData, obj interface{}) error {
+	marshaller, found := dph.marshallers[payloadData.MarshallerType]
+	if !found {
+		var err error
+		marshaller, err = factory.NewMarshalizer(payloadData.MarshallerType)
+		if err != nil {
+			return err
+		}
+		dph.marshallers[payloadData.MarshallerType] = marshaller
+	}
+
+	return marshaller.Unmarshal(obj, payloadData.Payload)
+}
+
+func (dph *deduplicatingPayloadHandler) add(key blockKey) {
+	if len(dph.order) == dph.capacity {
+		delete(dph.seen, dph.order[0])
+		dph.order = dph.order[1:]
+	}
+
+	dph.seen[key] = struct{}{}
+	dph.order = append(dph.order, key)
+}
+
+func (dph *deduplicatingPayloadHandler) remove(key blockKey) {
+	_, found := dph.seen[key]
+	if !found {
+		return
+	}
+
+	delete(dph.seen, key)
+	for i, orderedKey := range dph.order {
+		if orderedKey == key {
+			dph.order = append(dph.order[:i], dph.order[i+1:]...)
+			return
+		}
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (dph *deduplicatingPayloadHandler) IsInterfaceNil() bool {
+	return dph == nil
+}
@@ -0,0 +1,140 @@
+package transport
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-core-go/marshal/factory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createPayload(t *testing.T, topic string, obj interface{}) *PayloadData {
+	marshaller, _ := factory.NewMarshalizer(factory.JsonMarshalizer)
+	payload, err := marshaller.Marshal(obj)
+	require.Nil(t, err)
+
+	return &PayloadData{
+		Topic:          topic,
+		MarshallerType: factory.JsonMarshalizer,
+		Payload:        payload,
+	}
+}
+
+func createSaveBlockPayload(t *testing.T, shardID uint32, headerHash []byte) *PayloadData {
+	return createPayload(t, outport.TopicSaveBlock, &outport.OutportBlock{
+		ShardID:   shardID,
+		BlockData: &outport.BlockData{ShardID: shardID, HeaderHash: headerHash},
+	})
+}
+
+func TestNewDeduplicatingPayloadHandler(t *testing.T) {
+	t.Parallel()
+
+	dph, err := NewDeduplicatingPayloadHandler(ArgsDeduplicatingPayloadHandler{Capacity: 1})
+	assert.Equal(t, ErrNilPayloadHandler, err)
+	assert.True(t, check.IfNil(dph))
+
+	_, err = NewDeduplicatingPayloadHandler(ArgsDeduplicatingPayloadHandler{PayloadHandler: &payloadsCollector{}})
+	assert.True(t, errors.Is(err, ErrInvalidCapacity))
+
+	dph, err = NewDeduplicatingPayloadHandler(ArgsDeduplicatingPayloadHandler{PayloadHandler: &payloadsCollector{}, Capacity: 1})
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(dph))
+}
+
+func TestDeduplicatingPayloadHandler_ProcessPayload(t *testing.T) {
+	t.Parallel()
+
+	t.Run("duplicated block payloads should be dropped", func(t *testing.T) {
+		t.Parallel()
+
+		collector := &payloadsCollector{}
+		dph, _ := NewDeduplicatingPayloadHandler(ArgsDeduplicatingPayloadHandler{PayloadHandler: collector, Capacity: 10})
+
+		require.Nil(t, dph.ProcessPayload(createSaveBlockPayload(t, 0, []byte("hash"))))
+		require.Nil(t, dph.ProcessPayload(createSaveBlockPayload(t, 0, []byte("hash"))))
+		require.Nil(t, dph.ProcessPayload(createSaveBlockPayload(t, 1, []byte("hash"))))
+
+		finalized := createPayload(t, outport.TopicFinalizedBlock, &outport.FinalizedBlock{ShardID: 0, HeaderHash: []byte("hash")})
+		require.Nil(t, dph.ProcessPayload(finalized))
+		require.Nil(t, dph.ProcessPayload(finalized))
+
+		assert.Equal(t, []string{outport.TopicSaveBlock, outport.TopicSaveBlock, outport.TopicFinalizedBlock}, collector.getTopics())
+	})
+	t.Run("other topics should always be forwarded", func(t *testing.T) {
+		t.Parallel()
+
+		collector := &payloadsCollector{}
+		dph, _ := NewDeduplicatingPayloadHandler(ArgsDeduplicatingPayloadHandler{PayloadHandler: collector, Capacity: 10})
+
+		require.Nil(t, dph.ProcessPayload(&PayloadData{Topic: outport.TopicSettings}))
+		require.Nil(t, dph.ProcessPayload(&PayloadData{Topic: outport.TopicSettings}))
+		assert.Equal(t, []string{outport.TopicSettings, outport.TopicSettings}, collector.getTopics())
+	})
+	t.Run("a reverted block should be accepted again", func(t *testing.T) {
+		t.Parallel()
+
+		collector := &payloadsCollector{}
+		dph, _ := NewDeduplicatingPayloadHandler(ArgsDeduplicatingPayloadHandler{PayloadHandler: collector, Capacity: 10})
+
+		saveBlock := createSaveBlockPayload(t, 0, []byte("hash"))
+		revert := createPayload(t, outport.TopicRevertIndexedBlock, &outport.BlockData{ShardID: 0, HeaderHash: []byte("hash")})
+		require.Nil(t, dph.ProcessPayload(saveBlock))
+		require.Nil(t, dph.ProcessPayload(revert))
+		require.Nil(t, dph.ProcessPayload(saveBlock))
+
+		expectedTopics := []string{outport.TopicSaveBlock, outport.TopicRevertIndexedBlock, outport.TopicSaveBlock}
+		assert.Equal(t, expectedTopics, collector.getTopics())
+	})
+	t.Run("failed processing should not mark the payload as processed", func(t *testing.T) {
+		t.Parallel()
+
+		numCalls := 0
+		expectedErr := errors.New("expected error")
+		dph, _ := NewDeduplicatingPayloadHandler(ArgsDeduplicatingPayloadHandler{
+			PayloadHandler: &payloadHandlerStub{
+				ProcessPayloadCalled: func(payloadData *PayloadData) error {
+					numCalls++
+					if numCalls == 1 {
+						return expectedErr
+					}
+					return nil
+				},
+			},
+			Capacity: 10,
+		})
+
+		saveBlock := createSaveBlockPayload(t, 0, []byte("hash"))
+		assert.Equal(t, expectedErr, dph.ProcessPayload(saveBlock))
+		assert.Nil(t, dph.ProcessPayload(saveBlock))
+		assert.Equal(t, 2, numCalls)
+	})
+	t.Run("oldest keys should be evicted when reaching the capacity", func(t *testing.T) {
+		t.Parallel()
+
+		collector := &payloadsCollector{}
+		dph, _ := NewDeduplicatingPayloadHandler(ArgsDeduplicatingPayloadHandler{PayloadHandler: collector, Capacity: 2})
+
+		require.Nil(t, dph.ProcessPayload(createSaveBlockPayload(t, 0, []byte("hash1"))))
+		require.Nil(t, dph.ProcessPayload(createSaveBlockPayload(t, 0, []byte("hash2"))))
+		require.Nil(t, dph.ProcessPayload(createSaveBlockPayload(t, 0, []byte("hash3"))))
+		require.Nil(t, dph.ProcessPayload(createSaveBlockPayload(t, 0, []byte("hash3"))))
+		require.Nil(t, dph.ProcessPayload(createSaveBlockPayload(t, 0, []byte("hash1"))))
+
+		assert.Len(t, collector.getTopics(), 4)
+	})
+	t.Run("invalid payloads should error", func(t *testing.T) {
+		t.Parallel()
+
+		dph, _ := NewDeduplicatingPayloadHandler(ArgsDeduplicatingPayloadHandler{PayloadHandler: &payloadsCollector{}, Capacity: 2})
+
+		assert.Equal(t, ErrNilPayloadData, dph.ProcessPayload(nil))
+		err := dph.ProcessPayload(&PayloadData{Topic: outport.TopicSaveBlock, MarshallerType: "unknown"})
+		assert.NotNil(t, err)
+		err = dph.ProcessPayload(&PayloadData{Topic: outport.TopicSaveBlock, MarshallerType: factory.JsonMarshalizer, Payload: []byte("{")})
+		assert.NotNil(t, err)
+	})
+}
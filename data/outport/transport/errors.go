@@ -0,0 +1,72 @@
+package transport
+
+import "errors"
+
+// ErrNilPayloadHandler signals that a nil payload handler has been provided
+var ErrNilPayloadHandler = errors.New("nil payload handler")
+
+// ErrNilPayloadData signals that nil payload data has been provided
+var ErrNilPayloadData = errors.New("nil payload data")
+
+// ErrEmptyTopic signals that the payload data has an empty topic
+var ErrEmptyTopic = errors.New("empty topic")
+
+// ErrInvalidNetwork signals that an unsupported network has been provided
+var ErrInvalidNetwork = errors.New("invalid network, should be tcp or unix")
+
+// ErrEmptyAddress signals that an empty address has been provided
+var ErrEmptyAddress = errors.New("empty address")
+
+// ErrInvalidDuration signals that an invalid duration has been provided
+var ErrInvalidDuration = errors.New("invalid duration")
+
+// ErrFrameTooLarge signals that a frame exceeds the maximum allowed size
+var ErrFrameTooLarge = errors.New("frame too large")
+
+// ErrInvalidFrame signals that a malformed or unexpected frame has been received
+var ErrInvalidFrame = errors.New("invalid frame")
+
+// ErrAckCounterMismatch signals that the received ack does not match the sent payload
+var ErrAckCounterMismatch = errors.New("ack counter mismatch")
+
+// ErrPayloadNotProcessed signals that the receiver could not process the payload
+var ErrPayloadNotProcessed = errors.New("payload not processed by the receiver")
+
+// ErrSenderClosed signals that the sender was closed
+var ErrSenderClosed = errors.New("sender closed")
+
+// ErrJournalEntryNotFound signals that the journal holds no pending entry with the provided sequence number
+var ErrJournalEntryNotFound = errors.New("journal entry not found")
+
+// ErrCorruptedJournalRecord signals that a journal record, other than a torn last record, is invalid
+var ErrCorruptedJournalRecord = errors.New("corrupted journal record")
+
+// ErrNilPayloadSender signals that a nil payload sender has been provided
+var ErrNilPayloadSender = errors.New("nil payload sender")
+
+// ErrNilJournal signals that a nil journal has been provided
+var ErrNilJournal = errors.New("nil journal")
+
+// ErrNilAppStatusHandler signals that a nil app status handler has been provided
+var ErrNilAppStatusHandler = errors.New("nil app status handler")
+
+// ErrInvalidCapacity signals that an invalid capacity has been provided
+var ErrInvalidCapacity = errors.New("invalid capacity")
+
+// ErrEmptyFilePath signals that an empty file path has been provided
+var ErrEmptyFilePath = errors.New("empty file path")
+
+// ErrRecorderClosed signals that the recorder was closed
+var ErrRecorderClosed = errors.New("recorder closed")
+
+// ErrInvalidArchive signals that the provided file is not an outport archive
+var ErrInvalidArchive = errors.New("invalid archive")
+
+// ErrUnsupportedArchiveVersion signals that the archive was written with an unsupported version
+var ErrUnsupportedArchiveVersion = errors.New("unsupported archive version")
+
+// ErrCorruptedArchive signals that an archive record is truncated or failed the checksum verification
+var ErrCorruptedArchive = errors.New("corrupted archive")
+
+// ErrInvalidSpeedFactor signals that an invalid speed factor has been provided
+var ErrInvalidSpeedFactor = errors.New("invalid speed factor")
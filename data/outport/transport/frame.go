@@ -0,0 +1,190 @@
+package transport
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+)
+
+// MaxFrameSize is the maximum size of a frame body, in bytes
+const MaxFrameSize = 256 * 1024 * 1024
+
+const (
+	uint32Size      = 4
+	frameHeaderSize = 1 + 8 + uint32Size
+)
+
+type frameType byte
+
+const (
+	payloadFrame frameType = 1
+	ackFrame     frameType = 2
+)
+
+// AckStatusProcessed is the status of the ack of a successfully processed payload
+const AckStatusProcessed = "processed"
+
+const (
+	ackCompletedEventFlag byte = 1 << iota
+	ackErrorEventFlag
+)
+
+// PayloadData is the unit of data shipped from a sender to a receiver
+type PayloadData struct {
+	Topic          string
+	MarshallerType string
+	Payload        []byte
+}
+
+// AckData is the answer of a receiver for a processed payload. A failed processing is signaled by the error event
+// flag of the status info, while the status holds the processing error
+type AckData struct {
+	Counter    uint64
+	StatusInfo outport.StatusInfo
+}
+
+// A frame is composed of a header, holding the frame type, the counter and the body length, followed by the body.
+// A payload body holds the length prefixed topic and marshaller type, followed by the payload bytes, while an ack
+// body holds the completed and error event flags followed by the status
+
+func writePayloadFrame(w io.Writer, counter uint64, payloadData *PayloadData) error {
+	return writeFrame(w, payloadFrame, counter, encodePayloadData(payloadData))
+}
+
+func writeAckFrame(w io.Writer, ack *AckData) error {
+	flags := byte(0)
+	if ack.StatusInfo.CompletedEvent {
+		flags |= ackCompletedEventFlag
+	}
+	if ack.StatusInfo.ErrorEvent {
+		flags |= ackErrorEventFlag
+	}
+
+	body := make([]byte, 0, 1+len(ack.StatusInfo.Status))
+	body = append(body, flags)
+	body = append(body, ack.StatusInfo.Status...)
+
+	return writeFrame(w, ackFrame, ack.Counter, body)
+}
+
+func writeFrame(w io.Writer, fType frameType, counter uint64, body []byte) error {
+	if len(body) > MaxFrameSize {
+		return fmt.Errorf("%w, %d bytes", ErrFrameTooLarge, len(body))
+	}
+
+	frame := make([]byte, frameHeaderSize, frameHeaderSize+len(body))
+	frame[0] = byte(fType)
+	binary.BigEndian.PutUint64(frame[1:9], counter)
+	binary.BigEndian.PutUint32(frame[9:frameHeaderSize], uint32(len(body)))
+	frame = append(frame, body...)
+
+	_, err := w.Write(frame)
+	return err
+}
+
+func readPayloadFrame(r io.Reader) (uint64, *PayloadData, error) {
+	counter, body, err := readFrame(r, payloadFrame)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	payloadData, err := decodePayloadData(body)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return counter, payloadData, nil
+}
+
+func readAckFrame(r io.Reader) (*AckData, error) {
+	counter, body, err := readFrame(r, ackFrame)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) == 0 {
+		return nil, fmt.Errorf("%w, empty ack body", ErrInvalidFrame)
+	}
+
+	return &AckData{
+		Counter: counter,
+		StatusInfo: outport.StatusInfo{
+			CompletedEvent: body[0]&ackCompletedEventFlag != 0,
+			ErrorEvent:     body[0]&ackErrorEventFlag != 0,
+			Status:         string(body[1:]),
+		},
+	}, nil
+}
+
+func readFrame(r io.Reader, expectedType frameType) (uint64, []byte, error) {
+	header := make([]byte, frameHeaderSize)
+	_, err := io.ReadFull(r, header)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if frameType(header[0]) != expectedType {
+		return 0, nil, fmt.Errorf("%w, type %d, expected %d", ErrInvalidFrame, header[0], expectedType)
+	}
+	bodyLen := binary.BigEndian.Uint32(header[9:frameHeaderSize])
+	if bodyLen > MaxFrameSize {
+		return 0, nil, fmt.Errorf("%w, %d bytes", ErrFrameTooLarge, bodyLen)
+	}
+
+	body := make([]byte, bodyLen)
+	_, err = io.ReadFull(r, body)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return binary.BigEndian.Uint64(header[1:9]), body, nil
+}
+
+func appendLengthPrefixed(buff []byte, value []byte) []byte {
+	buff = binary.BigEndian.AppendUint32(buff, uint32(len(value)))
+	return append(buff, value...)
+}
+
+func readLengthPrefixed(buff []byte) ([]byte, []byte, error) {
+	if len(buff) < uint32Size {
+		return nil, nil, fmt.Errorf("%w, missing length prefix", ErrInvalidFrame)
+	}
+
+	length := binary.BigEndian.Uint32(buff[:uint32Size])
+	if uint64(length) > uint64(len(buff)-uint32Size) {
+		return nil, nil, fmt.Errorf("%w, length prefix %d exceeds the frame", ErrInvalidFrame, length)
+	}
+
+	end := uint32Size + int(length)
+	return buff[uint32Size:end], buff[end:], nil
+}
+
+func encodedPayloadDataSize(payloadData *PayloadData) int {
+	return 2*uint32Size + len(payloadData.Topic) + len(payloadData.MarshallerType) + len(payloadData.Payload)
+}
+
+func encodePayloadData(payloadData *PayloadData) []byte {
+	body := make([]byte, 0, encodedPayloadDataSize(payloadData))
+	body = appendLengthPrefixed(body, []byte(payloadData.Topic))
+	body = appendLengthPrefixed(body, []byte(payloadData.MarshallerType))
+
+	return append(body, payloadData.Payload...)
+}
+
+func decodePayloadData(body []byte) (*PayloadData, error) {
+	topic, rest, err := readLengthPrefixed(body)
+	if err != nil {
+		return nil, err
+	}
+	marshallerType, payload, err := readLengthPrefixed(rest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PayloadData{
+		Topic:          string(topic),
+		MarshallerType: string(marshallerType),
+		Payload:        payload,
+	}, nil
+}
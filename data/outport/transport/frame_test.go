@@ -0,0 +1,110 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayloadFrame(t *testing.T) {
+	t.Parallel()
+
+	t.Run("write and read should work", func(t *testing.T) {
+		t.Parallel()
+
+		payloadData := &PayloadData{
+			Topic:          "SaveBlock",
+			MarshallerType: "gogo protobuf",
+			Payload:        []byte("payload"),
+		}
+		buff := &bytes.Buffer{}
+		require.Nil(t, writePayloadFrame(buff, 7, payloadData))
+		require.Nil(t, writePayloadFrame(buff, 8, &PayloadData{Topic: "Settings"}))
+
+		counter, readPayloadData, err := readPayloadFrame(buff)
+		require.Nil(t, err)
+		assert.Equal(t, uint64(7), counter)
+		assert.Equal(t, payloadData, readPayloadData)
+
+		counter, readPayloadData, err = readPayloadFrame(buff)
+		require.Nil(t, err)
+		assert.Equal(t, uint64(8), counter)
+		assert.Equal(t, "Settings", readPayloadData.Topic)
+		assert.Empty(t, readPayloadData.Payload)
+
+		_, _, err = readPayloadFrame(buff)
+		assert.Equal(t, io.EOF, err)
+	})
+	t.Run("truncated frame should error", func(t *testing.T) {
+		t.Parallel()
+
+		buff := &bytes.Buffer{}
+		require.Nil(t, writePayloadFrame(buff, 1, &PayloadData{Topic: "SaveBlock", Payload: []byte("payload")}))
+
+		_, _, err := readPayloadFrame(bytes.NewReader(buff.Bytes()[:buff.Len()-1]))
+		assert.Equal(t, io.ErrUnexpectedEOF, err)
+	})
+	t.Run("wrong frame type should error", func(t *testing.T) {
+		t.Parallel()
+
+		buff := &bytes.Buffer{}
+		require.Nil(t, writeAckFrame(buff, &AckData{Counter: 1}))
+
+		_, _, err := readPayloadFrame(buff)
+		assert.True(t, errors.Is(err, ErrInvalidFrame))
+	})
+	t.Run("malformed length prefix should error", func(t *testing.T) {
+		t.Parallel()
+
+		body := binary.BigEndian.AppendUint32(nil, 100)
+		buff := &bytes.Buffer{}
+		require.Nil(t, writeFrame(buff, payloadFrame, 1, body))
+
+		_, _, err := readPayloadFrame(buff)
+		assert.True(t, errors.Is(err, ErrInvalidFrame))
+
+		buff.Reset()
+		require.Nil(t, writeFrame(buff, payloadFrame, 1, []byte{0}))
+		_, _, err = readPayloadFrame(buff)
+		assert.True(t, errors.Is(err, ErrInvalidFrame))
+	})
+	t.Run("too large frame should error", func(t *testing.T) {
+		t.Parallel()
+
+		header := make([]byte, frameHeaderSize)
+		header[0] = byte(payloadFrame)
+		binary.BigEndian.PutUint32(header[9:], MaxFrameSize+1)
+
+		_, _, err := readPayloadFrame(bytes.NewReader(header))
+		assert.True(t, errors.Is(err, ErrFrameTooLarge))
+	})
+}
+
+func TestAckFrame(t *testing.T) {
+	t.Parallel()
+
+	buff := &bytes.Buffer{}
+	ack := &AckData{Counter: 3, StatusInfo: outport.StatusInfo{ErrorEvent: true, Status: "cannot process"}}
+	require.Nil(t, writeAckFrame(buff, ack))
+
+	readAck, err := readAckFrame(buff)
+	require.Nil(t, err)
+	assert.Equal(t, ack, readAck)
+
+	ack = &AckData{Counter: 4, StatusInfo: outport.StatusInfo{CompletedEvent: true, Status: AckStatusProcessed}}
+	require.Nil(t, writeAckFrame(buff, ack))
+
+	readAck, err = readAckFrame(buff)
+	require.Nil(t, err)
+	assert.Equal(t, ack, readAck)
+
+	require.Nil(t, writeFrame(buff, ackFrame, 3, nil))
+	_, err = readAckFrame(buff)
+	assert.True(t, errors.Is(err, ErrInvalidFrame))
+}
@@ -0,0 +1,25 @@
+package transport
+
+// PayloadHandler defines the behavior of a component able to process the payloads received by a receiver
+type PayloadHandler interface {
+	ProcessPayload(payloadData *PayloadData) error
+	IsInterfaceNil() bool
+}
+
+// PayloadSender defines the behavior of a component able to ship payloads to a receiver
+type PayloadSender interface {
+	Send(payloadData *PayloadData) error
+	Close() error
+	IsInterfaceNil() bool
+}
+
+// Journal defines the behavior of a durable store of the payloads not yet acknowledged by the receiver
+type Journal interface {
+	Append(payloadData *PayloadData) (uint64, error)
+	Ack(sequence uint64) error
+	Pending() []*JournalEntry
+	LastSequence() uint64
+	LastAckedSequence() uint64
+	Close() error
+	IsInterfaceNil() bool
+}
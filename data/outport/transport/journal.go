@@ -0,0 +1,335 @@
+package transport
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+type recordType byte
+
+const (
+	entryRecord recordType = 1
+	ackRecord   recordType = 2
+
+	recordHeaderSize = 1 + 8 + 8 + uint32Size
+	checksumSize     = uint32Size
+
+	minObsoleteRecordsToCompact = 64
+	compactedFileSuffix         = ".compacted"
+)
+
+// JournalEntry is a payload stored in the journal, identified by its sequence number
+type JournalEntry struct {
+	Sequence    uint64
+	AppendedAt  time.Time
+	PayloadData *PayloadData
+}
+
+// journal is a file based, append-only log of payloads and of their acknowledgements. Each record is made of a
+// header holding the record type, the sequence number, the append timestamp and the body length, followed by the
+// body and by a crc32 checksum. A torn record at the end of the file, left by a crash, is discarded when opening,
+// while any other invalid record fails the opening and leaves the file untouched. The file is compacted once the
+// acknowledged entries and their ack records outnumber the pending entries
+type journal struct {
+	mut                sync.Mutex
+	filePath           string
+	file               *os.File
+	syncOnWrite        bool
+	lastSequence       uint64
+	lastAcked          uint64
+	numObsoleteRecords int
+	pending            []*JournalEntry
+}
+
+// NewJournal opens, or creates, the journal stored in the provided file. When syncOnWrite is set, every record is
+// flushed to the disk before returning
+func NewJournal(filePath string, syncOnWrite bool) (*journal, error) {
+	file, err := os.OpenFile(filePath, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	j := &journal{
+		filePath:    filePath,
+		file:        file,
+		syncOnWrite: syncOnWrite,
+		pending:     make([]*JournalEntry, 0),
+	}
+
+	err = j.load()
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	return j, nil
+}
+
+func (j *journal) load() error {
+	reader := bufio.NewReader(j.file)
+	validOffset := int64(0)
+	for {
+		rType, sequence, appendedAt, body, size, err := readRecord(reader)
+		if err == io.EOF {
+			break
+		}
+		if err == io.ErrUnexpectedEOF {
+			// only the last record can be torn by a crash while appending
+			return j.truncateAt(validOffset)
+		}
+		if err != nil {
+			return fmt.Errorf("%w at offset %d", err, validOffset)
+		}
+
+		err = j.loadRecord(rType, sequence, appendedAt, body)
+		if err != nil {
+			return fmt.Errorf("%w at offset %d", err, validOffset)
+		}
+		validOffset += size
+	}
+
+	_, err := j.file.Seek(validOffset, io.SeekStart)
+
+	return err
+}
+
+func (j *journal) loadRecord(rType recordType, sequence uint64, appendedAt time.Time, body []byte) error {
+	switch rType {
+	case entryRecord:
+		payloadData, err := decodePayloadData(body)
+		if err != nil {
+			return fmt.Errorf("%w, %s", ErrCorruptedJournalRecord, err.Error())
+		}
+		j.pending = append(j.pending, &JournalEntry{
+			Sequence:    sequence,
+			AppendedAt:  appendedAt,
+			PayloadData: payloadData,
+		})
+		j.lastSequence = sequence
+	case ackRecord:
+		if j.removePending(sequence) {
+			j.numObsoleteRecords += 2
+		}
+	default:
+		return fmt.Errorf("%w, unknown record type %d", ErrCorruptedJournalRecord, rType)
+	}
+
+	return nil
+}
+
+func (j *journal) truncateAt(offset int64) error {
+	err := j.file.Truncate(offset)
+	if err != nil {
+		return err
+	}
+	_, err = j.file.Seek(offset, io.SeekStart)
+
+	return err
+}
+
+// Append stores the provided payload and returns the sequence number assigned to it. Payloads that do not fit in a
+// frame are rejected, as they could never be delivered
+func (j *journal) Append(payloadData *PayloadData) (uint64, error) {
+	err := checkPayloadDataToSend(payloadData, MaxFrameSize)
+	if err != nil {
+		return 0, err
+	}
+
+	j.mut.Lock()
+	defer j.mut.Unlock()
+
+	sequence := j.lastSequence + 1
+	appendedAt := time.Now()
+	err = j.writeRecord(entryRecord, sequence, appendedAt, encodePayloadData(payloadData))
+	if err != nil {
+		return 0, err
+	}
+
+	j.lastSequence = sequence
+	j.pending = append(j.pending, &JournalEntry{
+		Sequence:    sequence,
+		AppendedAt:  appendedAt,
+		PayloadData: payloadData,
+	})
+
+	return sequence, nil
+}
+
+// Ack marks the entry with the provided sequence number as delivered. Once no entry is pending anymore, the
+// journal file is truncated, so the sequence numbers restart from 1 after reopening an empty journal. Otherwise,
+// the journal file is rewritten with the pending entries only when the acknowledged records dominate
+func (j *journal) Ack(sequence uint64) error {
+	j.mut.Lock()
+	defer j.mut.Unlock()
+
+	if !j.removePending(sequence) {
+		return fmt.Errorf("%w, sequence %d", ErrJournalEntryNotFound, sequence)
+	}
+
+	if len(j.pending) == 0 {
+		j.numObsoleteRecords = 0
+		return j.truncateAt(0)
+	}
+
+	err := j.writeRecord(ackRecord, sequence, time.Now(), nil)
+	if err != nil {
+		return err
+	}
+	j.numObsoleteRecords += 2
+	if j.numObsoleteRecords < minObsoleteRecordsToCompact || j.numObsoleteRecords <= len(j.pending) {
+		return nil
+	}
+
+	return j.compact()
+}
+
+// compact writes the pending entries in a new file, which then atomically replaces the journal file
+func (j *journal) compact() error {
+	compactedPath := j.filePath + compactedFileSuffix
+	compactedFile, err := os.OpenFile(compactedPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	buff := make([]byte, 0)
+	for _, entry := range j.pending {
+		buff = append(buff, encodeRecord(entryRecord, entry.Sequence, entry.AppendedAt, encodePayloadData(entry.PayloadData))...)
+	}
+	_, err = compactedFile.Write(buff)
+	if err == nil {
+		err = compactedFile.Sync()
+	}
+	if err == nil {
+		err = os.Rename(compactedPath, j.filePath)
+	}
+	if err != nil {
+		_ = compactedFile.Close()
+		_ = os.Remove(compactedPath)
+		return err
+	}
+
+	_ = j.file.Close()
+	j.file = compactedFile
+	j.numObsoleteRecords = 0
+
+	return nil
+}
+
+func (j *journal) removePending(sequence uint64) bool {
+	for i, entry := range j.pending {
+		if entry.Sequence != sequence {
+			continue
+		}
+
+		j.pending = append(j.pending[:i], j.pending[i+1:]...)
+		if sequence > j.lastAcked {
+			j.lastAcked = sequence
+		}
+		return true
+	}
+
+	return false
+}
+
+func (j *journal) writeRecord(rType recordType, sequence uint64, timestamp time.Time, body []byte) error {
+	_, err := j.file.Write(encodeRecord(rType, sequence, timestamp, body))
+	if err != nil {
+		return err
+	}
+	if j.syncOnWrite {
+		return j.file.Sync()
+	}
+
+	return nil
+}
+
+// Pending returns the entries not yet acknowledged, in the order they were appended
+func (j *journal) Pending() []*JournalEntry {
+	j.mut.Lock()
+	defer j.mut.Unlock()
+
+	pending := make([]*JournalEntry, len(j.pending))
+	copy(pending, j.pending)
+
+	return pending
+}
+
+// LastSequence returns the sequence number of the last appended entry
+func (j *journal) LastSequence() uint64 {
+	j.mut.Lock()
+	defer j.mut.Unlock()
+
+	return j.lastSequence
+}
+
+// LastAckedSequence returns the highest acknowledged sequence number since the journal was opened
+func (j *journal) LastAckedSequence() uint64 {
+	j.mut.Lock()
+	defer j.mut.Unlock()
+
+	return j.lastAcked
+}
+
+// Close closes the journal file
+func (j *journal) Close() error {
+	j.mut.Lock()
+	defer j.mut.Unlock()
+
+	return j.file.Close()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (j *journal) IsInterfaceNil() bool {
+	return j == nil
+}
+
+func encodeRecord(rType recordType, sequence uint64, timestamp time.Time, body []byte) []byte {
+	record := make([]byte, recordHeaderSize, recordHeaderSize+len(body)+checksumSize)
+	record[0] = byte(rType)
+	binary.BigEndian.PutUint64(record[1:9], sequence)
+	binary.BigEndian.PutUint64(record[9:17], uint64(timestamp.UnixNano()))
+	binary.BigEndian.PutUint32(record[17:recordHeaderSize], uint32(len(body)))
+	record = append(record, body...)
+
+	return binary.BigEndian.AppendUint32(record, crc32.ChecksumIEEE(record))
+}
+
+// readRecord returns io.EOF when no record is left and io.ErrUnexpectedEOF when the record is torn
+func readRecord(reader io.Reader) (recordType, uint64, time.Time, []byte, int64, error) {
+	header := make([]byte, recordHeaderSize)
+	_, err := io.ReadFull(reader, header)
+	if err != nil {
+		return 0, 0, time.Time{}, nil, 0, err
+	}
+
+	bodyLen := binary.BigEndian.Uint32(header[17:recordHeaderSize])
+	if bodyLen > MaxFrameSize {
+		return 0, 0, time.Time{}, nil, 0, fmt.Errorf("%w, record of %d bytes", ErrCorruptedJournalRecord, bodyLen)
+	}
+	rest := make([]byte, int(bodyLen)+checksumSize)
+	_, err = io.ReadFull(reader, rest)
+	if err == io.EOF {
+		return 0, 0, time.Time{}, nil, 0, io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return 0, 0, time.Time{}, nil, 0, err
+	}
+
+	body := rest[:bodyLen]
+	checksum := crc32.ChecksumIEEE(append(header, body...))
+	if checksum != binary.BigEndian.Uint32(rest[bodyLen:]) {
+		return 0, 0, time.Time{}, nil, 0, fmt.Errorf("%w, checksum mismatch", ErrCorruptedJournalRecord)
+	}
+
+	sequence := binary.BigEndian.Uint64(header[1:9])
+	appendedAt := time.Unix(0, int64(binary.BigEndian.Uint64(header[9:17])))
+	size := int64(recordHeaderSize + len(rest))
+
+	return recordType(header[0]), sequence, appendedAt, body, size, nil
+}
@@ -0,0 +1,248 @@
+package transport
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getPendingTopics(j Journal) []string {
+	topics := make([]string, 0)
+	for _, entry := range j.Pending() {
+		topics = append(topics, entry.PayloadData.Topic)
+	}
+
+	return topics
+}
+
+func TestNewJournal(t *testing.T) {
+	t.Parallel()
+
+	j, err := NewJournal(filepath.Join(t.TempDir(), "missing", "journal"), false)
+	assert.NotNil(t, err)
+	assert.True(t, check.IfNil(j))
+
+	j, err = NewJournal(filepath.Join(t.TempDir(), "journal"), true)
+	require.Nil(t, err)
+	assert.False(t, check.IfNil(j))
+	assert.Empty(t, j.Pending())
+	assert.Zero(t, j.LastSequence())
+	assert.Nil(t, j.Close())
+}
+
+func TestJournal_AppendAndAck(t *testing.T) {
+	t.Parallel()
+
+	j, _ := NewJournal(filepath.Join(t.TempDir(), "journal"), false)
+	defer func() {
+		_ = j.Close()
+	}()
+
+	_, err := j.Append(nil)
+	assert.Equal(t, ErrNilPayloadData, err)
+	_, err = j.Append(&PayloadData{Topic: outport.TopicSaveBlock, Payload: make([]byte, MaxFrameSize)})
+	assert.True(t, errors.Is(err, ErrFrameTooLarge))
+
+	sequence, err := j.Append(&PayloadData{Topic: outport.TopicSaveBlock, MarshallerType: "json", Payload: []byte("block")})
+	require.Nil(t, err)
+	assert.Equal(t, uint64(1), sequence)
+	sequence, _ = j.Append(&PayloadData{Topic: outport.TopicFinalizedBlock})
+	assert.Equal(t, uint64(2), sequence)
+
+	pending := j.Pending()
+	require.Len(t, pending, 2)
+	assert.Equal(t, []byte("block"), pending[0].PayloadData.Payload)
+	assert.Equal(t, "json", pending[0].PayloadData.MarshallerType)
+
+	err = j.Ack(3)
+	assert.True(t, errors.Is(err, ErrJournalEntryNotFound))
+
+	require.Nil(t, j.Ack(1))
+	assert.Equal(t, []string{outport.TopicFinalizedBlock}, getPendingTopics(j))
+	assert.Equal(t, uint64(1), j.LastAckedSequence())
+	assert.Equal(t, uint64(2), j.LastSequence())
+}
+
+func TestJournal_ReopenShouldRestorePendingEntries(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), "journal")
+	j, _ := NewJournal(filePath, true)
+	_, _ = j.Append(&PayloadData{Topic: outport.TopicSaveBlock})
+	_, _ = j.Append(&PayloadData{Topic: outport.TopicFinalizedBlock})
+	_, _ = j.Append(&PayloadData{Topic: outport.TopicSettings})
+	_ = j.Ack(2)
+	require.Nil(t, j.Close())
+
+	j, err := NewJournal(filePath, true)
+	require.Nil(t, err)
+	assert.Equal(t, []string{outport.TopicSaveBlock, outport.TopicSettings}, getPendingTopics(j))
+	assert.Equal(t, uint64(3), j.LastSequence())
+
+	sequence, _ := j.Append(&PayloadData{Topic: outport.TopicRevertIndexedBlock})
+	assert.Equal(t, uint64(4), sequence)
+	require.Nil(t, j.Close())
+}
+
+func TestJournal_ReopenShouldDiscardTornTail(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), "journal")
+	j, _ := NewJournal(filePath, false)
+	_, _ = j.Append(&PayloadData{Topic: outport.TopicSaveBlock})
+	_, _ = j.Append(&PayloadData{Topic: outport.TopicFinalizedBlock, Payload: []byte("finalized")})
+	require.Nil(t, j.Close())
+
+	info, _ := os.Stat(filePath)
+	require.Nil(t, os.Truncate(filePath, info.Size()-3))
+
+	j, err := NewJournal(filePath, false)
+	require.Nil(t, err)
+	assert.Equal(t, []string{outport.TopicSaveBlock}, getPendingTopics(j))
+
+	sequence, _ := j.Append(&PayloadData{Topic: outport.TopicSettings})
+	assert.Equal(t, uint64(2), sequence)
+	require.Nil(t, j.Close())
+
+	j, _ = NewJournal(filePath, false)
+	assert.Equal(t, []string{outport.TopicSaveBlock, outport.TopicSettings}, getPendingTopics(j))
+	require.Nil(t, j.Close())
+}
+
+func TestJournal_AckAllShouldTruncateTheFile(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), "journal")
+	j, _ := NewJournal(filePath, false)
+	_, _ = j.Append(&PayloadData{Topic: outport.TopicSaveBlock})
+	_, _ = j.Append(&PayloadData{Topic: outport.TopicFinalizedBlock})
+	require.Nil(t, j.Ack(1))
+	require.Nil(t, j.Ack(2))
+
+	info, _ := os.Stat(filePath)
+	assert.Zero(t, info.Size())
+
+	_, _ = j.Append(&PayloadData{Topic: outport.TopicSettings})
+	require.Nil(t, j.Close())
+
+	j, _ = NewJournal(filePath, false)
+	assert.Equal(t, []string{outport.TopicSettings}, getPendingTopics(j))
+	require.Nil(t, j.Close())
+}
+
+func TestJournal_ReopenWithCorruptedRecordShouldErrorAndLeaveTheFileUntouched(t *testing.T) {
+	t.Parallel()
+
+	createCorruptedJournal := func(t *testing.T, offsetFromEnd int64) string {
+		filePath := filepath.Join(t.TempDir(), "journal")
+		j, _ := NewJournal(filePath, false)
+		_, _ = j.Append(&PayloadData{Topic: outport.TopicSaveBlock, Payload: []byte("block")})
+		_, _ = j.Append(&PayloadData{Topic: outport.TopicFinalizedBlock, Payload: []byte("finalized")})
+		require.Nil(t, j.Close())
+
+		content, _ := os.ReadFile(filePath)
+		content[int64(len(content))-offsetFromEnd] ^= 0xFF
+		require.Nil(t, os.WriteFile(filePath, content, 0644))
+
+		return filePath
+	}
+
+	t.Run("corrupted record in the middle", func(t *testing.T) {
+		t.Parallel()
+
+		filePath := createCorruptedJournal(t, 60)
+		contentBefore, _ := os.ReadFile(filePath)
+
+		j, err := NewJournal(filePath, false)
+		assert.True(t, errors.Is(err, ErrCorruptedJournalRecord))
+		assert.True(t, check.IfNil(j))
+
+		contentAfter, _ := os.ReadFile(filePath)
+		assert.Equal(t, contentBefore, contentAfter)
+	})
+	t.Run("corrupted complete last record", func(t *testing.T) {
+		t.Parallel()
+
+		filePath := createCorruptedJournal(t, 6)
+		contentBefore, _ := os.ReadFile(filePath)
+
+		j, err := NewJournal(filePath, false)
+		assert.True(t, errors.Is(err, ErrCorruptedJournalRecord))
+		assert.True(t, check.IfNil(j))
+
+		contentAfter, _ := os.ReadFile(filePath)
+		assert.Equal(t, contentBefore, contentAfter)
+	})
+}
+
+func TestJournal_ReopenShouldDiscardTornHeader(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), "journal")
+	j, _ := NewJournal(filePath, false)
+	_, _ = j.Append(&PayloadData{Topic: outport.TopicSaveBlock})
+	require.Nil(t, j.Close())
+
+	info, _ := os.Stat(filePath)
+	file, _ := os.OpenFile(filePath, os.O_WRONLY|os.O_APPEND, 0644)
+	_, _ = file.Write(encodeRecord(entryRecord, 2, time.Now(), []byte("body"))[:recordHeaderSize])
+	require.Nil(t, file.Close())
+
+	j, err := NewJournal(filePath, false)
+	require.Nil(t, err)
+	assert.Equal(t, []string{outport.TopicSaveBlock}, getPendingTopics(j))
+	require.Nil(t, j.Close())
+
+	infoAfter, _ := os.Stat(filePath)
+	assert.Equal(t, info.Size(), infoAfter.Size())
+}
+
+func TestJournal_AckShouldCompactWhenAcknowledgedRecordsDominate(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), "journal")
+	j, _ := NewJournal(filePath, false)
+	numEntries := 100
+	for i := 0; i < numEntries; i++ {
+		_, _ = j.Append(&PayloadData{Topic: outport.TopicSaveBlock, Payload: []byte("block")})
+	}
+	info, _ := os.Stat(filePath)
+	recordSize := info.Size() / int64(numEntries)
+
+	// each ack makes obsolete both the entry and the ack record, so 33 acks leave 66 obsolete records for 67 pending
+	numAcks := 33
+	for i := 1; i <= numAcks; i++ {
+		require.Nil(t, j.Ack(uint64(i)))
+	}
+	info, _ = os.Stat(filePath)
+	assert.Greater(t, info.Size(), int64(numEntries)*recordSize, "acknowledged records do not dominate yet")
+
+	// from now on, the acknowledged entries and their ack records outnumber the pending entries
+	require.Nil(t, j.Ack(uint64(numAcks+1)))
+	numPending := numEntries - numAcks - 1
+	info, _ = os.Stat(filePath)
+	assert.Equal(t, int64(numPending)*recordSize, info.Size())
+	_, err := os.Stat(filePath + compactedFileSuffix)
+	assert.True(t, os.IsNotExist(err))
+
+	sequence, err := j.Append(&PayloadData{Topic: outport.TopicSettings})
+	require.Nil(t, err)
+	assert.Equal(t, uint64(numEntries+1), sequence)
+	require.Nil(t, j.Close())
+
+	j, err = NewJournal(filePath, false)
+	require.Nil(t, err)
+	pending := j.Pending()
+	require.Len(t, pending, numPending+1)
+	assert.Equal(t, uint64(numAcks+2), pending[0].Sequence)
+	assert.Equal(t, outport.TopicSettings, pending[numPending].PayloadData.Topic)
+	assert.Equal(t, uint64(numEntries+1), j.LastSequence())
+	require.Nil(t, j.Close())
+}
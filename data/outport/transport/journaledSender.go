@@ -0,0 +1,187 @@
+package transport
+
+import (
+	"sync"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+)
+
+const (
+	// MetricOutportPendingPayloads is the metric holding the number of journaled payloads not yet acknowledged
+	MetricOutportPendingPayloads = "erd_outport_pending_payloads"
+	// MetricOutportOldestPendingAgeMs is the metric holding the age, in milliseconds, of the oldest pending payload
+	MetricOutportOldestPendingAgeMs = "erd_outport_oldest_pending_age_ms"
+	// MetricOutportLastAppendedSequence is the metric holding the sequence number of the last journaled payload
+	MetricOutportLastAppendedSequence = "erd_outport_last_appended_sequence"
+	// MetricOutportLastAckedSequence is the metric holding the sequence number of the last acknowledged payload
+	MetricOutportLastAckedSequence = "erd_outport_last_acked_sequence"
+)
+
+// LagMetrics holds the delivery lag of a journaled sender
+type LagMetrics struct {
+	PendingPayloads       uint64
+	OldestPendingAge      time.Duration
+	LastAppendedSequence  uint64
+	LastAckedSequence     uint64
+	ReplayedPayloadsCount uint64
+	DroppedPayloadsCount  uint64
+}
+
+// ArgsJournaledSender holds the arguments needed to create a new journaled sender
+type ArgsJournaledSender struct {
+	Sender           PayloadSender
+	Journal          Journal
+	AppStatusHandler core.AppStatusHandler
+	Log              core.Logger
+}
+
+// journaledSender provides at-least-once delivery on top of a payload sender: every payload is written to the
+// journal before being sent and is removed from it only after the receiver acknowledged it. The pending payloads
+// are always delivered in order, before any newer payload. A pending payload that can never be delivered is dropped,
+// so it does not block the payloads appended after it
+type journaledSender struct {
+	sender           PayloadSender
+	journal          Journal
+	appStatusHandler core.AppStatusHandler
+	log              core.Logger
+
+	mut             sync.Mutex
+	replayedCounter uint64
+	droppedCounter  uint64
+}
+
+// NewJournaledSender creates a new journaled sender. Entries left pending by a previous run are delivered on the
+// first Send call, or explicitly by calling ReplayPending
+func NewJournaledSender(args ArgsJournaledSender) (*journaledSender, error) {
+	if check.IfNil(args.Sender) {
+		return nil, ErrNilPayloadSender
+	}
+	if check.IfNil(args.Journal) {
+		return nil, ErrNilJournal
+	}
+	if check.IfNil(args.AppStatusHandler) {
+		return nil, ErrNilAppStatusHandler
+	}
+	if check.IfNil(args.Log) {
+		return nil, core.ErrNilLogger
+	}
+
+	js := &journaledSender{
+		sender:           args.Sender,
+		journal:          args.Journal,
+		appStatusHandler: args.AppStatusHandler,
+		log:              args.Log,
+	}
+	js.updateMetrics()
+
+	return js, nil
+}
+
+// Send journals the provided payload and then delivers all the pending payloads, in order. An invalid or too large
+// payload is rejected without being journaled. If the delivery fails, the payloads remain in the journal
+// and are retried on the next call
+func (js *journaledSender) Send(payloadData *PayloadData) error {
+	err := checkPayloadDataToSend(payloadData, MaxFrameSize)
+	if err != nil {
+		return err
+	}
+
+	js.mut.Lock()
+	defer js.mut.Unlock()
+
+	_, err = js.journal.Append(payloadData)
+	if err != nil {
+		return err
+	}
+
+	return js.deliverPending(false)
+}
+
+// ReplayPending delivers, in order, the payloads left unacknowledged in the journal
+func (js *journaledSender) ReplayPending() error {
+	js.mut.Lock()
+	defer js.mut.Unlock()
+
+	return js.deliverPending(true)
+}
+
+func (js *journaledSender) deliverPending(isReplay bool) error {
+	defer js.updateMetrics()
+
+	for _, entry := range js.journal.Pending() {
+		err := js.sender.Send(entry.PayloadData)
+		if err != nil && !isPermanentSendError(err) {
+			return err
+		}
+		if err != nil {
+			js.log.Warn("journaledSender: dropping payload that can not be delivered",
+				"sequence", entry.Sequence, "topic", entry.PayloadData.Topic, "error", err)
+			js.droppedCounter++
+		}
+
+		errAck := js.journal.Ack(entry.Sequence)
+		if errAck != nil {
+			return errAck
+		}
+		if isReplay && err == nil {
+			js.replayedCounter++
+		}
+	}
+
+	return nil
+}
+
+func (js *journaledSender) updateMetrics() {
+	metrics := js.computeLagMetrics()
+
+	js.appStatusHandler.SetUInt64Value(MetricOutportPendingPayloads, metrics.PendingPayloads)
+	js.appStatusHandler.SetUInt64Value(MetricOutportOldestPendingAgeMs, uint64(metrics.OldestPendingAge.Milliseconds()))
+	js.appStatusHandler.SetUInt64Value(MetricOutportLastAppendedSequence, metrics.LastAppendedSequence)
+	js.appStatusHandler.SetUInt64Value(MetricOutportLastAckedSequence, metrics.LastAckedSequence)
+}
+
+func (js *journaledSender) computeLagMetrics() LagMetrics {
+	pending := js.journal.Pending()
+	metrics := LagMetrics{
+		PendingPayloads:       uint64(len(pending)),
+		LastAppendedSequence:  js.journal.LastSequence(),
+		LastAckedSequence:     js.journal.LastAckedSequence(),
+		ReplayedPayloadsCount: js.replayedCounter,
+		DroppedPayloadsCount:  js.droppedCounter,
+	}
+	if len(pending) > 0 {
+		metrics.OldestPendingAge = time.Since(pending[0].AppendedAt)
+	}
+
+	return metrics
+}
+
+// GetLagMetrics returns the current delivery lag
+func (js *journaledSender) GetLagMetrics() LagMetrics {
+	js.mut.Lock()
+	defer js.mut.Unlock()
+
+	return js.computeLagMetrics()
+}
+
+// Close closes the underlying sender and the journal
+func (js *journaledSender) Close() error {
+	errSender := js.sender.Close()
+
+	js.mut.Lock()
+	defer js.mut.Unlock()
+
+	errJournal := js.journal.Close()
+	if errSender != nil {
+		return errSender
+	}
+
+	return errJournal
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (js *journaledSender) IsInterfaceNil() bool {
+	return js == nil
+}
@@ -0,0 +1,295 @@
+package transport
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/core/mock"
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type payloadSenderStub struct {
+	SendCalled  func(payloadData *PayloadData) error
+	CloseCalled func() error
+}
+
+func (pss *payloadSenderStub) Send(payloadData *PayloadData) error {
+	if pss.SendCalled != nil {
+		return pss.SendCalled(payloadData)
+	}
+
+	return nil
+}
+
+func (pss *payloadSenderStub) Close() error {
+	if pss.CloseCalled != nil {
+		return pss.CloseCalled()
+	}
+
+	return nil
+}
+
+func (pss *payloadSenderStub) IsInterfaceNil() bool {
+	return pss == nil
+}
+
+type metricsCollector struct {
+	mut     sync.Mutex
+	metrics map[string]uint64
+}
+
+func newMetricsCollector() (*metricsCollector, *mock.AppStatusHandlerStub) {
+	collector := &metricsCollector{metrics: make(map[string]uint64)}
+	stub := &mock.AppStatusHandlerStub{
+		SetUInt64ValueHandler: func(key string, value uint64) {
+			collector.mut.Lock()
+			collector.metrics[key] = value
+			collector.mut.Unlock()
+		},
+	}
+
+	return collector, stub
+}
+
+func (mc *metricsCollector) get(key string) uint64 {
+	mc.mut.Lock()
+	defer mc.mut.Unlock()
+
+	return mc.metrics[key]
+}
+
+func createMockArgsJournaledSender(t *testing.T) ArgsJournaledSender {
+	j, err := NewJournal(filepath.Join(t.TempDir(), "journal"), false)
+	require.Nil(t, err)
+
+	return ArgsJournaledSender{
+		Sender:           &payloadSenderStub{},
+		Journal:          j,
+		AppStatusHandler: &mock.AppStatusHandlerStub{SetUInt64ValueHandler: func(key string, value uint64) {}},
+		Log:              &mock.LoggerMock{},
+	}
+}
+
+func TestNewJournaledSender(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsJournaledSender(t)
+	args.Sender = nil
+	js, err := NewJournaledSender(args)
+	assert.Equal(t, ErrNilPayloadSender, err)
+	assert.True(t, check.IfNil(js))
+
+	args = createMockArgsJournaledSender(t)
+	args.Journal = nil
+	_, err = NewJournaledSender(args)
+	assert.Equal(t, ErrNilJournal, err)
+
+	args = createMockArgsJournaledSender(t)
+	args.AppStatusHandler = nil
+	_, err = NewJournaledSender(args)
+	assert.Equal(t, ErrNilAppStatusHandler, err)
+
+	args = createMockArgsJournaledSender(t)
+	args.Log = nil
+	_, err = NewJournaledSender(args)
+	assert.Equal(t, core.ErrNilLogger, err)
+
+	js, err = NewJournaledSender(createMockArgsJournaledSender(t))
+	require.Nil(t, err)
+	assert.False(t, check.IfNil(js))
+	assert.Nil(t, js.Close())
+}
+
+func TestJournaledSender_Send(t *testing.T) {
+	t.Parallel()
+
+	t.Run("failed delivery should keep the payloads pending and retry them in order", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("receiver down")
+		sendErr := expectedErr
+		sentTopics := make([]string, 0)
+		args := createMockArgsJournaledSender(t)
+		args.Sender = &payloadSenderStub{
+			SendCalled: func(payloadData *PayloadData) error {
+				if sendErr != nil {
+					return sendErr
+				}
+				sentTopics = append(sentTopics, payloadData.Topic)
+				return nil
+			},
+		}
+		collector, statusHandler := newMetricsCollector()
+		args.AppStatusHandler = statusHandler
+		js, _ := NewJournaledSender(args)
+
+		err := js.Send(&PayloadData{Topic: outport.TopicSaveBlock})
+		assert.Equal(t, expectedErr, err)
+		err = js.Send(&PayloadData{Topic: outport.TopicFinalizedBlock})
+		assert.Equal(t, expectedErr, err)
+
+		metrics := js.GetLagMetrics()
+		assert.Equal(t, uint64(2), metrics.PendingPayloads)
+		assert.Equal(t, uint64(2), metrics.LastAppendedSequence)
+		assert.Zero(t, metrics.LastAckedSequence)
+		assert.Equal(t, uint64(2), collector.get(MetricOutportPendingPayloads))
+
+		sendErr = nil
+		require.Nil(t, js.Send(&PayloadData{Topic: outport.TopicSettings}))
+		assert.Equal(t, []string{outport.TopicSaveBlock, outport.TopicFinalizedBlock, outport.TopicSettings}, sentTopics)
+
+		metrics = js.GetLagMetrics()
+		assert.Zero(t, metrics.PendingPayloads)
+		assert.Zero(t, metrics.OldestPendingAge)
+		assert.Equal(t, uint64(3), metrics.LastAckedSequence)
+		assert.Zero(t, collector.get(MetricOutportPendingPayloads))
+		assert.Equal(t, uint64(3), collector.get(MetricOutportLastAckedSequence))
+	})
+	t.Run("invalid payload should error", func(t *testing.T) {
+		t.Parallel()
+
+		js, _ := NewJournaledSender(createMockArgsJournaledSender(t))
+		assert.Equal(t, ErrNilPayloadData, js.Send(nil))
+		assert.Equal(t, ErrEmptyTopic, js.Send(&PayloadData{}))
+		assert.Zero(t, js.GetLagMetrics().LastAppendedSequence)
+	})
+	t.Run("too large payload should error without blocking the next payloads", func(t *testing.T) {
+		t.Parallel()
+
+		filePath := filepath.Join(t.TempDir(), "journal")
+		sentTopics := make([]string, 0)
+		args := createMockArgsJournaledSender(t)
+		args.Journal, _ = NewJournal(filePath, false)
+		args.Sender = &payloadSenderStub{
+			SendCalled: func(payloadData *PayloadData) error {
+				sentTopics = append(sentTopics, payloadData.Topic)
+				return nil
+			},
+		}
+		js, _ := NewJournaledSender(args)
+
+		err := js.Send(&PayloadData{Topic: outport.TopicSaveBlock, Payload: make([]byte, MaxFrameSize)})
+		assert.True(t, errors.Is(err, ErrFrameTooLarge))
+		require.Nil(t, js.Send(&PayloadData{Topic: outport.TopicFinalizedBlock}))
+		assert.Equal(t, []string{outport.TopicFinalizedBlock}, sentTopics)
+		assert.Equal(t, uint64(1), js.GetLagMetrics().LastAppendedSequence)
+		require.Nil(t, js.Close())
+
+		j, err := NewJournal(filePath, false)
+		require.Nil(t, err)
+		assert.Empty(t, j.Pending())
+		require.Nil(t, j.Close())
+	})
+	t.Run("payload failing permanently should be dropped", func(t *testing.T) {
+		t.Parallel()
+
+		filePath := filepath.Join(t.TempDir(), "journal")
+		sentTopics := make([]string, 0)
+		args := createMockArgsJournaledSender(t)
+		args.Journal, _ = NewJournal(filePath, false)
+		args.Sender = &payloadSenderStub{
+			SendCalled: func(payloadData *PayloadData) error {
+				if payloadData.Topic == outport.TopicSaveBlock {
+					return ErrFrameTooLarge
+				}
+				sentTopics = append(sentTopics, payloadData.Topic)
+				return nil
+			},
+		}
+		js, _ := NewJournaledSender(args)
+
+		require.Nil(t, js.Send(&PayloadData{Topic: outport.TopicSaveBlock}))
+		require.Nil(t, js.Send(&PayloadData{Topic: outport.TopicFinalizedBlock}))
+		assert.Equal(t, []string{outport.TopicFinalizedBlock}, sentTopics)
+
+		metrics := js.GetLagMetrics()
+		assert.Equal(t, uint64(1), metrics.DroppedPayloadsCount)
+		assert.Zero(t, metrics.PendingPayloads)
+		require.Nil(t, js.Close())
+
+		j, err := NewJournal(filePath, false)
+		require.Nil(t, err)
+		assert.Empty(t, j.Pending())
+		require.Nil(t, j.Close())
+	})
+}
+
+func TestJournaledSender_ReplayPendingAfterRestart(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), "journal")
+	j, _ := NewJournal(filePath, true)
+	args := ArgsJournaledSender{
+		Sender: &payloadSenderStub{
+			SendCalled: func(payloadData *PayloadData) error {
+				return ErrSenderClosed
+			},
+		},
+		Journal:          j,
+		AppStatusHandler: &mock.AppStatusHandlerStub{SetUInt64ValueHandler: func(key string, value uint64) {}},
+		Log:              &mock.LoggerMock{},
+	}
+	js, _ := NewJournaledSender(args)
+	_ = js.Send(&PayloadData{Topic: outport.TopicSaveBlock})
+	_ = js.Send(&PayloadData{Topic: outport.TopicFinalizedBlock})
+	require.Nil(t, js.Close())
+
+	sentTopics := make([]string, 0)
+	args.Sender = &payloadSenderStub{
+		SendCalled: func(payloadData *PayloadData) error {
+			sentTopics = append(sentTopics, payloadData.Topic)
+			return nil
+		},
+	}
+	args.Journal, _ = NewJournal(filePath, true)
+	collector, statusHandler := newMetricsCollector()
+	args.AppStatusHandler = statusHandler
+	js, _ = NewJournaledSender(args)
+	assert.Equal(t, uint64(2), collector.get(MetricOutportPendingPayloads))
+
+	require.Nil(t, js.ReplayPending())
+	assert.Equal(t, []string{outport.TopicSaveBlock, outport.TopicFinalizedBlock}, sentTopics)
+
+	metrics := js.GetLagMetrics()
+	assert.Equal(t, uint64(2), metrics.ReplayedPayloadsCount)
+	assert.Zero(t, metrics.PendingPayloads)
+	assert.Zero(t, collector.get(MetricOutportPendingPayloads))
+	require.Nil(t, js.Close())
+}
+
+func TestJournaledSender_DeliveryOverTransport(t *testing.T) {
+	t.Parallel()
+
+	collector := &payloadsCollector{}
+	dedupHandler, _ := NewDeduplicatingPayloadHandler(ArgsDeduplicatingPayloadHandler{
+		PayloadHandler: collector,
+		Capacity:       10,
+	})
+	r, err := NewReceiver(createMockArgsReceiver(dedupHandler))
+	require.Nil(t, err)
+	defer func() {
+		_ = r.Close()
+	}()
+
+	s, _ := NewSender(createMockArgsSender(r.Addr().String()))
+	args := createMockArgsJournaledSender(t)
+	args.Sender = s
+	js, _ := NewJournaledSender(args)
+	defer func() {
+		_ = js.Close()
+	}()
+
+	saveBlock := createSaveBlockPayload(t, 1, []byte("hash"))
+	require.Nil(t, js.Send(saveBlock))
+	require.Nil(t, js.Send(saveBlock))
+	require.Nil(t, js.Send(&PayloadData{Topic: outport.TopicSettings}))
+
+	assert.Equal(t, []string{outport.TopicSaveBlock, outport.TopicSettings}, collector.getTopics())
+	assert.Zero(t, js.GetLagMetrics().PendingPayloads)
+}
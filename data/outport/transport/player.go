@@ -0,0 +1,138 @@
+package transport
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+)
+
+// ArgsPlayer holds the arguments needed to create a new player
+type ArgsPlayer struct {
+	FilePath       string
+	PayloadHandler PayloadHandler
+	// SpeedFactor scales the recorded delays between the payloads: 1 keeps the original timing, 2 replays twice as
+	// fast, while 0 replays all the payloads without any delay
+	SpeedFactor float64
+}
+
+// player re-emits the payloads stored in an archive file to a payload handler, in the recorded order
+type player struct {
+	filePath       string
+	payloadHandler PayloadHandler
+	speedFactor    float64
+}
+
+// NewPlayer creates a new player. The archive header is checked right away
+func NewPlayer(args ArgsPlayer) (*player, error) {
+	if len(args.FilePath) == 0 {
+		return nil, ErrEmptyFilePath
+	}
+	if check.IfNil(args.PayloadHandler) {
+		return nil, ErrNilPayloadHandler
+	}
+	if args.SpeedFactor < 0 {
+		return nil, fmt.Errorf("%w, %v", ErrInvalidSpeedFactor, args.SpeedFactor)
+	}
+
+	file, err := os.Open(args.FilePath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	err = readArchiveHeader(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return &player{
+		filePath:       args.FilePath,
+		payloadHandler: args.PayloadHandler,
+		speedFactor:    args.SpeedFactor,
+	}, nil
+}
+
+// Play re-emits the archived payloads and returns the number of payloads processed by the handler. It stops at the
+// first processing error or when the provided context is done. The archive is re-read on each call
+func (p *player) Play(ctx context.Context) (int, error) {
+	file, err := os.Open(p.filePath)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	reader := bufio.NewReader(file)
+	err = readArchiveHeader(reader)
+	if err != nil {
+		return 0, err
+	}
+
+	numPlayed := 0
+	var firstRecordedAt time.Time
+	startTime := time.Now()
+	for {
+		record, errRead := readArchiveRecord(reader)
+		if errors.Is(errRead, io.EOF) {
+			return numPlayed, nil
+		}
+		if errRead != nil {
+			return numPlayed, errRead
+		}
+
+		if numPlayed == 0 {
+			firstRecordedAt = record.RecordedAt
+		}
+		err = p.waitRecordTime(ctx, startTime, record.RecordedAt.Sub(firstRecordedAt))
+		if err != nil {
+			return numPlayed, err
+		}
+
+		err = p.payloadHandler.ProcessPayload(record.PayloadData)
+		if err != nil {
+			return numPlayed, err
+		}
+		numPlayed++
+	}
+}
+
+// waitRecordTime waits until the scaled offset of the record, measured from the playing start, is reached. Waiting
+// relative to the start avoids accumulating the processing time of the previous payloads as extra delays
+func (p *player) waitRecordTime(ctx context.Context, startTime time.Time, recordOffset time.Duration) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if p.speedFactor == 0 || recordOffset <= 0 {
+		return nil
+	}
+
+	scaledOffset := time.Duration(float64(recordOffset) / p.speedFactor)
+	delay := time.Until(startTime.Add(scaledOffset))
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (p *player) IsInterfaceNil() bool {
+	return p == nil
+}
@@ -0,0 +1,144 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var playedTopics = []string{outport.TopicSaveBlock, outport.TopicSaveRoundsInfo, outport.TopicFinalizedBlock}
+
+// createRecordedArchive records the played topics, 100ms apart from each other
+func createRecordedArchive(t *testing.T) string {
+	filePath := filepath.Join(t.TempDir(), "archive")
+	r, err := NewRecorder(ArgsRecorder{FilePath: filePath})
+	require.Nil(t, err)
+
+	recordedAt := time.Now()
+	r.getTimeHandler = func() time.Time {
+		recordedAt = recordedAt.Add(time.Millisecond * 100)
+		return recordedAt
+	}
+	for _, topic := range playedTopics {
+		require.Nil(t, r.ProcessPayload(&PayloadData{Topic: topic, Payload: []byte(topic)}))
+	}
+	require.Nil(t, r.Close())
+
+	return filePath
+}
+
+func TestNewPlayer(t *testing.T) {
+	t.Parallel()
+
+	filePath := createRecordedArchive(t)
+
+	p, err := NewPlayer(ArgsPlayer{PayloadHandler: &payloadsCollector{}})
+	assert.Equal(t, ErrEmptyFilePath, err)
+	assert.True(t, check.IfNil(p))
+
+	_, err = NewPlayer(ArgsPlayer{FilePath: filePath})
+	assert.Equal(t, ErrNilPayloadHandler, err)
+
+	_, err = NewPlayer(ArgsPlayer{FilePath: filePath, PayloadHandler: &payloadsCollector{}, SpeedFactor: -1})
+	assert.True(t, errors.Is(err, ErrInvalidSpeedFactor))
+
+	_, err = NewPlayer(ArgsPlayer{FilePath: filepath.Join(t.TempDir(), "missing"), PayloadHandler: &payloadsCollector{}})
+	assert.NotNil(t, err)
+
+	invalidFilePath := filepath.Join(t.TempDir(), "invalid")
+	require.Nil(t, os.WriteFile(invalidFilePath, []byte("invalid archive"), 0644))
+	_, err = NewPlayer(ArgsPlayer{FilePath: invalidFilePath, PayloadHandler: &payloadsCollector{}})
+	assert.True(t, errors.Is(err, ErrInvalidArchive))
+
+	p, err = NewPlayer(ArgsPlayer{FilePath: filePath, PayloadHandler: &payloadsCollector{}})
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(p))
+}
+
+func TestPlayer_Play(t *testing.T) {
+	t.Parallel()
+
+	t.Run("without delays should re-emit all the payloads in order", func(t *testing.T) {
+		t.Parallel()
+
+		collector := &payloadsCollector{}
+		p, _ := NewPlayer(ArgsPlayer{FilePath: createRecordedArchive(t), PayloadHandler: collector})
+
+		startTime := time.Now()
+		numPlayed, err := p.Play(context.Background())
+		require.Nil(t, err)
+		assert.Equal(t, len(playedTopics), numPlayed)
+		assert.Equal(t, playedTopics, collector.getTopics())
+		assert.Less(t, time.Since(startTime), time.Millisecond*100)
+
+		numPlayed, _ = p.Play(context.Background())
+		assert.Equal(t, len(playedTopics), numPlayed)
+		assert.Len(t, collector.getTopics(), 2*len(playedTopics))
+	})
+	t.Run("original timing should keep the recorded delays", func(t *testing.T) {
+		t.Parallel()
+
+		collector := &payloadsCollector{}
+		p, _ := NewPlayer(ArgsPlayer{FilePath: createRecordedArchive(t), PayloadHandler: collector, SpeedFactor: 1})
+
+		startTime := time.Now()
+		_, err := p.Play(context.Background())
+		require.Nil(t, err)
+		assert.GreaterOrEqual(t, time.Since(startTime), time.Millisecond*200)
+		assert.Equal(t, playedTopics, collector.getTopics())
+	})
+	t.Run("accelerated timing should scale the recorded delays", func(t *testing.T) {
+		t.Parallel()
+
+		p, _ := NewPlayer(ArgsPlayer{FilePath: createRecordedArchive(t), PayloadHandler: &payloadsCollector{}, SpeedFactor: 4})
+
+		startTime := time.Now()
+		_, err := p.Play(context.Background())
+		require.Nil(t, err)
+		elapsed := time.Since(startTime)
+		assert.GreaterOrEqual(t, elapsed, time.Millisecond*50)
+		assert.Less(t, elapsed, time.Millisecond*200)
+	})
+	t.Run("handler error should stop playing", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		p, _ := NewPlayer(ArgsPlayer{
+			FilePath: createRecordedArchive(t),
+			PayloadHandler: &payloadHandlerStub{
+				ProcessPayloadCalled: func(payloadData *PayloadData) error {
+					if payloadData.Topic == outport.TopicSaveRoundsInfo {
+						return expectedErr
+					}
+					return nil
+				},
+			},
+		})
+
+		numPlayed, err := p.Play(context.Background())
+		assert.Equal(t, expectedErr, err)
+		assert.Equal(t, 1, numPlayed)
+	})
+	t.Run("done context should stop playing", func(t *testing.T) {
+		t.Parallel()
+
+		collector := &payloadsCollector{}
+		p, _ := NewPlayer(ArgsPlayer{FilePath: createRecordedArchive(t), PayloadHandler: collector, SpeedFactor: 1})
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+		defer cancel()
+
+		numPlayed, err := p.Play(ctx)
+		assert.Equal(t, context.DeadlineExceeded, err)
+		assert.Equal(t, 1, numPlayed)
+		assert.Equal(t, []string{outport.TopicSaveBlock}, collector.getTopics())
+	})
+}
@@ -0,0 +1,167 @@
+package transport
+
+import (
+	"errors"
+	"net"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+)
+
+// ArgsReceiver holds the arguments needed to create a new receiver
+type ArgsReceiver struct {
+	Network        string
+	Address        string
+	PayloadHandler PayloadHandler
+	Log            core.Logger
+}
+
+// receiver listens on a local socket and passes every received payload to the payload handler, answering each of
+// them with an ack. Each accepted connection is served on its own go routine
+type receiver struct {
+	listener       net.Listener
+	payloadHandler PayloadHandler
+	log            core.Logger
+
+	mutConnections sync.Mutex
+	connections    map[net.Conn]struct{}
+	closed         bool
+	wg             sync.WaitGroup
+}
+
+// NewReceiver creates a new receiver which starts listening on the provided address
+func NewReceiver(args ArgsReceiver) (*receiver, error) {
+	err := checkNetworkAndAddress(args.Network, args.Address)
+	if err != nil {
+		return nil, err
+	}
+	if check.IfNil(args.PayloadHandler) {
+		return nil, ErrNilPayloadHandler
+	}
+	if check.IfNil(args.Log) {
+		return nil, core.ErrNilLogger
+	}
+
+	listener, err := net.Listen(args.Network, args.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &receiver{
+		listener:       listener,
+		payloadHandler: args.PayloadHandler,
+		log:            args.Log,
+		connections:    make(map[net.Conn]struct{}),
+	}
+
+	r.wg.Add(1)
+	go r.acceptConnections()
+
+	return r, nil
+}
+
+func (r *receiver) acceptConnections() {
+	defer r.wg.Done()
+
+	for {
+		conn, err := r.listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				r.log.Warn("receiver: cannot accept connection", "error", err)
+			}
+			return
+		}
+
+		if !r.addConnection(conn) {
+			_ = conn.Close()
+			return
+		}
+
+		r.wg.Add(1)
+		go r.serveConnection(conn)
+	}
+}
+
+func (r *receiver) addConnection(conn net.Conn) bool {
+	r.mutConnections.Lock()
+	defer r.mutConnections.Unlock()
+
+	if r.closed {
+		return false
+	}
+	r.connections[conn] = struct{}{}
+
+	return true
+}
+
+func (r *receiver) serveConnection(conn net.Conn) {
+	defer func() {
+		r.mutConnections.Lock()
+		delete(r.connections, conn)
+		r.mutConnections.Unlock()
+
+		_ = conn.Close()
+		r.wg.Done()
+	}()
+
+	for {
+		counter, payloadData, err := readPayloadFrame(conn)
+		if err != nil {
+			r.log.Debug("receiver: connection closed", "remote", conn.RemoteAddr().String(), "reason", err)
+			return
+		}
+
+		ack := &AckData{
+			Counter: counter,
+			StatusInfo: outport.StatusInfo{
+				CompletedEvent: true,
+				Status:         AckStatusProcessed,
+			},
+		}
+		err = r.payloadHandler.ProcessPayload(payloadData)
+		if err != nil {
+			r.log.Warn("receiver: cannot process payload", "topic", payloadData.Topic, "error", err)
+			ack.StatusInfo = outport.StatusInfo{
+				ErrorEvent: true,
+				Status:     err.Error(),
+			}
+		}
+
+		err = writeAckFrame(conn, ack)
+		if err != nil {
+			r.log.Debug("receiver: cannot send ack", "remote", conn.RemoteAddr().String(), "error", err)
+			return
+		}
+	}
+}
+
+// Addr returns the address the receiver listens on
+func (r *receiver) Addr() net.Addr {
+	return r.listener.Addr()
+}
+
+// Close stops listening, closes all the opened connections and waits for their go routines to finish
+func (r *receiver) Close() error {
+	r.mutConnections.Lock()
+	if r.closed {
+		r.mutConnections.Unlock()
+		return nil
+	}
+	r.closed = true
+	err := r.listener.Close()
+	for conn := range r.connections {
+		_ = conn.Close()
+	}
+	r.mutConnections.Unlock()
+
+	r.wg.Wait()
+
+	return err
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (r *receiver) IsInterfaceNil() bool {
+	return r == nil
+}
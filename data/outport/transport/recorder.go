@@ -0,0 +1,115 @@
+package transport
+
+import (
+	"bufio"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+)
+
+// ArgsRecorder holds the arguments needed to create a new recorder
+type ArgsRecorder struct {
+	FilePath string
+	// PayloadHandler is optional, when provided the recorded payloads are forwarded to it
+	PayloadHandler PayloadHandler
+}
+
+// recorder captures the received payloads, of any topic, into an archive file. It is a payload handler, so it can
+// be plugged in a receiver directly or in front of another payload handler
+type recorder struct {
+	payloadHandler PayloadHandler
+	getTimeHandler func() time.Time
+
+	mut      sync.Mutex
+	file     *os.File
+	writer   *bufio.Writer
+	isClosed bool
+}
+
+// NewRecorder creates a new recorder, writing to the provided file. An existing file is overwritten
+func NewRecorder(args ArgsRecorder) (*recorder, error) {
+	if len(args.FilePath) == 0 {
+		return nil, ErrEmptyFilePath
+	}
+
+	file, err := os.Create(args.FilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	writer := bufio.NewWriter(file)
+	err = writeArchiveHeader(writer)
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	return &recorder{
+		payloadHandler: args.PayloadHandler,
+		getTimeHandler: time.Now,
+		file:           file,
+		writer:         writer,
+	}, nil
+}
+
+// ProcessPayload records the provided payload and then forwards it to the payload handler, if any
+func (r *recorder) ProcessPayload(payloadData *PayloadData) error {
+	err := checkPayloadData(payloadData)
+	if err != nil {
+		return err
+	}
+
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	if r.isClosed {
+		return ErrRecorderClosed
+	}
+
+	err = writeArchiveRecord(r.writer, &ArchiveRecord{
+		RecordedAt:  r.getTimeHandler(),
+		PayloadData: payloadData,
+	})
+	if err != nil {
+		return err
+	}
+	err = r.writer.Flush()
+	if err != nil {
+		return err
+	}
+
+	if check.IfNil(r.payloadHandler) {
+		return nil
+	}
+
+	return r.payloadHandler.ProcessPayload(payloadData)
+}
+
+// Close flushes and closes the archive file
+func (r *recorder) Close() error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	if r.isClosed {
+		return nil
+	}
+	r.isClosed = true
+
+	err := r.writer.Flush()
+	errClose := r.file.Close()
+	if err != nil {
+		return err
+	}
+
+	return errClose
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (r *recorder) IsInterfaceNil() bool {
+	return r == nil
+}
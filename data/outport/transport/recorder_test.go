@@ -0,0 +1,154 @@
+package transport
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readArchiveFile(t *testing.T, filePath string) ([]*ArchiveRecord, error) {
+	file, err := os.Open(filePath)
+	require.Nil(t, err)
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return ReadArchive(file)
+}
+
+func TestNewRecorder(t *testing.T) {
+	t.Parallel()
+
+	r, err := NewRecorder(ArgsRecorder{})
+	assert.Equal(t, ErrEmptyFilePath, err)
+	assert.True(t, check.IfNil(r))
+
+	_, err = NewRecorder(ArgsRecorder{FilePath: filepath.Join(t.TempDir(), "missing", "archive")})
+	assert.NotNil(t, err)
+
+	r, err = NewRecorder(ArgsRecorder{FilePath: filepath.Join(t.TempDir(), "archive")})
+	require.Nil(t, err)
+	assert.False(t, check.IfNil(r))
+	assert.Nil(t, r.Close())
+	assert.Nil(t, r.Close())
+}
+
+func TestRecorder_ProcessPayload(t *testing.T) {
+	t.Parallel()
+
+	t.Run("payloads should be recorded and forwarded", func(t *testing.T) {
+		t.Parallel()
+
+		collector := &payloadsCollector{}
+		filePath := filepath.Join(t.TempDir(), "archive")
+		r, _ := NewRecorder(ArgsRecorder{FilePath: filePath, PayloadHandler: collector})
+
+		startTime := time.Unix(1700000000, 0)
+		r.getTimeHandler = func() time.Time {
+			startTime = startTime.Add(time.Second)
+			return startTime
+		}
+
+		assert.Equal(t, ErrNilPayloadData, r.ProcessPayload(nil))
+		topics := []string{outport.TopicSaveBlock, outport.TopicSaveRoundsInfo, outport.TopicSaveValidatorsRating, outport.TopicFinalizedBlock}
+		for _, topic := range topics {
+			require.Nil(t, r.ProcessPayload(&PayloadData{Topic: topic, MarshallerType: "json", Payload: []byte(topic)}))
+		}
+		assert.Equal(t, topics, collector.getTopics())
+
+		records, err := readArchiveFile(t, filePath)
+		require.Nil(t, err)
+		require.Len(t, records, len(topics))
+		for i, record := range records {
+			assert.Equal(t, &PayloadData{Topic: topics[i], MarshallerType: "json", Payload: []byte(topics[i])}, record.PayloadData)
+			assert.Equal(t, time.Unix(1700000001+int64(i), 0), record.RecordedAt)
+		}
+
+		require.Nil(t, r.Close())
+		assert.Equal(t, ErrRecorderClosed, r.ProcessPayload(&PayloadData{Topic: outport.TopicSettings}))
+	})
+	t.Run("handler error should be returned after recording", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		filePath := filepath.Join(t.TempDir(), "archive")
+		r, _ := NewRecorder(ArgsRecorder{
+			FilePath: filePath,
+			PayloadHandler: &payloadHandlerStub{
+				ProcessPayloadCalled: func(payloadData *PayloadData) error {
+					return expectedErr
+				},
+			},
+		})
+
+		assert.Equal(t, expectedErr, r.ProcessPayload(&PayloadData{Topic: outport.TopicSaveBlock}))
+		require.Nil(t, r.Close())
+
+		records, _ := readArchiveFile(t, filePath)
+		assert.Len(t, records, 1)
+	})
+}
+
+func TestReadArchive(t *testing.T) {
+	t.Parallel()
+
+	createArchive := func(t *testing.T) string {
+		filePath := filepath.Join(t.TempDir(), "archive")
+		r, _ := NewRecorder(ArgsRecorder{FilePath: filePath})
+		_ = r.ProcessPayload(&PayloadData{Topic: outport.TopicSaveBlock, Payload: []byte("block")})
+		_ = r.ProcessPayload(&PayloadData{Topic: outport.TopicFinalizedBlock, Payload: []byte("finalized")})
+		require.Nil(t, r.Close())
+
+		return filePath
+	}
+
+	t.Run("not an archive should error", func(t *testing.T) {
+		t.Parallel()
+
+		filePath := filepath.Join(t.TempDir(), "archive")
+		require.Nil(t, os.WriteFile(filePath, []byte("not an outport archive"), 0644))
+
+		_, err := readArchiveFile(t, filePath)
+		assert.True(t, errors.Is(err, ErrInvalidArchive))
+	})
+	t.Run("unsupported version should error", func(t *testing.T) {
+		t.Parallel()
+
+		filePath := createArchive(t)
+		content, _ := os.ReadFile(filePath)
+		binary.BigEndian.PutUint32(content[len(archiveMagic):], ArchiveVersion+1)
+		require.Nil(t, os.WriteFile(filePath, content, 0644))
+
+		_, err := readArchiveFile(t, filePath)
+		assert.True(t, errors.Is(err, ErrUnsupportedArchiveVersion))
+	})
+	t.Run("truncated record should error", func(t *testing.T) {
+		t.Parallel()
+
+		filePath := createArchive(t)
+		info, _ := os.Stat(filePath)
+		require.Nil(t, os.Truncate(filePath, info.Size()-2))
+
+		_, err := readArchiveFile(t, filePath)
+		assert.True(t, errors.Is(err, ErrCorruptedArchive))
+	})
+	t.Run("altered record should error", func(t *testing.T) {
+		t.Parallel()
+
+		filePath := createArchive(t)
+		content, _ := os.ReadFile(filePath)
+		content[len(content)-6] ^= 0xFF
+		require.Nil(t, os.WriteFile(filePath, content, 0644))
+
+		_, err := readArchiveFile(t, filePath)
+		assert.True(t, errors.Is(err, ErrCorruptedArchive))
+	})
+}
@@ -0,0 +1,213 @@
+package transport
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/core/closing"
+)
+
+const minDuration = time.Millisecond
+
+// ArgsSender holds the arguments needed to create a new sender
+type ArgsSender struct {
+	Network       string
+	Address       string
+	RetryDuration time.Duration
+	// WriteTimeout bounds the time needed to write a payload
+	WriteTimeout time.Duration
+	// AckTimeout bounds the time needed to receive the ack once the payload was written, so it includes the receiver's
+	// processing time. A payload whose ack times out is re-sent, so a receiver slower than AckTimeout would process
+	// it over and over again. The value 0 disables it, the sender waiting for the ack until the connection breaks or
+	// the sender is closed
+	AckTimeout time.Duration
+	Log        core.Logger
+}
+
+// sender ships payloads to a receiver, one at a time, waiting for the ack of each of them. The connection is opened
+// lazily and, whenever it breaks, the sender reconnects and re-sends the payload until it is acked or the sender is closed
+type sender struct {
+	network       string
+	address       string
+	retryDuration time.Duration
+	writeTimeout  time.Duration
+	ackTimeout    time.Duration
+	maxFrameSize  int
+	log           core.Logger
+	closer        core.SafeCloser
+
+	mutSend sync.Mutex
+	counter uint64
+
+	mutConn sync.Mutex
+	conn    net.Conn
+}
+
+// NewSender creates a new sender
+func NewSender(args ArgsSender) (*sender, error) {
+	err := checkNetworkAndAddress(args.Network, args.Address)
+	if err != nil {
+		return nil, err
+	}
+	if args.RetryDuration < minDuration {
+		return nil, fmt.Errorf("%w for RetryDuration, minimum %v", ErrInvalidDuration, minDuration)
+	}
+	if args.WriteTimeout < minDuration {
+		return nil, fmt.Errorf("%w for WriteTimeout, minimum %v", ErrInvalidDuration, minDuration)
+	}
+	if args.AckTimeout != 0 && args.AckTimeout < minDuration {
+		return nil, fmt.Errorf("%w for AckTimeout, should be 0 or minimum %v", ErrInvalidDuration, minDuration)
+	}
+	if check.IfNil(args.Log) {
+		return nil, core.ErrNilLogger
+	}
+
+	return &sender{
+		network:       args.Network,
+		address:       args.Address,
+		retryDuration: args.RetryDuration,
+		writeTimeout:  args.WriteTimeout,
+		ackTimeout:    args.AckTimeout,
+		maxFrameSize:  MaxFrameSize,
+		log:           args.Log,
+		closer:        closing.NewSafeChanCloser(),
+	}, nil
+}
+
+// Send ships the provided payload and blocks until the receiver acks it. Connection errors and unexpected acks
+// trigger a reconnection followed by a re-send, so a payload might be delivered more than once. An error is
+// returned without retrying if the payload is invalid or too large, if the receiver could not process the payload
+// or if the sender was closed
+func (s *sender) Send(payloadData *PayloadData) error {
+	err := checkPayloadDataToSend(payloadData, s.maxFrameSize)
+	if err != nil {
+		return err
+	}
+
+	body := encodePayloadData(payloadData)
+
+	s.mutSend.Lock()
+	defer s.mutSend.Unlock()
+
+	s.counter++
+	for {
+		if s.isClosed() {
+			return ErrSenderClosed
+		}
+
+		ack, errSend := s.trySend(s.counter, body)
+		if errSend == nil {
+			if ack.StatusInfo.ErrorEvent {
+				return fmt.Errorf("%w: %s", ErrPayloadNotProcessed, ack.StatusInfo.Status)
+			}
+
+			return nil
+		}
+
+		s.log.Debug("sender: cannot send payload, retrying", "topic", payloadData.Topic, "error", errSend)
+		s.dropConnection()
+		s.waitRetry()
+	}
+}
+
+func (s *sender) trySend(counter uint64, body []byte) (*AckData, error) {
+	conn, err := s.getConnection()
+	if err != nil {
+		return nil, err
+	}
+
+	err = conn.SetWriteDeadline(time.Now().Add(s.writeTimeout))
+	if err != nil {
+		return nil, err
+	}
+	err = writeFrame(conn, payloadFrame, counter, body)
+	if err != nil {
+		return nil, err
+	}
+
+	ackDeadline := time.Time{}
+	if s.ackTimeout > 0 {
+		ackDeadline = time.Now().Add(s.ackTimeout)
+	}
+	err = conn.SetReadDeadline(ackDeadline)
+	if err != nil {
+		return nil, err
+	}
+
+	ack, err := readAckFrame(conn)
+	if err != nil {
+		return nil, err
+	}
+	if ack.Counter != counter {
+		return nil, fmt.Errorf("%w, sent %d, received %d", ErrAckCounterMismatch, counter, ack.Counter)
+	}
+
+	return ack, nil
+}
+
+func (s *sender) getConnection() (net.Conn, error) {
+	s.mutConn.Lock()
+	defer s.mutConn.Unlock()
+
+	if s.isClosed() {
+		return nil, ErrSenderClosed
+	}
+	if s.conn != nil {
+		return s.conn, nil
+	}
+
+	conn, err := net.DialTimeout(s.network, s.address, s.retryDuration)
+	if err != nil {
+		return nil, err
+	}
+	s.conn = conn
+	s.log.Debug("sender: connected", "address", s.address)
+
+	return conn, nil
+}
+
+func (s *sender) dropConnection() {
+	s.mutConn.Lock()
+	defer s.mutConn.Unlock()
+
+	if s.conn != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+	}
+}
+
+func (s *sender) waitRetry() {
+	timer := time.NewTimer(s.retryDuration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-s.closer.ChanClose():
+	}
+}
+
+func (s *sender) isClosed() bool {
+	select {
+	case <-s.closer.ChanClose():
+		return true
+	default:
+		return false
+	}
+}
+
+// Close closes the sender, unblocking any pending Send call
+func (s *sender) Close() error {
+	s.closer.Close()
+	s.dropConnection()
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (s *sender) IsInterfaceNil() bool {
+	return s == nil
+}
@@ -0,0 +1,340 @@
+package transport
+
+import (
+	"errors"
+	"net"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/core/mock"
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type payloadHandlerStub struct {
+	ProcessPayloadCalled func(payloadData *PayloadData) error
+}
+
+func (phs *payloadHandlerStub) ProcessPayload(payloadData *PayloadData) error {
+	if phs.ProcessPayloadCalled != nil {
+		return phs.ProcessPayloadCalled(payloadData)
+	}
+
+	return nil
+}
+
+func (phs *payloadHandlerStub) IsInterfaceNil() bool {
+	return phs == nil
+}
+
+type payloadsCollector struct {
+	mut      sync.Mutex
+	payloads []*PayloadData
+}
+
+func (pc *payloadsCollector) ProcessPayload(payloadData *PayloadData) error {
+	pc.mut.Lock()
+	pc.payloads = append(pc.payloads, payloadData)
+	pc.mut.Unlock()
+
+	return nil
+}
+
+func (pc *payloadsCollector) getTopics() []string {
+	pc.mut.Lock()
+	defer pc.mut.Unlock()
+
+	topics := make([]string, 0, len(pc.payloads))
+	for _, payloadData := range pc.payloads {
+		topics = append(topics, payloadData.Topic)
+	}
+
+	return topics
+}
+
+func (pc *payloadsCollector) IsInterfaceNil() bool {
+	return pc == nil
+}
+
+func createMockArgsReceiver(handler PayloadHandler) ArgsReceiver {
+	return ArgsReceiver{
+		Network:        NetworkTCP,
+		Address:        "127.0.0.1:0",
+		PayloadHandler: handler,
+		Log:            &mock.LoggerMock{},
+	}
+}
+
+func createMockArgsSender(address string) ArgsSender {
+	return ArgsSender{
+		Network:       NetworkTCP,
+		Address:       address,
+		RetryDuration: time.Millisecond * 10,
+		WriteTimeout:  time.Second,
+		AckTimeout:    time.Second,
+		Log:           &mock.LoggerMock{},
+	}
+}
+
+func getFreeLocalAddress(t *testing.T) string {
+	listener, err := net.Listen(NetworkTCP, "127.0.0.1:0")
+	require.Nil(t, err)
+	address := listener.Addr().String()
+	require.Nil(t, listener.Close())
+
+	return address
+}
+
+func TestNewReceiver(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsReceiver(&payloadHandlerStub{})
+	args.Network = "udp"
+	r, err := NewReceiver(args)
+	assert.Equal(t, ErrInvalidNetwork, err)
+	assert.True(t, check.IfNil(r))
+
+	args = createMockArgsReceiver(&payloadHandlerStub{})
+	args.Address = ""
+	_, err = NewReceiver(args)
+	assert.Equal(t, ErrEmptyAddress, err)
+
+	_, err = NewReceiver(createMockArgsReceiver(nil))
+	assert.Equal(t, ErrNilPayloadHandler, err)
+
+	args = createMockArgsReceiver(&payloadHandlerStub{})
+	args.Log = nil
+	_, err = NewReceiver(args)
+	assert.Equal(t, core.ErrNilLogger, err)
+
+	r, err = NewReceiver(createMockArgsReceiver(&payloadHandlerStub{}))
+	require.Nil(t, err)
+	assert.False(t, check.IfNil(r))
+	assert.Nil(t, r.Close())
+	assert.Nil(t, r.Close())
+}
+
+func TestNewSender(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsSender("")
+	_, err := NewSender(args)
+	assert.Equal(t, ErrEmptyAddress, err)
+
+	args = createMockArgsSender("127.0.0.1:1")
+	args.RetryDuration = 0
+	_, err = NewSender(args)
+	assert.True(t, errors.Is(err, ErrInvalidDuration))
+
+	args = createMockArgsSender("127.0.0.1:1")
+	args.WriteTimeout = 0
+	_, err = NewSender(args)
+	assert.True(t, errors.Is(err, ErrInvalidDuration))
+
+	args = createMockArgsSender("127.0.0.1:1")
+	args.AckTimeout = time.Microsecond
+	_, err = NewSender(args)
+	assert.True(t, errors.Is(err, ErrInvalidDuration))
+
+	args = createMockArgsSender("127.0.0.1:1")
+	args.AckTimeout = 0
+	_, err = NewSender(args)
+	assert.Nil(t, err)
+
+	args = createMockArgsSender("127.0.0.1:1")
+	args.Log = nil
+	_, err = NewSender(args)
+	assert.Equal(t, core.ErrNilLogger, err)
+
+	s, err := NewSender(createMockArgsSender("127.0.0.1:1"))
+	require.Nil(t, err)
+	assert.False(t, check.IfNil(s))
+}
+
+func TestSenderAndReceiver(t *testing.T) {
+	t.Parallel()
+
+	t.Run("payloads should be delivered in order over tcp", func(t *testing.T) {
+		t.Parallel()
+
+		collector := &payloadsCollector{}
+		r, err := NewReceiver(createMockArgsReceiver(collector))
+		require.Nil(t, err)
+		defer func() {
+			_ = r.Close()
+		}()
+
+		s, _ := NewSender(createMockArgsSender(r.Addr().String()))
+		defer func() {
+			_ = s.Close()
+		}()
+
+		assert.Equal(t, ErrNilPayloadData, s.Send(nil))
+		assert.Equal(t, ErrEmptyTopic, s.Send(&PayloadData{}))
+
+		topics := []string{outport.TopicSettings, outport.TopicSaveBlock, outport.TopicFinalizedBlock}
+		for _, topic := range topics {
+			require.Nil(t, s.Send(&PayloadData{Topic: topic, MarshallerType: "json", Payload: []byte(topic)}))
+		}
+		assert.Equal(t, topics, collector.getTopics())
+	})
+	t.Run("payloads should be delivered over unix socket", func(t *testing.T) {
+		t.Parallel()
+
+		collector := &payloadsCollector{}
+		address := filepath.Join(t.TempDir(), "outport.sock")
+		args := createMockArgsReceiver(collector)
+		args.Network = NetworkUnix
+		args.Address = address
+		r, err := NewReceiver(args)
+		require.Nil(t, err)
+		defer func() {
+			_ = r.Close()
+		}()
+
+		senderArgs := createMockArgsSender(address)
+		senderArgs.Network = NetworkUnix
+		s, _ := NewSender(senderArgs)
+		defer func() {
+			_ = s.Close()
+		}()
+
+		require.Nil(t, s.Send(&PayloadData{Topic: outport.TopicSaveBlock, Payload: []byte("block")}))
+		assert.Equal(t, []string{outport.TopicSaveBlock}, collector.getTopics())
+	})
+	t.Run("processing error should be returned through the ack", func(t *testing.T) {
+		t.Parallel()
+
+		r, err := NewReceiver(createMockArgsReceiver(&payloadHandlerStub{
+			ProcessPayloadCalled: func(payloadData *PayloadData) error {
+				if payloadData.Topic == outport.TopicRevertIndexedBlock {
+					return errors.New("cannot revert")
+				}
+				return nil
+			},
+		}))
+		require.Nil(t, err)
+		defer func() {
+			_ = r.Close()
+		}()
+
+		s, _ := NewSender(createMockArgsSender(r.Addr().String()))
+		defer func() {
+			_ = s.Close()
+		}()
+
+		err = s.Send(&PayloadData{Topic: outport.TopicRevertIndexedBlock})
+		assert.True(t, errors.Is(err, ErrPayloadNotProcessed))
+		assert.Contains(t, err.Error(), "cannot revert")
+
+		assert.Nil(t, s.Send(&PayloadData{Topic: outport.TopicSaveBlock}))
+	})
+	t.Run("too large payload should error without retrying", func(t *testing.T) {
+		t.Parallel()
+
+		s, _ := NewSender(createMockArgsSender(getFreeLocalAddress(t)))
+		s.maxFrameSize = 16
+		defer func() {
+			_ = s.Close()
+		}()
+
+		err := s.Send(&PayloadData{Topic: outport.TopicSaveBlock, Payload: make([]byte, 17)})
+		assert.True(t, errors.Is(err, ErrFrameTooLarge))
+	})
+	t.Run("slow processing should not cause re-sends when the ack timeout is disabled", func(t *testing.T) {
+		t.Parallel()
+
+		numCalls := 0
+		mutCalls := sync.Mutex{}
+		r, err := NewReceiver(createMockArgsReceiver(&payloadHandlerStub{
+			ProcessPayloadCalled: func(payloadData *PayloadData) error {
+				mutCalls.Lock()
+				numCalls++
+				mutCalls.Unlock()
+
+				time.Sleep(time.Millisecond * 200)
+				return nil
+			},
+		}))
+		require.Nil(t, err)
+		defer func() {
+			_ = r.Close()
+		}()
+
+		args := createMockArgsSender(r.Addr().String())
+		args.WriteTimeout = time.Millisecond * 50
+		args.AckTimeout = 0
+		s, _ := NewSender(args)
+		defer func() {
+			_ = s.Close()
+		}()
+
+		require.Nil(t, s.Send(&PayloadData{Topic: outport.TopicSaveBlock}))
+		mutCalls.Lock()
+		assert.Equal(t, 1, numCalls)
+		mutCalls.Unlock()
+	})
+	t.Run("sender should wait for the receiver and reconnect after a restart", func(t *testing.T) {
+		t.Parallel()
+
+		address := getFreeLocalAddress(t)
+		s, _ := NewSender(createMockArgsSender(address))
+		defer func() {
+			_ = s.Close()
+		}()
+
+		collector := &payloadsCollector{}
+		args := createMockArgsReceiver(collector)
+		args.Address = address
+		go func() {
+			time.Sleep(time.Millisecond * 100)
+			r, err := NewReceiver(args)
+			require.Nil(t, err)
+			defer func() {
+				_ = r.Close()
+			}()
+
+			time.Sleep(time.Millisecond * 200)
+		}()
+
+		require.Nil(t, s.Send(&PayloadData{Topic: outport.TopicSaveBlock}))
+		time.Sleep(time.Millisecond * 300)
+
+		secondCollector := &payloadsCollector{}
+		args.PayloadHandler = secondCollector
+		r, err := NewReceiver(args)
+		require.Nil(t, err)
+		defer func() {
+			_ = r.Close()
+		}()
+
+		require.Nil(t, s.Send(&PayloadData{Topic: outport.TopicFinalizedBlock}))
+		assert.Equal(t, []string{outport.TopicSaveBlock}, collector.getTopics())
+		assert.Equal(t, []string{outport.TopicFinalizedBlock}, secondCollector.getTopics())
+	})
+	t.Run("close should unblock a pending send", func(t *testing.T) {
+		t.Parallel()
+
+		s, _ := NewSender(createMockArgsSender(getFreeLocalAddress(t)))
+
+		chErr := make(chan error)
+		go func() {
+			chErr <- s.Send(&PayloadData{Topic: outport.TopicSaveBlock})
+		}()
+
+		time.Sleep(time.Millisecond * 50)
+		assert.Nil(t, s.Close())
+
+		select {
+		case err := <-chErr:
+			assert.Equal(t, ErrSenderClosed, err)
+		case <-time.After(time.Second):
+			assert.Fail(t, "send should have been unblocked")
+		}
+	})
+}
@@ -0,0 +1,15 @@
+package tokenLedger
+
+import "errors"
+
+// ErrNilOutportBlock signals that a nil outport block has been provided
+var ErrNilOutportBlock = errors.New("nil outport block")
+
+// ErrInvalidEventTopics signals that a log event does not hold the topics expected for its identifier
+var ErrInvalidEventTopics = errors.New("invalid event topics")
+
+// ErrInvalidTokenBalance signals that a token balance is not a base 10 integer
+var ErrInvalidTokenBalance = errors.New("invalid token balance")
+
+// ErrInvalidSnapshot signals that a ledger snapshot holds invalid entries
+var ErrInvalidSnapshot = errors.New("invalid ledger snapshot")
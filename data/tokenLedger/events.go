@@ -0,0 +1,152 @@
+package tokenLedger
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+)
+
+const (
+	tokenTopicsGroupSize = 3
+	minBalanceTopics     = 3
+	minTransferTopics    = 4
+)
+
+type operationType int
+
+const (
+	credit operationType = iota
+	debit
+	mint
+	burn
+	initialMint
+	setBalance
+)
+
+// operation is a single change of the ledger, derived from a log event or from an altered account
+type operation struct {
+	opType  operationType
+	address string
+	key     tokenKey
+	value   *big.Int
+}
+
+type tokenTransfer struct {
+	key   tokenKey
+	value *big.Int
+}
+
+// createEventOperations converts a log event into ledger operations. The events not related to token balances or
+// supplies are ignored
+func (tl *tokenLedger) createEventOperations(event *transaction.Event, shardID uint32) ([]*operation, error) {
+	identifier := string(event.GetIdentifier())
+	switch identifier {
+	case core.BuiltInFunctionESDTTransfer, core.BuiltInFunctionESDTNFTTransfer, core.BuiltInFunctionMultiESDTNFTTransfer:
+		return tl.createTransferOperations(event, shardID)
+	case core.BuiltInFunctionESDTLocalMint, core.BuiltInFunctionESDTNFTAddQuantity, core.BuiltInFunctionESDTNFTCreate:
+		return tl.createSupplyOperations(event, event.Address, credit, mint)
+	case core.BuiltInFunctionESDTBurn, core.BuiltInFunctionESDTLocalBurn, core.BuiltInFunctionESDTNFTBurn:
+		return tl.createSupplyOperations(event, event.Address, debit, burn)
+	case core.BuiltInFunctionESDTWipe:
+		if len(event.Topics) < minTransferTopics {
+			return nil, fmt.Errorf("%w, %s with %d topics", ErrInvalidEventTopics, identifier, len(event.Topics))
+		}
+		return tl.createSupplyOperations(event, event.Topics[3], debit, burn)
+	default:
+		return nil, nil
+	}
+}
+
+// createTransferOperations debits the sender and credits the receiver only if they belong to the shard of the block,
+// as a cross shard transfer is logged in both the sender and the receiver shards. Transfers sent by the ESDT system
+// smart contract are the initial mints of the issued tokens
+func (tl *tokenLedger) createTransferOperations(event *transaction.Event, shardID uint32) ([]*operation, error) {
+	numTopics := len(event.Topics)
+	if numTopics < minTransferTopics || (numTopics-1)%tokenTopicsGroupSize != 0 {
+		return nil, fmt.Errorf("%w, %s with %d topics", ErrInvalidEventTopics, event.Identifier, numTopics)
+	}
+
+	transfers := parseTokenTransfers(event.Topics[:numTopics-1])
+	sender := event.Address
+	receiver := event.Topics[numTopics-1]
+	isInitialMint := bytes.Equal(sender, core.ESDTSCAddress)
+
+	operations := make([]*operation, 0, 2*len(transfers))
+	if !isInitialMint && tl.shardCoordinator.ComputeId(sender) == shardID {
+		senderOperations, err := tl.createTransfersOperations(sender, transfers, debit)
+		if err != nil {
+			return nil, err
+		}
+		operations = append(operations, senderOperations...)
+	}
+	if tl.shardCoordinator.ComputeId(receiver) == shardID {
+		receiverOperations, err := tl.createTransfersOperations(receiver, transfers, credit)
+		if err != nil {
+			return nil, err
+		}
+		operations = append(operations, receiverOperations...)
+
+		if isInitialMint {
+			for _, transfer := range transfers {
+				operations = append(operations, &operation{opType: initialMint, key: transfer.key, value: transfer.value})
+			}
+		}
+	}
+
+	return operations, nil
+}
+
+func (tl *tokenLedger) createTransfersOperations(address []byte, transfers []*tokenTransfer, opType operationType) ([]*operation, error) {
+	encodedAddress, err := tl.pubkeyConverter.Encode(address)
+	if err != nil {
+		return nil, err
+	}
+
+	operations := make([]*operation, 0, len(transfers))
+	for _, transfer := range transfers {
+		operations = append(operations, &operation{opType: opType, address: encodedAddress, key: transfer.key, value: transfer.value})
+	}
+
+	return operations, nil
+}
+
+func (tl *tokenLedger) createSupplyOperations(
+	event *transaction.Event,
+	address []byte,
+	balanceOpType operationType,
+	supplyOpType operationType,
+) ([]*operation, error) {
+	if len(event.Topics) < minBalanceTopics {
+		return nil, fmt.Errorf("%w, %s with %d topics", ErrInvalidEventTopics, event.Identifier, len(event.Topics))
+	}
+
+	transfer := parseTokenTransfers(event.Topics[:minBalanceTopics])[0]
+	encodedAddress, err := tl.pubkeyConverter.Encode(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*operation{
+		{opType: balanceOpType, address: encodedAddress, key: transfer.key, value: transfer.value},
+		{opType: supplyOpType, key: transfer.key, value: transfer.value},
+	}, nil
+}
+
+// parseTokenTransfers parses the (identifier, nonce, value) groups of topics
+func parseTokenTransfers(topics [][]byte) []*tokenTransfer {
+	transfers := make([]*tokenTransfer, 0, len(topics)/tokenTopicsGroupSize)
+	for i := 0; i+tokenTopicsGroupSize <= len(topics); i += tokenTopicsGroupSize {
+		transfers = append(transfers, &tokenTransfer{
+			key: tokenKey{
+				identifier: string(topics[i]),
+				nonce:      big.NewInt(0).SetBytes(topics[i+1]).Uint64(),
+			},
+			value: big.NewInt(0).SetBytes(topics[i+2]),
+		})
+	}
+
+	return transfers
+}
@@ -0,0 +1,7 @@
+package tokenLedger
+
+// ShardCoordinator defines the behavior of a component able to compute the shard of an address
+type ShardCoordinator interface {
+	ComputeId(address []byte) uint32
+	IsInterfaceNil() bool
+}
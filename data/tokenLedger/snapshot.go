@@ -0,0 +1,221 @@
+package tokenLedger
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// BalanceEntry holds the balance of a token held by an encoded address
+type BalanceEntry struct {
+	Address    string `json:"address"`
+	Identifier string `json:"identifier"`
+	Nonce      uint64 `json:"nonce"`
+	Balance    string `json:"balance"`
+}
+
+// SupplyEntry holds the supply counters of a token
+type SupplyEntry struct {
+	Identifier    string `json:"identifier"`
+	Nonce         uint64 `json:"nonce"`
+	InitialMinted string `json:"initialMinted"`
+	Minted        string `json:"minted"`
+	Burned        string `json:"burned"`
+}
+
+// ProcessedHeadersEntry holds the hex encoded hashes of the last processed headers of a shard, in processing order
+type ProcessedHeadersEntry struct {
+	ShardID      uint32   `json:"shardID"`
+	HeaderHashes []string `json:"headerHashes"`
+}
+
+// LedgerSnapshot holds the whole state of a token ledger, sorted by address, identifier and nonce, and by shard for
+// the processed headers
+type LedgerSnapshot struct {
+	Balances         []*BalanceEntry          `json:"balances"`
+	Supplies         []*SupplyEntry           `json:"supplies"`
+	ProcessedHeaders []*ProcessedHeadersEntry `json:"processedHeaders"`
+}
+
+// Snapshot serializes the state of the ledger with the ledger's marshaller
+func (tl *tokenLedger) Snapshot() ([]byte, error) {
+	tl.mut.RLock()
+	snapshot := tl.createSnapshot()
+	tl.mut.RUnlock()
+
+	return tl.marshaller.Marshal(snapshot)
+}
+
+func (tl *tokenLedger) createSnapshot() *LedgerSnapshot {
+	snapshot := &LedgerSnapshot{
+		Balances:         make([]*BalanceEntry, 0),
+		Supplies:         make([]*SupplyEntry, 0, len(tl.supplies)),
+		ProcessedHeaders: make([]*ProcessedHeadersEntry, 0, len(tl.processedHeaders)),
+	}
+
+	for address, tokens := range tl.balances {
+		for key, balance := range tokens {
+			snapshot.Balances = append(snapshot.Balances, &BalanceEntry{
+				Address:    address,
+				Identifier: key.identifier,
+				Nonce:      key.nonce,
+				Balance:    balance.String(),
+			})
+		}
+	}
+	sort.Slice(snapshot.Balances, func(i, j int) bool {
+		if snapshot.Balances[i].Address != snapshot.Balances[j].Address {
+			return snapshot.Balances[i].Address < snapshot.Balances[j].Address
+		}
+		return isTokenLess(snapshot.Balances[i].Identifier, snapshot.Balances[i].Nonce, snapshot.Balances[j].Identifier, snapshot.Balances[j].Nonce)
+	})
+
+	for key, supply := range tl.supplies {
+		snapshot.Supplies = append(snapshot.Supplies, &SupplyEntry{
+			Identifier:    key.identifier,
+			Nonce:         key.nonce,
+			InitialMinted: supply.initialMinted.String(),
+			Minted:        supply.minted.String(),
+			Burned:        supply.burned.String(),
+		})
+	}
+	sort.Slice(snapshot.Supplies, func(i, j int) bool {
+		return isTokenLess(snapshot.Supplies[i].Identifier, snapshot.Supplies[i].Nonce, snapshot.Supplies[j].Identifier, snapshot.Supplies[j].Nonce)
+	})
+
+	for shardID, processedHashes := range tl.processedHeaders {
+		entry := &ProcessedHeadersEntry{
+			ShardID:      shardID,
+			HeaderHashes: make([]string, 0, len(processedHashes)),
+		}
+		for _, headerHash := range processedHashes {
+			entry.HeaderHashes = append(entry.HeaderHashes, hex.EncodeToString([]byte(headerHash)))
+		}
+		snapshot.ProcessedHeaders = append(snapshot.ProcessedHeaders, entry)
+	}
+	sort.Slice(snapshot.ProcessedHeaders, func(i, j int) bool {
+		return snapshot.ProcessedHeaders[i].ShardID < snapshot.ProcessedHeaders[j].ShardID
+	})
+
+	return snapshot
+}
+
+func isTokenLess(identifierA string, nonceA uint64, identifierB string, nonceB uint64) bool {
+	if identifierA != identifierB {
+		return identifierA < identifierB
+	}
+	return nonceA < nonceB
+}
+
+// Restore replaces the state of the ledger with the one of the provided serialized snapshot. The state is left
+// untouched if the snapshot is invalid
+func (tl *tokenLedger) Restore(buff []byte) error {
+	snapshot := &LedgerSnapshot{}
+	err := tl.marshaller.Unmarshal(snapshot, buff)
+	if err != nil {
+		return err
+	}
+
+	balances := make(map[string]map[tokenKey]*big.Int)
+	for _, entry := range snapshot.Balances {
+		if entry == nil {
+			continue
+		}
+
+		balance, errParse := parseSnapshotValue(entry.Balance)
+		if errParse != nil {
+			return fmt.Errorf("%w, address %s, token %s", errParse, entry.Address, entry.Identifier)
+		}
+		if balance.Sign() == 0 {
+			continue
+		}
+
+		tokens, found := balances[entry.Address]
+		if !found {
+			tokens = make(map[tokenKey]*big.Int)
+			balances[entry.Address] = tokens
+		}
+		tokens[tokenKey{identifier: entry.Identifier, nonce: entry.Nonce}] = balance
+	}
+
+	supplies := make(map[tokenKey]*tokenSupply)
+	for _, entry := range snapshot.Supplies {
+		if entry == nil {
+			continue
+		}
+
+		supply, errParse := parseSupplyEntry(entry)
+		if errParse != nil {
+			return fmt.Errorf("%w, token %s", errParse, entry.Identifier)
+		}
+		supplies[tokenKey{identifier: entry.Identifier, nonce: entry.Nonce}] = supply
+	}
+
+	processedHeaders, err := parseProcessedHeaders(snapshot.ProcessedHeaders)
+	if err != nil {
+		return err
+	}
+
+	tl.mut.Lock()
+	tl.balances = balances
+	tl.supplies = supplies
+	tl.processedHeaders = processedHeaders
+	tl.mut.Unlock()
+
+	return nil
+}
+
+func parseProcessedHeaders(entries []*ProcessedHeadersEntry) (map[uint32][]string, error) {
+	processedHeaders := make(map[uint32][]string)
+	for _, entry := range entries {
+		if entry == nil {
+			continue
+		}
+
+		processedHashes := make([]string, 0, len(entry.HeaderHashes))
+		for _, encodedHash := range entry.HeaderHashes {
+			headerHash, err := hex.DecodeString(encodedHash)
+			if err != nil || len(headerHash) == 0 {
+				return nil, fmt.Errorf("%w, shard %d, header hash %q", ErrInvalidSnapshot, entry.ShardID, encodedHash)
+			}
+			processedHashes = append(processedHashes, string(headerHash))
+		}
+		if len(processedHashes) > numProcessedHeadersToKeep {
+			processedHashes = processedHashes[len(processedHashes)-numProcessedHeadersToKeep:]
+		}
+		processedHeaders[entry.ShardID] = processedHashes
+	}
+
+	return processedHeaders, nil
+}
+
+func parseSupplyEntry(entry *SupplyEntry) (*tokenSupply, error) {
+	initialMinted, err := parseSnapshotValue(entry.InitialMinted)
+	if err != nil {
+		return nil, err
+	}
+	minted, err := parseSnapshotValue(entry.Minted)
+	if err != nil {
+		return nil, err
+	}
+	burned, err := parseSnapshotValue(entry.Burned)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tokenSupply{
+		initialMinted: initialMinted,
+		minted:        minted,
+		burned:        burned,
+	}, nil
+}
+
+func parseSnapshotValue(value string) (*big.Int, error) {
+	parsed, ok := big.NewInt(0).SetString(value, 10)
+	if !ok || parsed.Sign() < 0 {
+		return nil, fmt.Errorf("%w, value %q", ErrInvalidSnapshot, value)
+	}
+
+	return parsed, nil
+}
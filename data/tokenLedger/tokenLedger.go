@@ -0,0 +1,279 @@
+package tokenLedger
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/alteredAccount"
+	"github.com/multiversx/mx-chain-core-go/data/api"
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+)
+
+// ArgsTokenLedger holds the components needed to create a new token ledger
+type ArgsTokenLedger struct {
+	PubkeyConverter  core.PubkeyConverter
+	ShardCoordinator ShardCoordinator
+	// Marshaller serializes the ledger snapshots, which are plain structs and not protobuf messages, so it should be
+	// a marshaller such as marshal.JsonMarshalizer
+	Marshaller marshal.Marshalizer
+}
+
+// numProcessedHeadersToKeep is the number of the most recent header hashes remembered for each shard, so that the
+// blocks delivered again are not applied twice
+const numProcessedHeadersToKeep = 100
+
+type tokenKey struct {
+	identifier string
+	nonce      uint64
+}
+
+type tokenSupply struct {
+	initialMinted *big.Int
+	minted        *big.Int
+	burned        *big.Int
+}
+
+type tokenLedger struct {
+	pubkeyConverter  core.PubkeyConverter
+	shardCoordinator ShardCoordinator
+	marshaller       marshal.Marshalizer
+
+	mut              sync.RWMutex
+	balances         map[string]map[tokenKey]*big.Int
+	supplies         map[tokenKey]*tokenSupply
+	processedHeaders map[uint32][]string
+}
+
+// NewTokenLedger creates an in-memory ledger of the token balances and supplies, built from the log events and the
+// altered accounts of the outport blocks
+func NewTokenLedger(args ArgsTokenLedger) (*tokenLedger, error) {
+	if check.IfNil(args.PubkeyConverter) {
+		return nil, core.ErrNilPubkeyConverter
+	}
+	if check.IfNil(args.ShardCoordinator) {
+		return nil, core.ErrNilShardCoordinator
+	}
+	if check.IfNil(args.Marshaller) {
+		return nil, core.ErrNilMarshalizer
+	}
+
+	return &tokenLedger{
+		pubkeyConverter:  args.PubkeyConverter,
+		shardCoordinator: args.ShardCoordinator,
+		marshaller:       args.Marshaller,
+		balances:         make(map[string]map[tokenKey]*big.Int),
+		supplies:         make(map[tokenKey]*tokenSupply),
+		processedHeaders: make(map[uint32][]string),
+	}, nil
+}
+
+// ProcessOutportBlock applies the token log events of the block, followed by the token balances of its altered
+// accounts, which are authoritative. Nothing is applied if any event or balance is invalid. A block having the
+// header hash of one of the last processed blocks of its shard is skipped, as the supply counters would otherwise be
+// increased twice. Blocks without a header hash are not deduplicated. Reverted blocks are not rolled back, so the
+// ledger should only be fed with final blocks
+func (tl *tokenLedger) ProcessOutportBlock(outportBlock *outport.OutportBlock) error {
+	if outportBlock == nil {
+		return ErrNilOutportBlock
+	}
+
+	operations := make([]*operation, 0)
+	for _, logData := range outportBlock.GetTransactionPool().GetLogs() {
+		for _, event := range logData.GetLog().GetEvents() {
+			if event == nil {
+				continue
+			}
+
+			eventOperations, err := tl.createEventOperations(event, outportBlock.ShardID)
+			if err != nil {
+				return fmt.Errorf("%w, tx hash %s", err, logData.TxHash)
+			}
+			operations = append(operations, eventOperations...)
+		}
+	}
+
+	accountsOperations, err := createAlteredAccountsOperations(outportBlock.AlteredAccounts)
+	if err != nil {
+		return err
+	}
+	operations = append(operations, accountsOperations...)
+
+	headerHash := string(outportBlock.GetBlockData().GetHeaderHash())
+
+	tl.mut.Lock()
+	defer tl.mut.Unlock()
+
+	if tl.isHeaderProcessed(outportBlock.ShardID, headerHash) {
+		return nil
+	}
+
+	for _, op := range operations {
+		tl.applyOperation(op)
+	}
+	tl.addProcessedHeader(outportBlock.ShardID, headerHash)
+
+	return nil
+}
+
+func (tl *tokenLedger) isHeaderProcessed(shardID uint32, headerHash string) bool {
+	if len(headerHash) == 0 {
+		return false
+	}
+
+	for _, processedHash := range tl.processedHeaders[shardID] {
+		if processedHash == headerHash {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (tl *tokenLedger) addProcessedHeader(shardID uint32, headerHash string) {
+	if len(headerHash) == 0 {
+		return
+	}
+
+	processedHashes := append(tl.processedHeaders[shardID], headerHash)
+	if len(processedHashes) > numProcessedHeadersToKeep {
+		processedHashes = processedHashes[len(processedHashes)-numProcessedHeadersToKeep:]
+	}
+	tl.processedHeaders[shardID] = processedHashes
+}
+
+func createAlteredAccountsOperations(accounts map[string]*alteredAccount.AlteredAccount) ([]*operation, error) {
+	operations := make([]*operation, 0)
+	for address, account := range accounts {
+		for _, token := range account.GetTokens() {
+			if token == nil {
+				continue
+			}
+
+			balance, err := parseBalance(token.Balance)
+			if err != nil {
+				return nil, fmt.Errorf("%w, address %s, token %s", err, address, token.Identifier)
+			}
+
+			operations = append(operations, &operation{
+				opType:  setBalance,
+				address: address,
+				key:     tokenKey{identifier: token.Identifier, nonce: token.Nonce},
+				value:   balance,
+			})
+		}
+	}
+
+	return operations, nil
+}
+
+// applyOperation drops the balances which are not positive, as the balances held before the ledger started are unknown
+func (tl *tokenLedger) applyOperation(op *operation) {
+	switch op.opType {
+	case credit:
+		tl.setBalance(op.address, op.key, big.NewInt(0).Add(tl.getBalance(op.address, op.key), op.value))
+	case debit:
+		tl.setBalance(op.address, op.key, big.NewInt(0).Sub(tl.getBalance(op.address, op.key), op.value))
+	case setBalance:
+		tl.setBalance(op.address, op.key, op.value)
+	case initialMint:
+		supply := tl.getOrCreateSupply(op.key)
+		supply.initialMinted.Add(supply.initialMinted, op.value)
+	case mint:
+		supply := tl.getOrCreateSupply(op.key)
+		supply.minted.Add(supply.minted, op.value)
+	case burn:
+		supply := tl.getOrCreateSupply(op.key)
+		supply.burned.Add(supply.burned, op.value)
+	}
+}
+
+func (tl *tokenLedger) getBalance(address string, key tokenKey) *big.Int {
+	balance, found := tl.balances[address][key]
+	if !found {
+		return big.NewInt(0)
+	}
+
+	return balance
+}
+
+func (tl *tokenLedger) setBalance(address string, key tokenKey, balance *big.Int) {
+	if balance.Sign() <= 0 {
+		delete(tl.balances[address], key)
+		if len(tl.balances[address]) == 0 {
+			delete(tl.balances, address)
+		}
+		return
+	}
+
+	tokens, found := tl.balances[address]
+	if !found {
+		tokens = make(map[tokenKey]*big.Int)
+		tl.balances[address] = tokens
+	}
+	tokens[key] = big.NewInt(0).Set(balance)
+}
+
+func (tl *tokenLedger) getOrCreateSupply(key tokenKey) *tokenSupply {
+	supply, found := tl.supplies[key]
+	if !found {
+		supply = &tokenSupply{
+			initialMinted: big.NewInt(0),
+			minted:        big.NewInt(0),
+			burned:        big.NewInt(0),
+		}
+		tl.supplies[key] = supply
+	}
+
+	return supply
+}
+
+// GetBalance returns the balance of the token held by the provided encoded address
+func (tl *tokenLedger) GetBalance(address string, identifier string, nonce uint64) *big.Int {
+	tl.mut.RLock()
+	defer tl.mut.RUnlock()
+
+	return big.NewInt(0).Set(tl.getBalance(address, tokenKey{identifier: identifier, nonce: nonce}))
+}
+
+// GetSupply returns the supply of the token, as computed from the processed events
+func (tl *tokenLedger) GetSupply(identifier string, nonce uint64) (*api.ESDTSupply, bool) {
+	tl.mut.RLock()
+	defer tl.mut.RUnlock()
+
+	supply, found := tl.supplies[tokenKey{identifier: identifier, nonce: nonce}]
+	if !found {
+		return nil, false
+	}
+
+	totalSupply := big.NewInt(0).Add(supply.initialMinted, supply.minted)
+	totalSupply.Sub(totalSupply, supply.burned)
+
+	return &api.ESDTSupply{
+		InitialMinted: supply.initialMinted.String(),
+		Supply:        totalSupply.String(),
+		Burned:        supply.burned.String(),
+		Minted:        supply.minted.String(),
+	}, true
+}
+
+func parseBalance(balance string) (*big.Int, error) {
+	if len(balance) == 0 {
+		return big.NewInt(0), nil
+	}
+
+	value, ok := big.NewInt(0).SetString(balance, 10)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidTokenBalance, balance)
+	}
+
+	return value, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (tl *tokenLedger) IsInterfaceNil() bool {
+	return tl == nil
+}
@@ -0,0 +1,375 @@
+package tokenLedger
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
+	"github.com/multiversx/mx-chain-core-go/core/sharding"
+	"github.com/multiversx/mx-chain-core-go/data/alteredAccount"
+	"github.com/multiversx/mx-chain-core-go/data/api"
+	"github.com/multiversx/mx-chain-core-go/data/outport"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const token = "TKN-abcdef"
+
+var (
+	alice = []byte("alice0") // shard 0
+	bob   = []byte("bobby2") // shard 0
+	carol = []byte("carol1") // shard 1
+)
+
+func createMockArgsTokenLedger() ArgsTokenLedger {
+	converter, _ := pubkeyConverter.NewHexPubkeyConverter(6)
+	shardCoordinator, _ := sharding.NewMultiShardCoordinator(2, 0)
+
+	return ArgsTokenLedger{
+		PubkeyConverter:  converter,
+		ShardCoordinator: shardCoordinator,
+		Marshaller:       &marshal.JsonMarshalizer{},
+	}
+}
+
+func createEvent(identifier string, address []byte, topics ...[]byte) *transaction.Event {
+	return &transaction.Event{
+		Address:    address,
+		Identifier: []byte(identifier),
+		Topics:     topics,
+	}
+}
+
+func createOutportBlock(shardID uint32, events ...*transaction.Event) *outport.OutportBlock {
+	return &outport.OutportBlock{
+		ShardID: shardID,
+		TransactionPool: &outport.TransactionPool{
+			Logs: []*outport.LogData{{TxHash: "hash", Log: &transaction.Log{Events: events}}},
+		},
+	}
+}
+
+func encode(address []byte) string {
+	return hex.EncodeToString(address)
+}
+
+func TestNewTokenLedger(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil pubkey converter should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsTokenLedger()
+		args.PubkeyConverter = nil
+		tl, err := NewTokenLedger(args)
+		assert.Nil(t, tl)
+		assert.Equal(t, core.ErrNilPubkeyConverter, err)
+	})
+	t.Run("nil shard coordinator should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsTokenLedger()
+		args.ShardCoordinator = nil
+		tl, err := NewTokenLedger(args)
+		assert.Nil(t, tl)
+		assert.Equal(t, core.ErrNilShardCoordinator, err)
+	})
+	t.Run("nil marshaller should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsTokenLedger()
+		args.Marshaller = nil
+		tl, err := NewTokenLedger(args)
+		assert.Nil(t, tl)
+		assert.Equal(t, core.ErrNilMarshalizer, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		tl, err := NewTokenLedger(createMockArgsTokenLedger())
+		assert.Nil(t, err)
+		assert.False(t, check.IfNil(tl))
+	})
+}
+
+func TestTokenLedger_ProcessOutportBlock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil outport block should error", func(t *testing.T) {
+		t.Parallel()
+
+		tl, _ := NewTokenLedger(createMockArgsTokenLedger())
+		assert.Equal(t, ErrNilOutportBlock, tl.ProcessOutportBlock(nil))
+	})
+	t.Run("issue, mint, transfer and burn should update balances and supply", func(t *testing.T) {
+		t.Parallel()
+
+		tl, _ := NewTokenLedger(createMockArgsTokenLedger())
+		err := tl.ProcessOutportBlock(createOutportBlock(0,
+			createEvent(core.BuiltInFunctionESDTTransfer, core.ESDTSCAddress, []byte(token), nil, big.NewInt(1000).Bytes(), alice),
+			createEvent(core.BuiltInFunctionESDTLocalMint, alice, []byte(token), nil, big.NewInt(50).Bytes()),
+			createEvent(core.BuiltInFunctionESDTTransfer, alice, []byte(token), nil, big.NewInt(300).Bytes(), bob),
+			createEvent(core.BuiltInFunctionESDTLocalBurn, bob, []byte(token), nil, big.NewInt(100).Bytes()),
+			createEvent(core.WriteLogIdentifier, alice, []byte("ignored")),
+		))
+		require.Nil(t, err)
+
+		assert.Equal(t, big.NewInt(750), tl.GetBalance(encode(alice), token, 0))
+		assert.Equal(t, big.NewInt(200), tl.GetBalance(encode(bob), token, 0))
+
+		supply, found := tl.GetSupply(token, 0)
+		require.True(t, found)
+		expectedSupply := &api.ESDTSupply{
+			InitialMinted: "1000",
+			Supply:        "950",
+			Burned:        "100",
+			Minted:        "50",
+		}
+		assert.Equal(t, expectedSupply, supply)
+
+		_, found = tl.GetSupply("MISSING-abcdef", 0)
+		assert.False(t, found)
+	})
+	t.Run("esdt burn should decrease the balance and increase the burned supply", func(t *testing.T) {
+		t.Parallel()
+
+		tl, _ := NewTokenLedger(createMockArgsTokenLedger())
+		err := tl.ProcessOutportBlock(createOutportBlock(0,
+			createEvent(core.BuiltInFunctionESDTTransfer, core.ESDTSCAddress, []byte(token), nil, big.NewInt(100).Bytes(), alice),
+			createEvent(core.BuiltInFunctionESDTBurn, alice, []byte(token), nil, big.NewInt(30).Bytes()),
+		))
+		require.Nil(t, err)
+
+		assert.Equal(t, big.NewInt(70), tl.GetBalance(encode(alice), token, 0))
+		supply, _ := tl.GetSupply(token, 0)
+		assert.Equal(t, "70", supply.Supply)
+		assert.Equal(t, "30", supply.Burned)
+	})
+	t.Run("block processed twice should be applied once", func(t *testing.T) {
+		t.Parallel()
+
+		tl, _ := NewTokenLedger(createMockArgsTokenLedger())
+		outportBlock := createOutportBlock(0,
+			createEvent(core.BuiltInFunctionESDTTransfer, core.ESDTSCAddress, []byte(token), nil, big.NewInt(100).Bytes(), alice),
+			createEvent(core.BuiltInFunctionESDTLocalMint, alice, []byte(token), nil, big.NewInt(20).Bytes()),
+			createEvent(core.BuiltInFunctionESDTLocalBurn, alice, []byte(token), nil, big.NewInt(5).Bytes()),
+		)
+		outportBlock.BlockData = &outport.BlockData{ShardID: 0, HeaderHash: []byte("hash 1")}
+		require.Nil(t, tl.ProcessOutportBlock(outportBlock))
+		require.Nil(t, tl.ProcessOutportBlock(outportBlock))
+
+		expectedSupply := &api.ESDTSupply{
+			InitialMinted: "100",
+			Supply:        "115",
+			Burned:        "5",
+			Minted:        "20",
+		}
+		supply, _ := tl.GetSupply(token, 0)
+		assert.Equal(t, expectedSupply, supply)
+		assert.Equal(t, big.NewInt(115), tl.GetBalance(encode(alice), token, 0))
+
+		otherShardBlock := createOutportBlock(1,
+			createEvent(core.BuiltInFunctionESDTLocalMint, carol, []byte(token), nil, big.NewInt(1).Bytes()),
+		)
+		otherShardBlock.BlockData = &outport.BlockData{ShardID: 1, HeaderHash: []byte("hash 1")}
+		require.Nil(t, tl.ProcessOutportBlock(otherShardBlock))
+		supply, _ = tl.GetSupply(token, 0)
+		assert.Equal(t, "21", supply.Minted)
+
+		for i := 0; i < numProcessedHeadersToKeep; i++ {
+			nextBlock := createOutportBlock(0)
+			nextBlock.BlockData = &outport.BlockData{ShardID: 0, HeaderHash: big.NewInt(int64(i + 2)).Bytes()}
+			require.Nil(t, tl.ProcessOutportBlock(nextBlock))
+		}
+		require.Nil(t, tl.ProcessOutportBlock(outportBlock))
+		supply, _ = tl.GetSupply(token, 0)
+		assert.Equal(t, "41", supply.Minted, "the block is applied again once evicted from the processed headers")
+	})
+	t.Run("cross shard transfer should only update the accounts of the block shard", func(t *testing.T) {
+		t.Parallel()
+
+		tl, _ := NewTokenLedger(createMockArgsTokenLedger())
+		_ = tl.ProcessOutportBlock(createOutportBlock(0,
+			createEvent(core.BuiltInFunctionESDTLocalMint, alice, []byte(token), nil, big.NewInt(10).Bytes()),
+		))
+
+		transfer := createEvent(core.BuiltInFunctionESDTTransfer, alice, []byte(token), nil, big.NewInt(4).Bytes(), carol)
+		require.Nil(t, tl.ProcessOutportBlock(createOutportBlock(0, transfer)))
+		assert.Equal(t, big.NewInt(6), tl.GetBalance(encode(alice), token, 0))
+		assert.Zero(t, tl.GetBalance(encode(carol), token, 0).Sign())
+
+		require.Nil(t, tl.ProcessOutportBlock(createOutportBlock(1, transfer)))
+		assert.Equal(t, big.NewInt(6), tl.GetBalance(encode(alice), token, 0))
+		assert.Equal(t, big.NewInt(4), tl.GetBalance(encode(carol), token, 0))
+	})
+	t.Run("nft events", func(t *testing.T) {
+		t.Parallel()
+
+		nonce := big.NewInt(7).Bytes()
+		tl, _ := NewTokenLedger(createMockArgsTokenLedger())
+		err := tl.ProcessOutportBlock(createOutportBlock(0,
+			createEvent(core.BuiltInFunctionESDTNFTCreate, alice, []byte("SFT-abcdef"), nonce, big.NewInt(10).Bytes(), []byte("data")),
+			createEvent(core.BuiltInFunctionESDTNFTAddQuantity, alice, []byte("SFT-abcdef"), nonce, big.NewInt(5).Bytes()),
+			createEvent(core.BuiltInFunctionMultiESDTNFTTransfer, alice,
+				[]byte("SFT-abcdef"), nonce, big.NewInt(3).Bytes(),
+				[]byte(token), nil, big.NewInt(0).Bytes(),
+				bob),
+			createEvent(core.BuiltInFunctionESDTNFTBurn, alice, []byte("SFT-abcdef"), nonce, big.NewInt(2).Bytes()),
+			createEvent(core.BuiltInFunctionESDTWipe, alice, []byte("SFT-abcdef"), nonce, big.NewInt(3).Bytes(), bob),
+		))
+		require.Nil(t, err)
+
+		assert.Equal(t, big.NewInt(10), tl.GetBalance(encode(alice), "SFT-abcdef", 7))
+		assert.Zero(t, tl.GetBalance(encode(bob), "SFT-abcdef", 7).Sign())
+
+		supply, _ := tl.GetSupply("SFT-abcdef", 7)
+		assert.Equal(t, "10", supply.Supply)
+		assert.Equal(t, "15", supply.Minted)
+		assert.Equal(t, "5", supply.Burned)
+	})
+	t.Run("altered accounts should override the balances", func(t *testing.T) {
+		t.Parallel()
+
+		tl, _ := NewTokenLedger(createMockArgsTokenLedger())
+		outportBlock := createOutportBlock(0,
+			createEvent(core.BuiltInFunctionESDTLocalMint, alice, []byte(token), nil, big.NewInt(10).Bytes()),
+			createEvent(core.BuiltInFunctionESDTLocalMint, bob, []byte(token), nil, big.NewInt(10).Bytes()),
+		)
+		outportBlock.AlteredAccounts = map[string]*alteredAccount.AlteredAccount{
+			encode(alice): {Address: encode(alice), Tokens: []*alteredAccount.AccountTokenData{{Identifier: token, Balance: "12"}}},
+			encode(bob):   {Address: encode(bob), Tokens: []*alteredAccount.AccountTokenData{{Identifier: token, Balance: "0"}}},
+		}
+		require.Nil(t, tl.ProcessOutportBlock(outportBlock))
+
+		assert.Equal(t, big.NewInt(12), tl.GetBalance(encode(alice), token, 0))
+		assert.Zero(t, tl.GetBalance(encode(bob), token, 0).Sign())
+	})
+	t.Run("invalid block should not apply anything", func(t *testing.T) {
+		t.Parallel()
+
+		tl, _ := NewTokenLedger(createMockArgsTokenLedger())
+		err := tl.ProcessOutportBlock(createOutportBlock(0,
+			createEvent(core.BuiltInFunctionESDTLocalMint, alice, []byte(token), nil, big.NewInt(10).Bytes()),
+			createEvent(core.BuiltInFunctionESDTTransfer, alice, []byte(token), nil),
+		))
+		assert.True(t, errors.Is(err, ErrInvalidEventTopics))
+		assert.Zero(t, tl.GetBalance(encode(alice), token, 0).Sign())
+
+		outportBlock := createOutportBlock(0,
+			createEvent(core.BuiltInFunctionESDTLocalMint, alice, []byte(token), nil, big.NewInt(10).Bytes()),
+		)
+		outportBlock.AlteredAccounts = map[string]*alteredAccount.AlteredAccount{
+			encode(alice): {Address: encode(alice), Tokens: []*alteredAccount.AccountTokenData{{Identifier: token, Balance: "ten"}}},
+		}
+		err = tl.ProcessOutportBlock(outportBlock)
+		assert.True(t, errors.Is(err, ErrInvalidTokenBalance))
+		_, found := tl.GetSupply(token, 0)
+		assert.False(t, found)
+	})
+}
+
+func TestTokenLedger_SnapshotRestore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("restored ledger should hold the same state", func(t *testing.T) {
+		t.Parallel()
+
+		tl, _ := NewTokenLedger(createMockArgsTokenLedger())
+		_ = tl.ProcessOutportBlock(createOutportBlock(0,
+			createEvent(core.BuiltInFunctionESDTTransfer, core.ESDTSCAddress, []byte(token), nil, big.NewInt(100).Bytes(), alice),
+			createEvent(core.BuiltInFunctionESDTTransfer, alice, []byte(token), nil, big.NewInt(40).Bytes(), bob),
+			createEvent(core.BuiltInFunctionESDTLocalBurn, bob, []byte(token), nil, big.NewInt(10).Bytes()),
+		))
+
+		buff, err := tl.Snapshot()
+		require.Nil(t, err)
+
+		restored, _ := NewTokenLedger(createMockArgsTokenLedger())
+		require.Nil(t, restored.Restore(buff))
+
+		assert.Equal(t, big.NewInt(60), restored.GetBalance(encode(alice), token, 0))
+		assert.Equal(t, big.NewInt(30), restored.GetBalance(encode(bob), token, 0))
+		expectedSupply, _ := tl.GetSupply(token, 0)
+		supply, _ := restored.GetSupply(token, 0)
+		assert.Equal(t, expectedSupply, supply)
+
+		restoredBuff, _ := restored.Snapshot()
+		assert.Equal(t, buff, restoredBuff)
+	})
+	t.Run("restored ledger should skip the already processed blocks", func(t *testing.T) {
+		t.Parallel()
+
+		tl, _ := NewTokenLedger(createMockArgsTokenLedger())
+		outportBlock := createOutportBlock(0,
+			createEvent(core.BuiltInFunctionESDTLocalMint, alice, []byte(token), nil, big.NewInt(10).Bytes()),
+		)
+		outportBlock.BlockData = &outport.BlockData{ShardID: 0, HeaderHash: []byte("hash")}
+		_ = tl.ProcessOutportBlock(outportBlock)
+		buff, _ := tl.Snapshot()
+
+		restored, _ := NewTokenLedger(createMockArgsTokenLedger())
+		require.Nil(t, restored.Restore(buff))
+		require.Nil(t, restored.ProcessOutportBlock(outportBlock))
+		supply, _ := restored.GetSupply(token, 0)
+		assert.Equal(t, "10", supply.Minted)
+
+		err := restored.Restore([]byte(`{"processedHeaders":[{"shardID":0,"headerHashes":["not hex"]}]}`))
+		assert.True(t, errors.Is(err, ErrInvalidSnapshot))
+	})
+	t.Run("should work with gogo proto marshalled outport blocks", func(t *testing.T) {
+		t.Parallel()
+
+		marshaller := &marshal.GogoProtoMarshalizer{}
+		outportBlockBuff, err := marshaller.Marshal(createOutportBlock(0,
+			createEvent(core.BuiltInFunctionESDTTransfer, core.ESDTSCAddress, []byte(token), nil, big.NewInt(100).Bytes(), alice),
+			createEvent(core.BuiltInFunctionESDTBurn, alice, []byte(token), nil, big.NewInt(10).Bytes()),
+		))
+		require.Nil(t, err)
+		outportBlock := &outport.OutportBlock{}
+		require.Nil(t, marshaller.Unmarshal(outportBlock, outportBlockBuff))
+
+		tl, _ := NewTokenLedger(createMockArgsTokenLedger())
+		require.Nil(t, tl.ProcessOutportBlock(outportBlock))
+		buff, err := tl.Snapshot()
+		require.Nil(t, err)
+
+		restored, _ := NewTokenLedger(createMockArgsTokenLedger())
+		require.Nil(t, restored.Restore(buff))
+		assert.Equal(t, big.NewInt(90), restored.GetBalance(encode(alice), token, 0))
+		supply, _ := restored.GetSupply(token, 0)
+		assert.Equal(t, "10", supply.Burned)
+	})
+	t.Run("gogo proto marshaller can not serialize the snapshot", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsTokenLedger()
+		args.Marshaller = &marshal.GogoProtoMarshalizer{}
+		tl, _ := NewTokenLedger(args)
+
+		buff, err := tl.Snapshot()
+		assert.Nil(t, buff)
+		assert.True(t, errors.Is(err, marshal.ErrMarshallingProto))
+	})
+	t.Run("invalid snapshot should keep the state", func(t *testing.T) {
+		t.Parallel()
+
+		tl, _ := NewTokenLedger(createMockArgsTokenLedger())
+		_ = tl.ProcessOutportBlock(createOutportBlock(0,
+			createEvent(core.BuiltInFunctionESDTLocalMint, alice, []byte(token), nil, big.NewInt(10).Bytes()),
+		))
+
+		err := tl.Restore([]byte(`{"balances":[{"address":"bob","identifier":"TKN-abcdef","balance":"-1"}]}`))
+		assert.True(t, errors.Is(err, ErrInvalidSnapshot))
+		assert.Equal(t, big.NewInt(10), tl.GetBalance(encode(alice), token, 0))
+
+		err = tl.Restore([]byte("not a snapshot"))
+		assert.NotNil(t, err)
+		assert.Equal(t, big.NewInt(10), tl.GetBalance(encode(alice), token, 0))
+	})
+}
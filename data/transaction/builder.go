@@ -0,0 +1,430 @@
+package transaction
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/crypto"
+	"github.com/multiversx/mx-chain-core-go/data"
+)
+
+// ArgsTxBuilder holds the arguments needed to create a new transaction builder
+type ArgsTxBuilder struct {
+	Encoder      data.Encoder
+	Marshaller   data.Marshaller
+	Hasher       data.Hasher
+	MinTxVersion uint32
+}
+
+// txBuilder is able to construct, validate and sign a transaction using a fluent API.
+// The first error encountered while setting fields is retained and returned when building the transaction
+type txBuilder struct {
+	encoder        data.Encoder
+	marshaller     data.Marshaller
+	hasher         data.Hasher
+	minTxVersion   uint32
+	tx             *Transaction
+	senderSigner   crypto.Signer
+	guardianSigner crypto.Signer
+	relayerSigner  crypto.Signer
+	err            error
+}
+
+// NewTxBuilder creates a new transaction builder instance
+func NewTxBuilder(args ArgsTxBuilder) (*txBuilder, error) {
+	if check.IfNil(args.Encoder) {
+		return nil, ErrNilEncoder
+	}
+	if check.IfNil(args.Marshaller) {
+		return nil, ErrNilMarshalizer
+	}
+	if check.IfNil(args.Hasher) {
+		return nil, ErrNilHasher
+	}
+	if args.MinTxVersion < core.InitialVersionOfTransaction {
+		return nil, fmt.Errorf("%w, provided min version %d", ErrInvalidTxVersion, args.MinTxVersion)
+	}
+
+	return &txBuilder{
+		encoder:      args.Encoder,
+		marshaller:   args.Marshaller,
+		hasher:       args.Hasher,
+		minTxVersion: args.MinTxVersion,
+		tx: &Transaction{
+			Value:   big.NewInt(0),
+			Version: args.MinTxVersion,
+		},
+	}, nil
+}
+
+// WithNonce sets the transaction nonce
+func (tb *txBuilder) WithNonce(nonce uint64) *txBuilder {
+	if tb.err != nil {
+		return tb
+	}
+	tb.tx.Nonce = nonce
+	return tb
+}
+
+// WithValue sets the transaction value. The value should be non-nil and not negative
+func (tb *txBuilder) WithValue(value *big.Int) *txBuilder {
+	if tb.err != nil {
+		return tb
+	}
+	if value == nil {
+		tb.err = data.ErrNilValue
+		return tb
+	}
+	if value.Sign() < 0 {
+		tb.err = data.ErrNegativeValue
+		return tb
+	}
+
+	tb.tx.Value = new(big.Int).Set(value)
+	return tb
+}
+
+// WithSender sets the sender address and the signer that will produce the sender's signature
+func (tb *txBuilder) WithSender(address []byte, signer crypto.Signer) *txBuilder {
+	if tb.err != nil {
+		return tb
+	}
+	if len(address) == 0 {
+		tb.err = ErrEmptySenderAddress
+		return tb
+	}
+	if check.IfNil(signer) {
+		tb.err = fmt.Errorf("%w for sender", ErrNilTxSigner)
+		return tb
+	}
+
+	tb.tx.SndAddr = address
+	tb.senderSigner = signer
+	return tb
+}
+
+// WithReceiver sets the receiver address
+func (tb *txBuilder) WithReceiver(address []byte) *txBuilder {
+	if tb.err != nil {
+		return tb
+	}
+	if len(address) == 0 {
+		tb.err = ErrEmptyReceiverAddress
+		return tb
+	}
+
+	tb.tx.RcvAddr = address
+	return tb
+}
+
+// WithSenderUsername sets the sender's username
+func (tb *txBuilder) WithSenderUsername(username []byte) *txBuilder {
+	if tb.err != nil {
+		return tb
+	}
+	if len(username) > core.MaxUserNameLength {
+		tb.err = fmt.Errorf("%w for sender username", data.ErrInvalidUserNameLength)
+		return tb
+	}
+
+	tb.tx.SndUserName = username
+	return tb
+}
+
+// WithReceiverUsername sets the receiver's username
+func (tb *txBuilder) WithReceiverUsername(username []byte) *txBuilder {
+	if tb.err != nil {
+		return tb
+	}
+	if len(username) > core.MaxUserNameLength {
+		tb.err = fmt.Errorf("%w for receiver username", data.ErrInvalidUserNameLength)
+		return tb
+	}
+
+	tb.tx.RcvUserName = username
+	return tb
+}
+
+// WithGasPrice sets the gas price
+func (tb *txBuilder) WithGasPrice(gasPrice uint64) *txBuilder {
+	if tb.err != nil {
+		return tb
+	}
+	tb.tx.GasPrice = gasPrice
+	return tb
+}
+
+// WithGasLimit sets the gas limit
+func (tb *txBuilder) WithGasLimit(gasLimit uint64) *txBuilder {
+	if tb.err != nil {
+		return tb
+	}
+	tb.tx.GasLimit = gasLimit
+	return tb
+}
+
+// WithData sets the data field
+func (tb *txBuilder) WithData(txData []byte) *txBuilder {
+	if tb.err != nil {
+		return tb
+	}
+	tb.tx.Data = txData
+	return tb
+}
+
+// WithChainID sets the chain ID
+func (tb *txBuilder) WithChainID(chainID []byte) *txBuilder {
+	if tb.err != nil {
+		return tb
+	}
+	if len(chainID) == 0 {
+		tb.err = ErrEmptyChainID
+		return tb
+	}
+
+	tb.tx.ChainID = chainID
+	return tb
+}
+
+// WithVersion sets the transaction version. The version should not be lower than the configured minimum version
+func (tb *txBuilder) WithVersion(version uint32) *txBuilder {
+	if tb.err != nil {
+		return tb
+	}
+	if version < tb.minTxVersion {
+		tb.err = fmt.Errorf("%w, provided version %d, min version %d", ErrInvalidTxVersion, version, tb.minTxVersion)
+		return tb
+	}
+
+	tb.tx.Version = version
+	return tb
+}
+
+// WithSignedWithHash sets the option that will cause the signatures to be applied on the hash of the marshalled transaction
+func (tb *txBuilder) WithSignedWithHash() *txBuilder {
+	if tb.err != nil {
+		return tb
+	}
+	tb.tx.Options |= MaskSignedWithHash
+	return tb
+}
+
+// WithGuardian sets the guardian address, the guarded transaction option and the signer that will produce
+// the guardian's signature
+func (tb *txBuilder) WithGuardian(address []byte, signer crypto.Signer) *txBuilder {
+	if tb.err != nil {
+		return tb
+	}
+	if len(address) == 0 {
+		tb.err = ErrEmptyGuardianAddress
+		return tb
+	}
+	if check.IfNil(signer) {
+		tb.err = fmt.Errorf("%w for guardian", ErrNilTxSigner)
+		return tb
+	}
+
+	tb.tx.GuardianAddr = address
+	tb.tx.Options |= MaskGuardedTransaction
+	tb.guardianSigner = signer
+	return tb
+}
+
+// WithRelayer sets the relayer address and the signer that will produce the relayer's signature
+func (tb *txBuilder) WithRelayer(address []byte, signer crypto.Signer) *txBuilder {
+	if tb.err != nil {
+		return tb
+	}
+	if len(address) == 0 {
+		tb.err = ErrEmptyRelayerAddress
+		return tb
+	}
+	if check.IfNil(signer) {
+		tb.err = fmt.Errorf("%w for relayer", ErrNilTxSigner)
+		return tb
+	}
+
+	tb.tx.RelayerAddr = address
+	tb.relayerSigner = signer
+	return tb
+}
+
+// SigningPayload validates the fields set so far and returns the bytes that have to be signed by the
+// sender, guardian and relayer
+func (tb *txBuilder) SigningPayload() ([]byte, error) {
+	err := tb.checkTransaction()
+	if err != nil {
+		return nil, err
+	}
+
+	return tb.tx.GetDataForSigning(tb.encoder, tb.marshaller, tb.hasher)
+}
+
+// Build validates the fields, computes the signing payload and returns a new transaction signed by all
+// the required parties. Each signer's public key has to be the address it signs for
+func (tb *txBuilder) Build() (*Transaction, error) {
+	payload, err := tb.SigningPayload()
+	if err != nil {
+		return nil, err
+	}
+
+	tx := tb.copyTransaction()
+	err = tb.checkSigners(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx.Signature, err = tb.senderSigner.Sign(payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w while signing as sender", err)
+	}
+
+	if tx.HasOptionGuardianSet() {
+		tx.GuardianSignature, err = tb.guardianSigner.Sign(payload)
+		if err != nil {
+			return nil, fmt.Errorf("%w while signing as guardian", err)
+		}
+	}
+
+	if len(tx.RelayerAddr) > 0 {
+		tx.RelayerSignature, err = tb.relayerSigner.Sign(payload)
+		if err != nil {
+			return nil, fmt.Errorf("%w while signing as relayer", err)
+		}
+	}
+
+	return tx, nil
+}
+
+// BuildFrontendTransaction builds the signed transaction and returns it along with its frontend representation,
+// ready to be sent to a proxy or an observer
+func (tb *txBuilder) BuildFrontendTransaction() (*Transaction, *FrontendTransaction, error) {
+	tx, err := tb.Build()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ftx, err := ConvertToFrontendTransaction(tx, tb.encoder)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tx, ftx, nil
+}
+
+func (tb *txBuilder) checkTransaction() error {
+	if tb.err != nil {
+		return tb.err
+	}
+	if len(tb.tx.SndAddr) == 0 {
+		return ErrEmptySenderAddress
+	}
+	if len(tb.tx.RcvAddr) == 0 {
+		return ErrEmptyReceiverAddress
+	}
+	if len(tb.tx.ChainID) == 0 {
+		return ErrEmptyChainID
+	}
+	if tb.tx.Version == core.InitialVersionOfTransaction && tb.tx.Options != 0 {
+		return fmt.Errorf("%w, version %d, options %d", ErrOptionsNotAllowedForVersion, tb.tx.Version, tb.tx.Options)
+	}
+	if bytes.Equal(tb.tx.GuardianAddr, tb.tx.SndAddr) {
+		return ErrGuardianSameAsSender
+	}
+	if bytes.Equal(tb.tx.RelayerAddr, tb.tx.SndAddr) {
+		return ErrRelayerSameAsSender
+	}
+
+	return nil
+}
+
+// checkSigners verifies that the public key of each signer is the address it signs for
+func (tb *txBuilder) checkSigners(tx *Transaction) error {
+	if !bytes.Equal(tb.senderSigner.PublicKey(), tx.SndAddr) {
+		return fmt.Errorf("%w for sender", ErrSignerAddressMismatch)
+	}
+	if tx.HasOptionGuardianSet() && !bytes.Equal(tb.guardianSigner.PublicKey(), tx.GuardianAddr) {
+		return fmt.Errorf("%w for guardian", ErrSignerAddressMismatch)
+	}
+	if len(tx.RelayerAddr) > 0 && !bytes.Equal(tb.relayerSigner.PublicKey(), tx.RelayerAddr) {
+		return fmt.Errorf("%w for relayer", ErrSignerAddressMismatch)
+	}
+
+	return nil
+}
+
+func (tb *txBuilder) copyTransaction() *Transaction {
+	tx := *tb.tx
+	tx.Value = new(big.Int).Set(tb.tx.Value)
+
+	return &tx
+}
+
+// ConvertToFrontendTransaction converts the provided transaction into its frontend representation.
+// The addresses are encoded using the provided encoder while the signatures are hex encoded
+func ConvertToFrontendTransaction(tx *Transaction, encoder data.Encoder) (*FrontendTransaction, error) {
+	if tx == nil {
+		return nil, ErrNilTransaction
+	}
+	if check.IfNil(encoder) {
+		return nil, ErrNilEncoder
+	}
+
+	receiverAddr, err := encoder.Encode(tx.RcvAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	senderAddr, err := encoder.Encode(tx.SndAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	value := "0"
+	if tx.Value != nil {
+		value = tx.Value.String()
+	}
+
+	ftx := &FrontendTransaction{
+		Nonce:            tx.Nonce,
+		Value:            value,
+		Receiver:         receiverAddr,
+		Sender:           senderAddr,
+		SenderUsername:   tx.SndUserName,
+		ReceiverUsername: tx.RcvUserName,
+		GasPrice:         tx.GasPrice,
+		GasLimit:         tx.GasLimit,
+		Data:             tx.Data,
+		Signature:        hex.EncodeToString(tx.Signature),
+		ChainID:          string(tx.ChainID),
+		Version:          tx.Version,
+		Options:          tx.Options,
+	}
+
+	if len(tx.GuardianAddr) > 0 {
+		ftx.GuardianAddr, err = encoder.Encode(tx.GuardianAddr)
+		if err != nil {
+			return nil, err
+		}
+		ftx.GuardianSignature = hex.EncodeToString(tx.GuardianSignature)
+	}
+
+	if len(tx.RelayerAddr) > 0 {
+		ftx.RelayerAddr, err = encoder.Encode(tx.RelayerAddr)
+		if err != nil {
+			return nil, err
+		}
+		ftx.RelayerSignature = hex.EncodeToString(tx.RelayerSignature)
+	}
+
+	return ftx, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (tb *txBuilder) IsInterfaceNil() bool {
+	return tb == nil
+}
@@ -0,0 +1,378 @@
+package transaction_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data"
+	"github.com/multiversx/mx-chain-core-go/data/mock"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMockArgsTxBuilder() transaction.ArgsTxBuilder {
+	return transaction.ArgsTxBuilder{
+		Encoder: &mock.PubkeyConverterStub{
+			EncodeCalled: func(pkBytes []byte) (string, error) {
+				return string(pkBytes), nil
+			},
+		},
+		Marshaller:   &mock.MarshalizerMock{},
+		Hasher:       &mock.HasherMock{},
+		MinTxVersion: 1,
+	}
+}
+
+func createSignerStub(prefix string, address string) *mock.TxSignerStub {
+	return &mock.TxSignerStub{
+		SignCalled: func(message []byte) ([]byte, error) {
+			return append([]byte(prefix), message...), nil
+		},
+		PublicKeyCalled: func() []byte {
+			return []byte(address)
+		},
+	}
+}
+
+func TestNewTxBuilder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil encoder should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsTxBuilder()
+		args.Encoder = nil
+		tb, err := transaction.NewTxBuilder(args)
+		assert.Equal(t, transaction.ErrNilEncoder, err)
+		assert.True(t, check.IfNil(tb))
+	})
+	t.Run("nil marshaller should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsTxBuilder()
+		args.Marshaller = nil
+		tb, err := transaction.NewTxBuilder(args)
+		assert.Equal(t, transaction.ErrNilMarshalizer, err)
+		assert.True(t, check.IfNil(tb))
+	})
+	t.Run("nil hasher should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsTxBuilder()
+		args.Hasher = nil
+		tb, err := transaction.NewTxBuilder(args)
+		assert.Equal(t, transaction.ErrNilHasher, err)
+		assert.True(t, check.IfNil(tb))
+	})
+	t.Run("invalid min version should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsTxBuilder()
+		args.MinTxVersion = 0
+		tb, err := transaction.NewTxBuilder(args)
+		assert.True(t, errors.Is(err, transaction.ErrInvalidTxVersion))
+		assert.True(t, check.IfNil(tb))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		tb, err := transaction.NewTxBuilder(createMockArgsTxBuilder())
+		assert.Nil(t, err)
+		assert.False(t, check.IfNil(tb))
+	})
+}
+
+func TestTxBuilder_ValidationErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil value", func(t *testing.T) {
+		t.Parallel()
+
+		tb, _ := transaction.NewTxBuilder(createMockArgsTxBuilder())
+		tx, err := tb.WithValue(nil).Build()
+		assert.Nil(t, tx)
+		assert.Equal(t, data.ErrNilValue, err)
+	})
+	t.Run("negative value", func(t *testing.T) {
+		t.Parallel()
+
+		tb, _ := transaction.NewTxBuilder(createMockArgsTxBuilder())
+		tx, err := tb.WithValue(big.NewInt(-1)).Build()
+		assert.Nil(t, tx)
+		assert.Equal(t, data.ErrNegativeValue, err)
+	})
+	t.Run("nil sender signer", func(t *testing.T) {
+		t.Parallel()
+
+		tb, _ := transaction.NewTxBuilder(createMockArgsTxBuilder())
+		tx, err := tb.WithSender([]byte("sender"), nil).Build()
+		assert.Nil(t, tx)
+		assert.True(t, errors.Is(err, transaction.ErrNilTxSigner))
+	})
+	t.Run("username too long", func(t *testing.T) {
+		t.Parallel()
+
+		tb, _ := transaction.NewTxBuilder(createMockArgsTxBuilder())
+		tx, err := tb.WithReceiverUsername([]byte("invalid-username-length-exceeds-max-allowed-length")).Build()
+		assert.Nil(t, tx)
+		assert.True(t, errors.Is(err, data.ErrInvalidUserNameLength))
+	})
+	t.Run("version lower than min version", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsTxBuilder()
+		args.MinTxVersion = 2
+		tb, _ := transaction.NewTxBuilder(args)
+		tx, err := tb.WithVersion(1).Build()
+		assert.Nil(t, tx)
+		assert.True(t, errors.Is(err, transaction.ErrInvalidTxVersion))
+	})
+	t.Run("first error is kept", func(t *testing.T) {
+		t.Parallel()
+
+		tb, _ := transaction.NewTxBuilder(createMockArgsTxBuilder())
+		tx, err := tb.
+			WithReceiver(nil).
+			WithChainID(nil).
+			WithSender([]byte("sender"), createSignerStub("s", "sender")).
+			Build()
+		assert.Nil(t, tx)
+		assert.Equal(t, transaction.ErrEmptyReceiverAddress, err)
+	})
+	t.Run("missing mandatory fields", func(t *testing.T) {
+		t.Parallel()
+
+		tb, _ := transaction.NewTxBuilder(createMockArgsTxBuilder())
+		_, err := tb.Build()
+		assert.Equal(t, transaction.ErrEmptySenderAddress, err)
+
+		tb.WithSender([]byte("sender"), createSignerStub("s", "sender"))
+		_, err = tb.Build()
+		assert.Equal(t, transaction.ErrEmptyReceiverAddress, err)
+
+		tb.WithReceiver([]byte("receiver"))
+		_, err = tb.Build()
+		assert.Equal(t, transaction.ErrEmptyChainID, err)
+	})
+	t.Run("options on initial version", func(t *testing.T) {
+		t.Parallel()
+
+		tb, _ := transaction.NewTxBuilder(createMockArgsTxBuilder())
+		tx, err := tb.
+			WithSender([]byte("sender"), createSignerStub("s", "sender")).
+			WithReceiver([]byte("receiver")).
+			WithChainID([]byte("T")).
+			WithSignedWithHash().
+			Build()
+		assert.Nil(t, tx)
+		assert.True(t, errors.Is(err, transaction.ErrOptionsNotAllowedForVersion))
+	})
+	t.Run("relayer same as sender", func(t *testing.T) {
+		t.Parallel()
+
+		tb, _ := transaction.NewTxBuilder(createMockArgsTxBuilder())
+		tx, err := tb.
+			WithSender([]byte("sender"), createSignerStub("s", "sender")).
+			WithReceiver([]byte("receiver")).
+			WithChainID([]byte("T")).
+			WithRelayer([]byte("sender"), createSignerStub("r", "relayer")).
+			Build()
+		assert.Nil(t, tx)
+		assert.Equal(t, transaction.ErrRelayerSameAsSender, err)
+	})
+	t.Run("guardian same as sender", func(t *testing.T) {
+		t.Parallel()
+
+		tb, _ := transaction.NewTxBuilder(createMockArgsTxBuilder())
+		tx, err := tb.
+			WithSender([]byte("sender"), createSignerStub("s", "sender")).
+			WithReceiver([]byte("receiver")).
+			WithChainID([]byte("T")).
+			WithVersion(2).
+			WithGuardian([]byte("sender"), createSignerStub("g", "guardian")).
+			Build()
+		assert.Nil(t, tx)
+		assert.Equal(t, transaction.ErrGuardianSameAsSender, err)
+	})
+	t.Run("signer error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		tb, _ := transaction.NewTxBuilder(createMockArgsTxBuilder())
+		tx, err := tb.
+			WithSender([]byte("sender"), &mock.TxSignerStub{
+				SignCalled: func(message []byte) ([]byte, error) {
+					return nil, expectedErr
+				},
+				PublicKeyCalled: func() []byte {
+					return []byte("sender")
+				},
+			}).
+			WithReceiver([]byte("receiver")).
+			WithChainID([]byte("T")).
+			Build()
+		assert.Nil(t, tx)
+		assert.True(t, errors.Is(err, expectedErr))
+	})
+}
+
+func TestTxBuilder_SignerAddressMismatch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sender signer", func(t *testing.T) {
+		t.Parallel()
+
+		tb, _ := transaction.NewTxBuilder(createMockArgsTxBuilder())
+		tx, err := tb.
+			WithSender([]byte("sender"), createSignerStub("s", "other")).
+			WithReceiver([]byte("receiver")).
+			WithChainID([]byte("T")).
+			Build()
+		assert.Nil(t, tx)
+		assert.True(t, errors.Is(err, transaction.ErrSignerAddressMismatch))
+		assert.Contains(t, err.Error(), "sender")
+	})
+	t.Run("guardian signer", func(t *testing.T) {
+		t.Parallel()
+
+		tb, _ := transaction.NewTxBuilder(createMockArgsTxBuilder())
+		tx, err := tb.
+			WithSender([]byte("sender"), createSignerStub("s", "sender")).
+			WithReceiver([]byte("receiver")).
+			WithChainID([]byte("T")).
+			WithVersion(2).
+			WithGuardian([]byte("guardian"), createSignerStub("g", "other")).
+			Build()
+		assert.Nil(t, tx)
+		assert.True(t, errors.Is(err, transaction.ErrSignerAddressMismatch))
+		assert.Contains(t, err.Error(), "guardian")
+	})
+	t.Run("relayer signer", func(t *testing.T) {
+		t.Parallel()
+
+		tb, _ := transaction.NewTxBuilder(createMockArgsTxBuilder())
+		tx, err := tb.
+			WithSender([]byte("sender"), createSignerStub("s", "sender")).
+			WithReceiver([]byte("receiver")).
+			WithChainID([]byte("T")).
+			WithRelayer([]byte("relayer"), createSignerStub("r", "other")).
+			Build()
+		assert.Nil(t, tx)
+		assert.True(t, errors.Is(err, transaction.ErrSignerAddressMismatch))
+		assert.Contains(t, err.Error(), "relayer")
+	})
+}
+
+func TestTxBuilder_BuildShouldWork(t *testing.T) {
+	t.Parallel()
+
+	t.Run("simple transaction", func(t *testing.T) {
+		t.Parallel()
+
+		tb, _ := transaction.NewTxBuilder(createMockArgsTxBuilder())
+		tx, err := tb.
+			WithNonce(7).
+			WithValue(big.NewInt(1000)).
+			WithSender([]byte("sender"), createSignerStub("s", "sender")).
+			WithReceiver([]byte("receiver")).
+			WithGasPrice(1000000000).
+			WithGasLimit(50000).
+			WithData([]byte("data")).
+			WithChainID([]byte("T")).
+			Build()
+		require.Nil(t, err)
+
+		payload, err := tx.GetDataForSigning(createMockArgsTxBuilder().Encoder, &mock.MarshalizerMock{}, &mock.HasherMock{})
+		require.Nil(t, err)
+		assert.Equal(t, append([]byte("s"), payload...), tx.Signature)
+		assert.Equal(t, uint64(7), tx.Nonce)
+		assert.Equal(t, big.NewInt(1000), tx.Value)
+		assert.Equal(t, uint32(1), tx.Version)
+		assert.Empty(t, tx.GuardianSignature)
+		assert.Empty(t, tx.RelayerSignature)
+		assert.Nil(t, tx.CheckIntegrity())
+	})
+	t.Run("guarded and relayed transaction signed with hash", func(t *testing.T) {
+		t.Parallel()
+
+		tb, _ := transaction.NewTxBuilder(createMockArgsTxBuilder())
+		tx, ftx, err := tb.
+			WithSender([]byte("sender"), createSignerStub("s", "sender")).
+			WithReceiver([]byte("receiver")).
+			WithChainID([]byte("T")).
+			WithVersion(2).
+			WithSignedWithHash().
+			WithGuardian([]byte("guardian"), createSignerStub("g", "guardian")).
+			WithRelayer([]byte("relayer"), createSignerStub("r", "relayer")).
+			BuildFrontendTransaction()
+		require.Nil(t, err)
+
+		payload, err := tb.SigningPayload()
+		require.Nil(t, err)
+		assert.Equal(t, mock.HasherMock{}.Size(), len(payload))
+		assert.True(t, tx.HasOptionGuardianSet())
+		assert.True(t, tx.HasOptionHashSignSet())
+		assert.Equal(t, append([]byte("s"), payload...), tx.Signature)
+		assert.Equal(t, append([]byte("g"), payload...), tx.GuardianSignature)
+		assert.Equal(t, append([]byte("r"), payload...), tx.RelayerSignature)
+
+		assert.Equal(t, "sender", ftx.Sender)
+		assert.Equal(t, "guardian", ftx.GuardianAddr)
+		assert.Equal(t, "relayer", ftx.RelayerAddr)
+		assert.Equal(t, hex.EncodeToString(tx.Signature), ftx.Signature)
+		assert.Equal(t, hex.EncodeToString(tx.GuardianSignature), ftx.GuardianSignature)
+		assert.Equal(t, hex.EncodeToString(tx.RelayerSignature), ftx.RelayerSignature)
+		assert.Equal(t, tx.Options, ftx.Options)
+
+		buff, err := json.Marshal(ftx)
+		require.Nil(t, err)
+		assert.Contains(t, string(buff), `"relayer":"relayer"`)
+	})
+	t.Run("built transactions are independent", func(t *testing.T) {
+		t.Parallel()
+
+		tb, _ := transaction.NewTxBuilder(createMockArgsTxBuilder())
+		tb.WithSender([]byte("sender"), createSignerStub("s", "sender")).
+			WithReceiver([]byte("receiver")).
+			WithChainID([]byte("T"))
+
+		tx1, err := tb.WithNonce(1).Build()
+		require.Nil(t, err)
+		tx2, err := tb.WithNonce(2).Build()
+		require.Nil(t, err)
+
+		assert.Equal(t, uint64(1), tx1.Nonce)
+		assert.Equal(t, uint64(2), tx2.Nonce)
+		assert.NotEqual(t, tx1.Signature, tx2.Signature)
+	})
+}
+
+func TestConvertToFrontendTransaction(t *testing.T) {
+	t.Parallel()
+
+	ftx, err := transaction.ConvertToFrontendTransaction(nil, &mock.PubkeyConverterStub{})
+	assert.Nil(t, ftx)
+	assert.Equal(t, transaction.ErrNilTransaction, err)
+
+	ftx, err = transaction.ConvertToFrontendTransaction(&transaction.Transaction{}, nil)
+	assert.Nil(t, ftx)
+	assert.Equal(t, transaction.ErrNilEncoder, err)
+
+	expectedErr := errors.New("expected error")
+	ftx, err = transaction.ConvertToFrontendTransaction(&transaction.Transaction{}, &mock.PubkeyConverterStub{
+		EncodeCalled: func(pkBytes []byte) (string, error) {
+			return "", expectedErr
+		},
+	})
+	assert.Nil(t, ftx)
+	assert.Equal(t, expectedErr, err)
+
+	ftx, err = transaction.ConvertToFrontendTransaction(&transaction.Transaction{}, &mock.PubkeyConverterStub{})
+	assert.Nil(t, err)
+	assert.Equal(t, "0", ftx.Value)
+}
@@ -0,0 +1,169 @@
+package dataField
+
+import "math/big"
+
+// BuiltInFunctionCall defines the behavior of a typed built-in function call extracted from a data field
+type BuiltInFunctionCall interface {
+	GetFunction() string
+}
+
+// CallData holds a function name along with its hex decoded arguments
+type CallData struct {
+	Function  string
+	Arguments [][]byte
+}
+
+// GetFunction returns the function name
+func (cd *CallData) GetFunction() string {
+	return cd.Function
+}
+
+// TokenTransfer holds the details of a single transferred token. Nonce is 0 for fungible tokens
+type TokenTransfer struct {
+	TokenIdentifier []byte
+	Nonce           uint64
+	Amount          *big.Int
+}
+
+// ESDTTransferCall holds the parsed arguments of an ESDTTransfer call. The destination is the transaction's receiver
+type ESDTTransferCall struct {
+	Function  string
+	Transfer  *TokenTransfer
+	InnerCall *CallData
+}
+
+// GetFunction returns the function name
+func (call *ESDTTransferCall) GetFunction() string {
+	return call.Function
+}
+
+// ESDTNFTTransferCall holds the parsed arguments of an ESDTNFTTransfer call. The transaction is sent to self and
+// the destination is provided as argument
+type ESDTNFTTransferCall struct {
+	Function    string
+	Transfer    *TokenTransfer
+	Destination []byte
+	InnerCall   *CallData
+}
+
+// GetFunction returns the function name
+func (call *ESDTNFTTransferCall) GetFunction() string {
+	return call.Function
+}
+
+// MultiESDTNFTTransferCall holds the parsed arguments of a MultiESDTNFTTransfer call
+type MultiESDTNFTTransferCall struct {
+	Function    string
+	Destination []byte
+	Transfers   []*TokenTransfer
+	InnerCall   *CallData
+}
+
+// GetFunction returns the function name
+func (call *MultiESDTNFTTransferCall) GetFunction() string {
+	return call.Function
+}
+
+// ESDTAmountCall holds the parsed arguments of the calls that operate on an amount of a fungible token, such as
+// ESDTLocalMint, ESDTLocalBurn or ESDTBurn
+type ESDTAmountCall struct {
+	Function        string
+	TokenIdentifier []byte
+	Amount          *big.Int
+}
+
+// GetFunction returns the function name
+func (call *ESDTAmountCall) GetFunction() string {
+	return call.Function
+}
+
+// ESDTNFTQuantityCall holds the parsed arguments of the calls that operate on a quantity of an NFT/SFT, such as
+// ESDTNFTAddQuantity or ESDTNFTBurn
+type ESDTNFTQuantityCall struct {
+	Function        string
+	TokenIdentifier []byte
+	Nonce           uint64
+	Quantity        *big.Int
+}
+
+// GetFunction returns the function name
+func (call *ESDTNFTQuantityCall) GetFunction() string {
+	return call.Function
+}
+
+// ESDTNFTCreateCall holds the parsed arguments of an ESDTNFTCreate call
+type ESDTNFTCreateCall struct {
+	Function        string
+	TokenIdentifier []byte
+	Quantity        *big.Int
+	Name            []byte
+	Royalties       uint32
+	Hash            []byte
+	Attributes      []byte
+	URIs            [][]byte
+}
+
+// GetFunction returns the function name
+func (call *ESDTNFTCreateCall) GetFunction() string {
+	return call.Function
+}
+
+// ESDTNFTAddURICall holds the parsed arguments of an ESDTNFTAddURI call
+type ESDTNFTAddURICall struct {
+	Function        string
+	TokenIdentifier []byte
+	Nonce           uint64
+	URIs            [][]byte
+}
+
+// GetFunction returns the function name
+func (call *ESDTNFTAddURICall) GetFunction() string {
+	return call.Function
+}
+
+// ESDTNFTUpdateAttributesCall holds the parsed arguments of an ESDTNFTUpdateAttributes call
+type ESDTNFTUpdateAttributesCall struct {
+	Function        string
+	TokenIdentifier []byte
+	Nonce           uint64
+	Attributes      []byte
+}
+
+// GetFunction returns the function name
+func (call *ESDTNFTUpdateAttributesCall) GetFunction() string {
+	return call.Function
+}
+
+// SetGuardianCall holds the parsed arguments of a SetGuardian call
+type SetGuardianCall struct {
+	Function        string
+	GuardianAddress []byte
+	ServiceUID      []byte
+}
+
+// GetFunction returns the function name
+func (call *SetGuardianCall) GetFunction() string {
+	return call.Function
+}
+
+// ChangeOwnerAddressCall holds the parsed arguments of a ChangeOwnerAddress call
+type ChangeOwnerAddressCall struct {
+	Function string
+	NewOwner []byte
+}
+
+// GetFunction returns the function name
+func (call *ChangeOwnerAddressCall) GetFunction() string {
+	return call.Function
+}
+
+// SetUserNameCall holds the parsed arguments of a SetUserName call
+type SetUserNameCall struct {
+	Function string
+	UserName []byte
+}
+
+// GetFunction returns the function name
+func (call *SetUserNameCall) GetFunction() string {
+	return call.Function
+}
@@ -0,0 +1,30 @@
+package dataField
+
+import "errors"
+
+// ErrEmptyDataField signals that an empty data field has been provided
+var ErrEmptyDataField = errors.New("empty data field")
+
+// ErrEmptyFunctionName signals that the data field does not contain a function name
+var ErrEmptyFunctionName = errors.New("empty function name")
+
+// ErrInvalidHexArgument signals that an argument is not a valid hex encoded string
+var ErrInvalidHexArgument = errors.New("invalid hex argument")
+
+// ErrNotBuiltInFunction signals that the data field does not call a known built-in function
+var ErrNotBuiltInFunction = errors.New("not a built-in function call")
+
+// ErrInvalidNumberOfArguments signals that an invalid number of arguments has been provided
+var ErrInvalidNumberOfArguments = errors.New("invalid number of arguments")
+
+// ErrInvalidNonce signals that an invalid nonce argument has been provided
+var ErrInvalidNonce = errors.New("invalid nonce")
+
+// ErrInvalidAmount signals that an invalid amount argument has been provided
+var ErrInvalidAmount = errors.New("invalid amount")
+
+// ErrEmptyTokenIdentifier signals that an empty token identifier has been provided
+var ErrEmptyTokenIdentifier = errors.New("empty token identifier")
+
+// ErrNilArgumentsParserFunc signals that a nil arguments parser function has been provided
+var ErrNilArgumentsParserFunc = errors.New("nil arguments parser function")
@@ -0,0 +1,379 @@
+package dataField
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+)
+
+const (
+	argumentsSeparator      = "@"
+	maxNonceBytes           = 8
+	maxRoyaltiesBytes       = 4
+	lenArgsSetGuardian      = 2
+	minLenArgsNFTCreate     = 7
+	minLenArgsNFTAddURI     = 3
+	lenArgsNFTQuantity      = 3
+	lenArgsNFTAttributes    = 3
+	minLenArgsMultiTransfer = 2
+	numArgsPerTransfer      = 3
+)
+
+// ArgumentsParserFunc defines the function able to convert the hex decoded arguments of a built-in function
+// into a typed call
+type ArgumentsParserFunc func(function string, args [][]byte) (BuiltInFunctionCall, error)
+
+type dataFieldParser struct {
+	mut     sync.RWMutex
+	parsers map[string]ArgumentsParserFunc
+}
+
+// NewDataFieldParser creates a new data field parser instance having the known built-in functions already registered
+func NewDataFieldParser() *dataFieldParser {
+	dfp := &dataFieldParser{
+		parsers: make(map[string]ArgumentsParserFunc),
+	}
+
+	dfp.parsers[core.BuiltInFunctionESDTTransfer] = parseESDTTransfer
+	dfp.parsers[core.BuiltInFunctionESDTNFTTransfer] = parseESDTNFTTransfer
+	dfp.parsers[core.BuiltInFunctionMultiESDTNFTTransfer] = parseMultiESDTNFTTransfer
+	dfp.parsers[core.BuiltInFunctionESDTLocalMint] = parseESDTAmount
+	dfp.parsers[core.BuiltInFunctionESDTLocalBurn] = parseESDTAmount
+	dfp.parsers[core.BuiltInFunctionESDTBurn] = parseESDTAmount
+	dfp.parsers[core.BuiltInFunctionESDTNFTAddQuantity] = parseESDTNFTQuantity
+	dfp.parsers[core.BuiltInFunctionESDTNFTBurn] = parseESDTNFTQuantity
+	dfp.parsers[core.BuiltInFunctionESDTNFTCreate] = parseESDTNFTCreate
+	dfp.parsers[core.BuiltInFunctionESDTNFTAddURI] = parseESDTNFTAddURI
+	dfp.parsers[core.BuiltInFunctionESDTNFTUpdateAttributes] = parseESDTNFTUpdateAttributes
+	dfp.parsers[core.BuiltInFunctionSetGuardian] = parseSetGuardian
+	dfp.parsers[core.BuiltInFunctionGuardAccount] = parseNoArguments
+	dfp.parsers[core.BuiltInFunctionUnGuardAccount] = parseNoArguments
+	dfp.parsers[core.BuiltInFunctionClaimDeveloperRewards] = parseNoArguments
+	dfp.parsers[core.BuiltInFunctionChangeOwnerAddress] = parseChangeOwnerAddress
+	dfp.parsers[core.BuiltInFunctionSetUserName] = parseSetUserName
+
+	return dfp
+}
+
+// RegisterParser adds or replaces the arguments parser for the provided function
+func (dfp *dataFieldParser) RegisterParser(function string, parser ArgumentsParserFunc) error {
+	if len(function) == 0 {
+		return ErrEmptyFunctionName
+	}
+	if parser == nil {
+		return ErrNilArgumentsParserFunc
+	}
+
+	dfp.mut.Lock()
+	dfp.parsers[function] = parser
+	dfp.mut.Unlock()
+
+	return nil
+}
+
+// IsBuiltInFunction returns true if the provided function has a registered parser
+func (dfp *dataFieldParser) IsBuiltInFunction(function string) bool {
+	dfp.mut.RLock()
+	_, found := dfp.parsers[function]
+	dfp.mut.RUnlock()
+
+	return found
+}
+
+// Parse splits the provided data field and returns the typed built-in function call.
+// It errors with ErrNotBuiltInFunction if the called function does not have a registered parser
+func (dfp *dataFieldParser) Parse(dataField []byte) (BuiltInFunctionCall, error) {
+	callData, err := SplitDataField(dataField)
+	if err != nil {
+		return nil, err
+	}
+
+	dfp.mut.RLock()
+	parser, found := dfp.parsers[callData.Function]
+	dfp.mut.RUnlock()
+	if !found {
+		return nil, fmt.Errorf("%w, function %s", ErrNotBuiltInFunction, callData.Function)
+	}
+
+	call, err := parser(callData.Function, callData.Arguments)
+	if err != nil {
+		return nil, fmt.Errorf("%w for function %s", err, callData.Function)
+	}
+
+	return call, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (dfp *dataFieldParser) IsInterfaceNil() bool {
+	return dfp == nil
+}
+
+// SplitDataField splits the provided data field of the form function@arg1@arg2 into the function name and
+// the hex decoded arguments
+func SplitDataField(dataField []byte) (*CallData, error) {
+	if len(dataField) == 0 {
+		return nil, ErrEmptyDataField
+	}
+
+	tokens := strings.Split(string(dataField), argumentsSeparator)
+	if len(tokens[0]) == 0 {
+		return nil, ErrEmptyFunctionName
+	}
+
+	args := make([][]byte, 0, len(tokens)-1)
+	for index, token := range tokens[1:] {
+		arg, err := hex.DecodeString(token)
+		if err != nil {
+			return nil, fmt.Errorf("%w at index %d: %s", ErrInvalidHexArgument, index, err.Error())
+		}
+
+		args = append(args, arg)
+	}
+
+	return &CallData{
+		Function:  tokens[0],
+		Arguments: args,
+	}, nil
+}
+
+func parseESDTTransfer(function string, args [][]byte) (BuiltInFunctionCall, error) {
+	if len(args) < core.MinLenArgumentsESDTTransfer {
+		return nil, fmt.Errorf("%w, got %d, min %d", ErrInvalidNumberOfArguments, len(args), core.MinLenArgumentsESDTTransfer)
+	}
+
+	transfer, err := parseTokenTransfer(args[0], nil, args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return &ESDTTransferCall{
+		Function:  function,
+		Transfer:  transfer,
+		InnerCall: parseInnerCall(args[core.MinLenArgumentsESDTTransfer:]),
+	}, nil
+}
+
+func parseESDTNFTTransfer(function string, args [][]byte) (BuiltInFunctionCall, error) {
+	if len(args) < core.MinLenArgumentsESDTNFTTransfer {
+		return nil, fmt.Errorf("%w, got %d, min %d", ErrInvalidNumberOfArguments, len(args), core.MinLenArgumentsESDTNFTTransfer)
+	}
+
+	transfer, err := parseTokenTransfer(args[0], args[1], args[2])
+	if err != nil {
+		return nil, err
+	}
+
+	return &ESDTNFTTransferCall{
+		Function:    function,
+		Transfer:    transfer,
+		Destination: args[3],
+		InnerCall:   parseInnerCall(args[core.MinLenArgumentsESDTNFTTransfer:]),
+	}, nil
+}
+
+func parseMultiESDTNFTTransfer(function string, args [][]byte) (BuiltInFunctionCall, error) {
+	if len(args) < minLenArgsMultiTransfer {
+		return nil, fmt.Errorf("%w, got %d, min %d", ErrInvalidNumberOfArguments, len(args), minLenArgsMultiTransfer)
+	}
+
+	numTransfers := big.NewInt(0).SetBytes(args[1])
+	maxTransfers := (len(args) - minLenArgsMultiTransfer) / numArgsPerTransfer
+	if !numTransfers.IsUint64() || numTransfers.Uint64() == 0 || numTransfers.Uint64() > uint64(maxTransfers) {
+		return nil, fmt.Errorf("%w, invalid number of transfers %s", ErrInvalidNumberOfArguments, numTransfers.String())
+	}
+
+	transfers := make([]*TokenTransfer, 0, numTransfers.Uint64())
+	index := minLenArgsMultiTransfer
+	for i := uint64(0); i < numTransfers.Uint64(); i++ {
+		transfer, err := parseTokenTransfer(args[index], args[index+1], args[index+2])
+		if err != nil {
+			return nil, fmt.Errorf("%w for transfer %d", err, i)
+		}
+
+		transfers = append(transfers, transfer)
+		index += numArgsPerTransfer
+	}
+
+	return &MultiESDTNFTTransferCall{
+		Function:    function,
+		Destination: args[0],
+		Transfers:   transfers,
+		InnerCall:   parseInnerCall(args[index:]),
+	}, nil
+}
+
+func parseESDTAmount(function string, args [][]byte) (BuiltInFunctionCall, error) {
+	if len(args) != core.MinLenArgumentsESDTTransfer {
+		return nil, fmt.Errorf("%w, got %d, expected %d", ErrInvalidNumberOfArguments, len(args), core.MinLenArgumentsESDTTransfer)
+	}
+
+	transfer, err := parseTokenTransfer(args[0], nil, args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return &ESDTAmountCall{
+		Function:        function,
+		TokenIdentifier: transfer.TokenIdentifier,
+		Amount:          transfer.Amount,
+	}, nil
+}
+
+func parseESDTNFTQuantity(function string, args [][]byte) (BuiltInFunctionCall, error) {
+	if len(args) != lenArgsNFTQuantity {
+		return nil, fmt.Errorf("%w, got %d, expected %d", ErrInvalidNumberOfArguments, len(args), lenArgsNFTQuantity)
+	}
+
+	transfer, err := parseTokenTransfer(args[0], args[1], args[2])
+	if err != nil {
+		return nil, err
+	}
+
+	return &ESDTNFTQuantityCall{
+		Function:        function,
+		TokenIdentifier: transfer.TokenIdentifier,
+		Nonce:           transfer.Nonce,
+		Quantity:        transfer.Amount,
+	}, nil
+}
+
+func parseESDTNFTCreate(function string, args [][]byte) (BuiltInFunctionCall, error) {
+	if len(args) < minLenArgsNFTCreate {
+		return nil, fmt.Errorf("%w, got %d, min %d", ErrInvalidNumberOfArguments, len(args), minLenArgsNFTCreate)
+	}
+
+	transfer, err := parseTokenTransfer(args[0], nil, args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	royalties := big.NewInt(0).SetBytes(args[3])
+	if len(args[3]) > maxRoyaltiesBytes || royalties.Uint64() > uint64(core.MaxRoyalty) {
+		return nil, fmt.Errorf("%w, royalties %s exceed max %d", ErrInvalidAmount, royalties.String(), core.MaxRoyalty)
+	}
+
+	return &ESDTNFTCreateCall{
+		Function:        function,
+		TokenIdentifier: transfer.TokenIdentifier,
+		Quantity:        transfer.Amount,
+		Name:            args[2],
+		Royalties:       uint32(royalties.Uint64()),
+		Hash:            args[4],
+		Attributes:      args[5],
+		URIs:            args[6:],
+	}, nil
+}
+
+func parseESDTNFTAddURI(function string, args [][]byte) (BuiltInFunctionCall, error) {
+	if len(args) < minLenArgsNFTAddURI {
+		return nil, fmt.Errorf("%w, got %d, min %d", ErrInvalidNumberOfArguments, len(args), minLenArgsNFTAddURI)
+	}
+
+	transfer, err := parseTokenTransfer(args[0], args[1], nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ESDTNFTAddURICall{
+		Function:        function,
+		TokenIdentifier: transfer.TokenIdentifier,
+		Nonce:           transfer.Nonce,
+		URIs:            args[2:],
+	}, nil
+}
+
+func parseESDTNFTUpdateAttributes(function string, args [][]byte) (BuiltInFunctionCall, error) {
+	if len(args) != lenArgsNFTAttributes {
+		return nil, fmt.Errorf("%w, got %d, expected %d", ErrInvalidNumberOfArguments, len(args), lenArgsNFTAttributes)
+	}
+
+	transfer, err := parseTokenTransfer(args[0], args[1], nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ESDTNFTUpdateAttributesCall{
+		Function:        function,
+		TokenIdentifier: transfer.TokenIdentifier,
+		Nonce:           transfer.Nonce,
+		Attributes:      args[2],
+	}, nil
+}
+
+func parseSetGuardian(function string, args [][]byte) (BuiltInFunctionCall, error) {
+	if len(args) != lenArgsSetGuardian {
+		return nil, fmt.Errorf("%w, got %d, expected %d", ErrInvalidNumberOfArguments, len(args), lenArgsSetGuardian)
+	}
+
+	return &SetGuardianCall{
+		Function:        function,
+		GuardianAddress: args[0],
+		ServiceUID:      args[1],
+	}, nil
+}
+
+func parseChangeOwnerAddress(function string, args [][]byte) (BuiltInFunctionCall, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("%w, got %d, expected 1", ErrInvalidNumberOfArguments, len(args))
+	}
+
+	return &ChangeOwnerAddressCall{
+		Function: function,
+		NewOwner: args[0],
+	}, nil
+}
+
+func parseSetUserName(function string, args [][]byte) (BuiltInFunctionCall, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("%w, got %d, expected 1", ErrInvalidNumberOfArguments, len(args))
+	}
+
+	return &SetUserNameCall{
+		Function: function,
+		UserName: args[0],
+	}, nil
+}
+
+func parseNoArguments(function string, args [][]byte) (BuiltInFunctionCall, error) {
+	if len(args) != 0 {
+		return nil, fmt.Errorf("%w, got %d, expected 0", ErrInvalidNumberOfArguments, len(args))
+	}
+
+	return &CallData{
+		Function:  function,
+		Arguments: args,
+	}, nil
+}
+
+func parseTokenTransfer(tokenIdentifier []byte, nonce []byte, amount []byte) (*TokenTransfer, error) {
+	if len(tokenIdentifier) == 0 {
+		return nil, ErrEmptyTokenIdentifier
+	}
+	if len(nonce) > maxNonceBytes {
+		return nil, fmt.Errorf("%w, nonce has %d bytes", ErrInvalidNonce, len(nonce))
+	}
+	if len(amount) > core.MaxLenForESDTIssueMint {
+		return nil, fmt.Errorf("%w, amount has %d bytes", ErrInvalidAmount, len(amount))
+	}
+
+	return &TokenTransfer{
+		TokenIdentifier: tokenIdentifier,
+		Nonce:           big.NewInt(0).SetBytes(nonce).Uint64(),
+		Amount:          big.NewInt(0).SetBytes(amount),
+	}, nil
+}
+
+func parseInnerCall(args [][]byte) *CallData {
+	if len(args) == 0 {
+		return nil
+	}
+
+	return &CallData{
+		Function:  string(args[0]),
+		Arguments: args[1:],
+	}
+}
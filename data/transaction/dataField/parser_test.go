@@ -0,0 +1,234 @@
+package dataField
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitDataField(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty data field should error", func(t *testing.T) {
+		t.Parallel()
+
+		callData, err := SplitDataField(nil)
+		assert.Nil(t, callData)
+		assert.Equal(t, ErrEmptyDataField, err)
+	})
+	t.Run("empty function should error", func(t *testing.T) {
+		t.Parallel()
+
+		callData, err := SplitDataField([]byte("@01"))
+		assert.Nil(t, callData)
+		assert.Equal(t, ErrEmptyFunctionName, err)
+	})
+	t.Run("invalid hex argument should error", func(t *testing.T) {
+		t.Parallel()
+
+		callData, err := SplitDataField([]byte("func@01@0g"))
+		assert.Nil(t, callData)
+		assert.True(t, errors.Is(err, ErrInvalidHexArgument))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		callData, err := SplitDataField([]byte("func@01@@6162"))
+		require.Nil(t, err)
+		assert.Equal(t, "func", callData.GetFunction())
+		assert.Equal(t, [][]byte{{1}, {}, []byte("ab")}, callData.Arguments)
+
+		callData, err = SplitDataField([]byte("func"))
+		require.Nil(t, err)
+		assert.Equal(t, "func", callData.Function)
+		assert.Empty(t, callData.Arguments)
+	})
+}
+
+func TestDataFieldParser_RegisterParser(t *testing.T) {
+	t.Parallel()
+
+	dfp := NewDataFieldParser()
+	assert.False(t, check.IfNil(dfp))
+
+	err := dfp.RegisterParser("", parseNoArguments)
+	assert.Equal(t, ErrEmptyFunctionName, err)
+
+	err = dfp.RegisterParser("custom", nil)
+	assert.Equal(t, ErrNilArgumentsParserFunc, err)
+
+	assert.False(t, dfp.IsBuiltInFunction("custom"))
+	err = dfp.RegisterParser("custom", parseNoArguments)
+	assert.Nil(t, err)
+	assert.True(t, dfp.IsBuiltInFunction("custom"))
+
+	call, err := dfp.Parse([]byte("custom"))
+	require.Nil(t, err)
+	assert.Equal(t, "custom", call.GetFunction())
+}
+
+func TestDataFieldParser_Parse(t *testing.T) {
+	t.Parallel()
+
+	dfp := NewDataFieldParser()
+
+	t.Run("unknown function should error", func(t *testing.T) {
+		t.Parallel()
+
+		call, err := dfp.Parse([]byte("stake@01"))
+		assert.Nil(t, call)
+		assert.True(t, errors.Is(err, ErrNotBuiltInFunction))
+	})
+	t.Run("ESDTTransfer", func(t *testing.T) {
+		t.Parallel()
+
+		call, err := dfp.Parse([]byte("ESDTTransfer@544f4b454e2d616263646566@0de0b6b3a7640000"))
+		require.Nil(t, err)
+		esdtTransfer := call.(*ESDTTransferCall)
+		assert.Equal(t, []byte("TOKEN-abcdef"), esdtTransfer.Transfer.TokenIdentifier)
+		assert.Equal(t, uint64(0), esdtTransfer.Transfer.Nonce)
+		assert.Equal(t, big.NewInt(1000000000000000000), esdtTransfer.Transfer.Amount)
+		assert.Nil(t, esdtTransfer.InnerCall)
+
+		call, err = dfp.Parse([]byte("ESDTTransfer@544f4b454e@0a@73776170@01@02"))
+		require.Nil(t, err)
+		esdtTransfer = call.(*ESDTTransferCall)
+		assert.Equal(t, &CallData{Function: "swap", Arguments: [][]byte{{1}, {2}}}, esdtTransfer.InnerCall)
+
+		call, err = dfp.Parse([]byte("ESDTTransfer@544f4b454e"))
+		assert.Nil(t, call)
+		assert.True(t, errors.Is(err, ErrInvalidNumberOfArguments))
+
+		call, err = dfp.Parse([]byte("ESDTTransfer@@0a"))
+		assert.Nil(t, call)
+		assert.True(t, errors.Is(err, ErrEmptyTokenIdentifier))
+	})
+	t.Run("ESDTNFTTransfer", func(t *testing.T) {
+		t.Parallel()
+
+		call, err := dfp.Parse([]byte("ESDTNFTTransfer@4e46542d616263646566@05@01@64657374@636c61696d"))
+		require.Nil(t, err)
+		nftTransfer := call.(*ESDTNFTTransferCall)
+		assert.Equal(t, []byte("NFT-abcdef"), nftTransfer.Transfer.TokenIdentifier)
+		assert.Equal(t, uint64(5), nftTransfer.Transfer.Nonce)
+		assert.Equal(t, big.NewInt(1), nftTransfer.Transfer.Amount)
+		assert.Equal(t, []byte("dest"), nftTransfer.Destination)
+		assert.Equal(t, "claim", nftTransfer.InnerCall.Function)
+
+		call, err = dfp.Parse([]byte("ESDTNFTTransfer@4e4654@010203040506070809@01@64657374"))
+		assert.Nil(t, call)
+		assert.True(t, errors.Is(err, ErrInvalidNonce))
+	})
+	t.Run("MultiESDTNFTTransfer", func(t *testing.T) {
+		t.Parallel()
+
+		call, err := dfp.Parse([]byte("MultiESDTNFTTransfer@64657374@02@544f4b@@0a@4e4654@07@01@6d696e74"))
+		require.Nil(t, err)
+		multiTransfer := call.(*MultiESDTNFTTransferCall)
+		assert.Equal(t, []byte("dest"), multiTransfer.Destination)
+		require.Equal(t, 2, len(multiTransfer.Transfers))
+		assert.Equal(t, &TokenTransfer{TokenIdentifier: []byte("TOK"), Nonce: 0, Amount: big.NewInt(10)}, multiTransfer.Transfers[0])
+		assert.Equal(t, &TokenTransfer{TokenIdentifier: []byte("NFT"), Nonce: 7, Amount: big.NewInt(1)}, multiTransfer.Transfers[1])
+		assert.Equal(t, &CallData{Function: "mint", Arguments: [][]byte{}}, multiTransfer.InnerCall)
+
+		call, err = dfp.Parse([]byte("MultiESDTNFTTransfer@64657374@02@544f4b@@0a"))
+		assert.Nil(t, call)
+		assert.True(t, errors.Is(err, ErrInvalidNumberOfArguments))
+
+		call, err = dfp.Parse([]byte("MultiESDTNFTTransfer@64657374@00"))
+		assert.Nil(t, call)
+		assert.True(t, errors.Is(err, ErrInvalidNumberOfArguments))
+	})
+	t.Run("ESDTLocalMint", func(t *testing.T) {
+		t.Parallel()
+
+		call, err := dfp.Parse([]byte("ESDTLocalMint@544f4b@64"))
+		require.Nil(t, err)
+		assert.Equal(t, &ESDTAmountCall{
+			Function:        "ESDTLocalMint",
+			TokenIdentifier: []byte("TOK"),
+			Amount:          big.NewInt(100),
+		}, call)
+
+		call, err = dfp.Parse([]byte("ESDTLocalMint@544f4b@64@01"))
+		assert.Nil(t, call)
+		assert.True(t, errors.Is(err, ErrInvalidNumberOfArguments))
+	})
+	t.Run("ESDTNFTBurn", func(t *testing.T) {
+		t.Parallel()
+
+		call, err := dfp.Parse([]byte("ESDTNFTBurn@4e4654@02@03"))
+		require.Nil(t, err)
+		assert.Equal(t, &ESDTNFTQuantityCall{
+			Function:        "ESDTNFTBurn",
+			TokenIdentifier: []byte("NFT"),
+			Nonce:           2,
+			Quantity:        big.NewInt(3),
+		}, call)
+	})
+	t.Run("ESDTNFTCreate", func(t *testing.T) {
+		t.Parallel()
+
+		call, err := dfp.Parse([]byte("ESDTNFTCreate@4e4654@01@6e616d65@03e8@68617368@61747472@75726931@75726932"))
+		require.Nil(t, err)
+		assert.Equal(t, &ESDTNFTCreateCall{
+			Function:        "ESDTNFTCreate",
+			TokenIdentifier: []byte("NFT"),
+			Quantity:        big.NewInt(1),
+			Name:            []byte("name"),
+			Royalties:       1000,
+			Hash:            []byte("hash"),
+			Attributes:      []byte("attr"),
+			URIs:            [][]byte{[]byte("uri1"), []byte("uri2")},
+		}, call)
+
+		call, err = dfp.Parse([]byte("ESDTNFTCreate@4e4654@01@6e616d65@2711@68617368@61747472@75726931"))
+		assert.Nil(t, call)
+		assert.True(t, errors.Is(err, ErrInvalidAmount))
+	})
+	t.Run("ESDTNFTAddURI and ESDTNFTUpdateAttributes", func(t *testing.T) {
+		t.Parallel()
+
+		call, err := dfp.Parse([]byte("ESDTNFTAddURI@4e4654@01@757269"))
+		require.Nil(t, err)
+		assert.Equal(t, [][]byte{[]byte("uri")}, call.(*ESDTNFTAddURICall).URIs)
+
+		call, err = dfp.Parse([]byte("ESDTNFTUpdateAttributes@4e4654@01@61747472"))
+		require.Nil(t, err)
+		assert.Equal(t, []byte("attr"), call.(*ESDTNFTUpdateAttributesCall).Attributes)
+	})
+	t.Run("guardian functions", func(t *testing.T) {
+		t.Parallel()
+
+		call, err := dfp.Parse([]byte("SetGuardian@677561726469616e@75696431"))
+		require.Nil(t, err)
+		assert.Equal(t, &SetGuardianCall{
+			Function:        "SetGuardian",
+			GuardianAddress: []byte("guardian"),
+			ServiceUID:      []byte("uid1"),
+		}, call)
+
+		call, err = dfp.Parse([]byte("GuardAccount"))
+		require.Nil(t, err)
+		assert.Equal(t, "GuardAccount", call.GetFunction())
+
+		call, err = dfp.Parse([]byte("UnGuardAccount@01"))
+		assert.Nil(t, call)
+		assert.True(t, errors.Is(err, ErrInvalidNumberOfArguments))
+	})
+	t.Run("ChangeOwnerAddress and SetUserName", func(t *testing.T) {
+		t.Parallel()
+
+		call, err := dfp.Parse([]byte("ChangeOwnerAddress@6f776e6572"))
+		require.Nil(t, err)
+		assert.Equal(t, []byte("owner"), call.(*ChangeOwnerAddressCall).NewOwner)
+
+		call, err = dfp.Parse([]byte("SetUserName@616c696365"))
+		require.Nil(t, err)
+		assert.Equal(t, []byte("alice"), call.(*SetUserNameCall).UserName)
+	})
+}
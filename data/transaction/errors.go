@@ -19,3 +19,51 @@ var ErrNilUint64ByteSliceConverter = errors.New("nil Uint64ByteSliceConverter")
 
 // ErrNilApiTransactionResult signals that a nil api transaction result has been provided
 var ErrNiStorageService = errors.New("nil StorageService")
+
+// ErrNilTxSigner signals that a nil transaction signer has been provided
+var ErrNilTxSigner = errors.New("nil transaction signer")
+
+// ErrEmptySenderAddress signals that an empty sender address has been provided
+var ErrEmptySenderAddress = errors.New("empty sender address")
+
+// ErrEmptyReceiverAddress signals that an empty receiver address has been provided
+var ErrEmptyReceiverAddress = errors.New("empty receiver address")
+
+// ErrEmptyGuardianAddress signals that an empty guardian address has been provided
+var ErrEmptyGuardianAddress = errors.New("empty guardian address")
+
+// ErrEmptyRelayerAddress signals that an empty relayer address has been provided
+var ErrEmptyRelayerAddress = errors.New("empty relayer address")
+
+// ErrEmptyChainID signals that an empty chain ID has been provided
+var ErrEmptyChainID = errors.New("empty chain ID")
+
+// ErrInvalidTxVersion signals that an invalid transaction version has been provided
+var ErrInvalidTxVersion = errors.New("invalid transaction version")
+
+// ErrOptionsNotAllowedForVersion signals that options were set on a transaction version that does not support them
+var ErrOptionsNotAllowedForVersion = errors.New("options are not allowed for the transaction version")
+
+// ErrRelayerSameAsSender signals that the relayer address is the same as the sender address
+var ErrRelayerSameAsSender = errors.New("relayer address is the same as sender address")
+
+// ErrGuardianSameAsSender signals that the guardian address is the same as the sender address
+var ErrGuardianSameAsSender = errors.New("guardian address is the same as sender address")
+
+// ErrSignerAddressMismatch signals that the public key of a signer does not match the address it signs for
+var ErrSignerAddressMismatch = errors.New("signer public key does not match the address")
+
+// ErrNilTransaction signals that a nil transaction has been provided
+var ErrNilTransaction = errors.New("nil transaction")
+
+// ErrNilSignatureVerifier signals that a nil signature verifier has been provided
+var ErrNilSignatureVerifier = errors.New("nil signature verifier")
+
+// ErrMissingSignature signals that a required signature is missing
+var ErrMissingSignature = errors.New("missing signature")
+
+// ErrGuardianSignatureNotExpected signals that guardian fields were set on a transaction that is not guarded
+var ErrGuardianSignatureNotExpected = errors.New("guardian signature not expected")
+
+// ErrRelayerSignatureNotExpected signals that a relayer signature was set on a transaction without a relayer
+var ErrRelayerSignatureNotExpected = errors.New("relayer signature not expected")
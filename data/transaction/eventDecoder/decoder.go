@@ -0,0 +1,333 @@
+package eventDecoder
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+)
+
+const (
+	returnDataSeparator      = "@"
+	maxNonceBytes            = 8
+	numTopicsPerTransfer     = 3
+	minNumTopicsTransfer     = 4
+	minNumTopicsNFTCreate    = 4
+	minNumTopicsDeployment   = 2
+	minNumTopicsSignalError  = 2
+	codeHashTopicIndex       = 2
+	signalErrorMessageIndex  = 1
+	writeLogCallerTopicIndex = 0
+)
+
+// DecoderFunc defines the function able to convert an event into a typed event. The addresses are encoded with
+// the provided pubkey converter
+type DecoderFunc func(event *transaction.Event, pubkeyConverter core.PubkeyConverter) (DecodedEvent, error)
+
+type eventDecoder struct {
+	pubkeyConverter core.PubkeyConverter
+
+	mut      sync.RWMutex
+	decoders map[string]DecoderFunc
+}
+
+// NewEventDecoder creates a new event decoder instance having the known events already registered
+func NewEventDecoder(pubkeyConverter core.PubkeyConverter) (*eventDecoder, error) {
+	if check.IfNil(pubkeyConverter) {
+		return nil, core.ErrNilPubkeyConverter
+	}
+
+	ed := &eventDecoder{
+		pubkeyConverter: pubkeyConverter,
+		decoders:        make(map[string]DecoderFunc),
+	}
+
+	ed.decoders[core.BuiltInFunctionESDTTransfer] = decodeESDTTransfer
+	ed.decoders[core.BuiltInFunctionESDTNFTTransfer] = decodeESDTTransfer
+	ed.decoders[core.BuiltInFunctionMultiESDTNFTTransfer] = decodeESDTTransfer
+	ed.decoders[core.BuiltInFunctionESDTNFTCreate] = decodeNFTCreate
+	ed.decoders[core.SCDeployIdentifier] = decodeSCDeployment
+	ed.decoders[core.SCUpgradeIdentifier] = decodeSCDeployment
+	ed.decoders[core.WriteLogIdentifier] = decodeWriteLog
+	ed.decoders[core.SignalErrorOperation] = decodeSignalError
+
+	return ed, nil
+}
+
+// RegisterDecoder adds or replaces the decoder for the provided event identifier
+func (ed *eventDecoder) RegisterDecoder(identifier string, decoder DecoderFunc) error {
+	if len(identifier) == 0 {
+		return ErrEmptyIdentifier
+	}
+	if decoder == nil {
+		return ErrNilDecoderFunc
+	}
+
+	ed.mut.Lock()
+	ed.decoders[identifier] = decoder
+	ed.mut.Unlock()
+
+	return nil
+}
+
+// Decode returns the typed event of the provided log event. An event without a registered decoder is returned as
+// a *RawEvent
+func (ed *eventDecoder) Decode(event *transaction.Event) (DecodedEvent, error) {
+	if event == nil {
+		return nil, ErrNilEvent
+	}
+
+	identifier := string(event.Identifier)
+	ed.mut.RLock()
+	decoder, found := ed.decoders[identifier]
+	ed.mut.RUnlock()
+	if !found {
+		return ed.createRawEvent(event)
+	}
+
+	decodedEvent, err := decoder(event, ed.pubkeyConverter)
+	if err != nil {
+		return nil, fmt.Errorf("%w for event %s", err, identifier)
+	}
+
+	return decodedEvent, nil
+}
+
+// DecodeAPIEvent returns the typed event of the provided api event, which holds an encoded address
+func (ed *eventDecoder) DecodeAPIEvent(event *transaction.Events) (DecodedEvent, error) {
+	if event == nil {
+		return nil, ErrNilEvent
+	}
+
+	address, err := ed.pubkeyConverter.Decode(event.Address)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %s", ErrInvalidAddress, event.Address, err.Error())
+	}
+
+	return ed.Decode(&transaction.Event{
+		Address:        address,
+		Identifier:     []byte(event.Identifier),
+		Topics:         event.Topics,
+		Data:           event.Data,
+		AdditionalData: event.AdditionalData,
+	})
+}
+
+func (ed *eventDecoder) createRawEvent(event *transaction.Event) (DecodedEvent, error) {
+	return &RawEvent{
+		Address:        ed.encodeRawEventAddress(event.Address),
+		Identifier:     string(event.Identifier),
+		Topics:         event.Topics,
+		Data:           event.Data,
+		AdditionalData: event.AdditionalData,
+	}, nil
+}
+
+// encodeRawEventAddress encodes the address of an event without a registered decoder. Such events are passed
+// through as they are, so an empty address is left empty and an address that can not be encoded by the pubkey
+// converter (e.g. one with an unexpected length) is hex encoded instead of failing the decoding
+func (ed *eventDecoder) encodeRawEventAddress(address []byte) string {
+	if len(address) == 0 {
+		return ""
+	}
+
+	encoded, err := ed.pubkeyConverter.Encode(address)
+	if err != nil {
+		return hex.EncodeToString(address)
+	}
+
+	return encoded
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ed *eventDecoder) IsInterfaceNil() bool {
+	return ed == nil
+}
+
+// decodeESDTTransfer decodes the transfer events, having the topics of the form
+// token1, nonce1, amount1, [token2, nonce2, amount2, ...], receiver
+func decodeESDTTransfer(event *transaction.Event, pubkeyConverter core.PubkeyConverter) (DecodedEvent, error) {
+	numTopics := len(event.Topics)
+	if numTopics < minNumTopicsTransfer || (numTopics-1)%numTopicsPerTransfer != 0 {
+		return nil, fmt.Errorf("%w, got %d", ErrInvalidNumberOfTopics, numTopics)
+	}
+
+	transfers := make([]*TokenTransfer, 0, (numTopics-1)/numTopicsPerTransfer)
+	for index := 0; index < numTopics-1; index += numTopicsPerTransfer {
+		transfer, err := decodeTokenTransfer(event.Topics[index], event.Topics[index+1], event.Topics[index+2])
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	sender, err := encodeAddress(event.Address, pubkeyConverter)
+	if err != nil {
+		return nil, err
+	}
+	receiver, err := encodeAddress(event.Topics[numTopics-1], pubkeyConverter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ESDTTransferEvent{
+		Identifier: string(event.Identifier),
+		Sender:     sender,
+		Receiver:   receiver,
+		Transfers:  transfers,
+	}, nil
+}
+
+// decodeNFTCreate decodes the events having the topics of the form token, nonce, quantity, marshalled token
+func decodeNFTCreate(event *transaction.Event, pubkeyConverter core.PubkeyConverter) (DecodedEvent, error) {
+	if len(event.Topics) < minNumTopicsNFTCreate {
+		return nil, fmt.Errorf("%w, got %d, min %d", ErrInvalidNumberOfTopics, len(event.Topics), minNumTopicsNFTCreate)
+	}
+
+	transfer, err := decodeTokenTransfer(event.Topics[0], event.Topics[1], event.Topics[2])
+	if err != nil {
+		return nil, err
+	}
+	creator, err := encodeAddress(event.Address, pubkeyConverter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NFTCreateEvent{
+		Identifier:      string(event.Identifier),
+		Creator:         creator,
+		TokenIdentifier: transfer.TokenIdentifier,
+		Nonce:           transfer.Nonce,
+		Quantity:        transfer.Amount,
+		TokenData:       event.Topics[3],
+	}, nil
+}
+
+// decodeSCDeployment decodes the events having the topics of the form contract, caller, [code hash]
+func decodeSCDeployment(event *transaction.Event, pubkeyConverter core.PubkeyConverter) (DecodedEvent, error) {
+	if len(event.Topics) < minNumTopicsDeployment {
+		return nil, fmt.Errorf("%w, got %d, min %d", ErrInvalidNumberOfTopics, len(event.Topics), minNumTopicsDeployment)
+	}
+
+	contract, err := encodeAddress(event.Topics[0], pubkeyConverter)
+	if err != nil {
+		return nil, err
+	}
+	caller, err := encodeAddress(event.Topics[1], pubkeyConverter)
+	if err != nil {
+		return nil, err
+	}
+
+	deploymentEvent := &SCDeploymentEvent{
+		Identifier: string(event.Identifier),
+		Contract:   contract,
+		Caller:     caller,
+	}
+	if len(event.Topics) > codeHashTopicIndex {
+		deploymentEvent.CodeHash = event.Topics[codeHashTopicIndex]
+	}
+
+	return deploymentEvent, nil
+}
+
+// decodeWriteLog decodes the events having the caller as the first topic, if any
+func decodeWriteLog(event *transaction.Event, pubkeyConverter core.PubkeyConverter) (DecodedEvent, error) {
+	address, err := encodeAddress(event.Address, pubkeyConverter)
+	if err != nil {
+		return nil, err
+	}
+
+	writeLogEvent := &WriteLogEvent{
+		Identifier: string(event.Identifier),
+		Address:    address,
+		Data:       event.Data,
+		ReturnData: splitReturnData(event.Data),
+	}
+	if len(event.Topics) > writeLogCallerTopicIndex {
+		writeLogEvent.Caller, err = encodeAddress(event.Topics[writeLogCallerTopicIndex], pubkeyConverter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return writeLogEvent, nil
+}
+
+// decodeSignalError decodes the events having the topics of the form caller, message and the data field of the
+// form @returnCode
+func decodeSignalError(event *transaction.Event, pubkeyConverter core.PubkeyConverter) (DecodedEvent, error) {
+	if len(event.Topics) < minNumTopicsSignalError {
+		return nil, fmt.Errorf("%w, got %d, min %d", ErrInvalidNumberOfTopics, len(event.Topics), minNumTopicsSignalError)
+	}
+
+	address, err := encodeAddress(event.Address, pubkeyConverter)
+	if err != nil {
+		return nil, err
+	}
+	caller, err := encodeAddress(event.Topics[0], pubkeyConverter)
+	if err != nil {
+		return nil, err
+	}
+
+	signalErrorEvent := &SignalErrorEvent{
+		Identifier: string(event.Identifier),
+		Address:    address,
+		Caller:     caller,
+		Message:    string(event.Topics[signalErrorMessageIndex]),
+	}
+	returnData := splitReturnData(event.Data)
+	if len(returnData) > 0 {
+		signalErrorEvent.ReturnCode = string(returnData[0])
+	}
+
+	return signalErrorEvent, nil
+}
+
+func decodeTokenTransfer(tokenIdentifier []byte, nonce []byte, amount []byte) (*TokenTransfer, error) {
+	if len(tokenIdentifier) == 0 {
+		return nil, ErrEmptyTokenIdentifier
+	}
+	if len(nonce) > maxNonceBytes {
+		return nil, fmt.Errorf("%w, nonce has %d bytes", ErrInvalidNonce, len(nonce))
+	}
+
+	return &TokenTransfer{
+		TokenIdentifier: string(tokenIdentifier),
+		Nonce:           big.NewInt(0).SetBytes(nonce).Uint64(),
+		Amount:          big.NewInt(0).SetBytes(amount),
+	}, nil
+}
+
+func encodeAddress(address []byte, pubkeyConverter core.PubkeyConverter) (string, error) {
+	encoded, err := pubkeyConverter.Encode(address)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidAddress, err.Error())
+	}
+
+	return encoded, nil
+}
+
+// splitReturnData returns the hex decoded values of a data field of the form @arg1@arg2, or nil if the data field
+// does not have this form
+func splitReturnData(data []byte) [][]byte {
+	if !strings.HasPrefix(string(data), returnDataSeparator) {
+		return nil
+	}
+
+	tokens := strings.Split(string(data), returnDataSeparator)[1:]
+	returnData := make([][]byte, 0, len(tokens))
+	for _, token := range tokens {
+		value, err := hex.DecodeString(token)
+		if err != nil {
+			return nil
+		}
+		returnData = append(returnData, value)
+	}
+
+	return returnData
+}
@@ -0,0 +1,297 @@
+package eventDecoder
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
+	"github.com/multiversx/mx-chain-core-go/data/mock"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	alice    = []byte("alice0")
+	bob      = []byte("bobby0")
+	contract = []byte("sc0000")
+)
+
+func createDecoder(t *testing.T) *eventDecoder {
+	converter, _ := pubkeyConverter.NewHexPubkeyConverter(6)
+	ed, err := NewEventDecoder(converter)
+	require.Nil(t, err)
+
+	return ed
+}
+
+func encode(address []byte) string {
+	return hex.EncodeToString(address)
+}
+
+func TestNewEventDecoder(t *testing.T) {
+	t.Parallel()
+
+	ed, err := NewEventDecoder(nil)
+	assert.Nil(t, ed)
+	assert.Equal(t, core.ErrNilPubkeyConverter, err)
+
+	ed = createDecoder(t)
+	assert.False(t, check.IfNil(ed))
+}
+
+func TestEventDecoder_RegisterDecoder(t *testing.T) {
+	t.Parallel()
+
+	ed := createDecoder(t)
+	assert.Equal(t, ErrEmptyIdentifier, ed.RegisterDecoder("", decodeWriteLog))
+	assert.Equal(t, ErrNilDecoderFunc, ed.RegisterDecoder("custom", nil))
+
+	err := ed.RegisterDecoder("custom", func(event *transaction.Event, _ core.PubkeyConverter) (DecodedEvent, error) {
+		return &RawEvent{Identifier: "decoded " + string(event.Identifier)}, nil
+	})
+	require.Nil(t, err)
+
+	decodedEvent, err := ed.Decode(&transaction.Event{Identifier: []byte("custom")})
+	require.Nil(t, err)
+	assert.Equal(t, "decoded custom", decodedEvent.GetIdentifier())
+}
+
+func TestEventDecoder_Decode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil event should error", func(t *testing.T) {
+		t.Parallel()
+
+		decodedEvent, err := createDecoder(t).Decode(nil)
+		assert.Nil(t, decodedEvent)
+		assert.Equal(t, ErrNilEvent, err)
+	})
+	t.Run("unknown event should be passed through raw", func(t *testing.T) {
+		t.Parallel()
+
+		event := &transaction.Event{
+			Address:        alice,
+			Identifier:     []byte("customEvent"),
+			Topics:         [][]byte{[]byte("topic")},
+			Data:           []byte("data"),
+			AdditionalData: [][]byte{[]byte("additional")},
+		}
+		decodedEvent, err := createDecoder(t).Decode(event)
+		require.Nil(t, err)
+
+		expectedEvent := &RawEvent{
+			Address:        encode(alice),
+			Identifier:     "customEvent",
+			Topics:         event.Topics,
+			Data:           event.Data,
+			AdditionalData: event.AdditionalData,
+		}
+		assert.Equal(t, expectedEvent, decodedEvent)
+	})
+	t.Run("unknown event with an empty address should be passed through raw", func(t *testing.T) {
+		t.Parallel()
+
+		decodedEvent, err := createDecoder(t).Decode(&transaction.Event{
+			Identifier: []byte("customEvent"),
+			Topics:     [][]byte{[]byte("topic")},
+		})
+		require.Nil(t, err)
+
+		expectedEvent := &RawEvent{
+			Identifier: "customEvent",
+			Topics:     [][]byte{[]byte("topic")},
+		}
+		assert.Equal(t, expectedEvent, decodedEvent)
+	})
+	t.Run("unknown event with an address of unexpected length should be passed through hex encoded", func(t *testing.T) {
+		t.Parallel()
+
+		address := []byte("abc")
+		decodedEvent, err := createDecoder(t).Decode(&transaction.Event{
+			Address:    address,
+			Identifier: []byte("customEvent"),
+		})
+		require.Nil(t, err)
+
+		expectedEvent := &RawEvent{
+			Address:    hex.EncodeToString(address),
+			Identifier: "customEvent",
+		}
+		assert.Equal(t, expectedEvent, decodedEvent)
+	})
+	t.Run("esdt transfers", func(t *testing.T) {
+		t.Parallel()
+
+		ed := createDecoder(t)
+		decodedEvent, err := ed.Decode(&transaction.Event{
+			Address:    alice,
+			Identifier: []byte(core.BuiltInFunctionESDTTransfer),
+			Topics:     [][]byte{[]byte("TKN-abcdef"), nil, big.NewInt(100).Bytes(), bob},
+		})
+		require.Nil(t, err)
+		expectedEvent := &ESDTTransferEvent{
+			Identifier: core.BuiltInFunctionESDTTransfer,
+			Sender:     encode(alice),
+			Receiver:   encode(bob),
+			Transfers:  []*TokenTransfer{{TokenIdentifier: "TKN-abcdef", Amount: big.NewInt(100)}},
+		}
+		assert.Equal(t, expectedEvent, decodedEvent)
+
+		decodedEvent, err = ed.Decode(&transaction.Event{
+			Address:    alice,
+			Identifier: []byte(core.BuiltInFunctionMultiESDTNFTTransfer),
+			Topics: [][]byte{
+				[]byte("TKN-abcdef"), nil, big.NewInt(1).Bytes(),
+				[]byte("NFT-abcdef"), big.NewInt(5).Bytes(), big.NewInt(1).Bytes(),
+				bob,
+			},
+		})
+		require.Nil(t, err)
+		transferEvent := decodedEvent.(*ESDTTransferEvent)
+		require.Len(t, transferEvent.Transfers, 2)
+		assert.Equal(t, "NFT-abcdef", transferEvent.Transfers[1].TokenIdentifier)
+		assert.Equal(t, uint64(5), transferEvent.Transfers[1].Nonce)
+
+		_, err = ed.Decode(&transaction.Event{
+			Identifier: []byte(core.BuiltInFunctionESDTNFTTransfer),
+			Topics:     [][]byte{[]byte("NFT-abcdef"), nil, bob},
+		})
+		assert.True(t, errors.Is(err, ErrInvalidNumberOfTopics))
+
+		_, err = ed.Decode(&transaction.Event{
+			Identifier: []byte(core.BuiltInFunctionESDTNFTTransfer),
+			Topics:     [][]byte{[]byte("NFT-abcdef"), make([]byte, 9), big.NewInt(1).Bytes(), bob},
+		})
+		assert.True(t, errors.Is(err, ErrInvalidNonce))
+	})
+	t.Run("nft create", func(t *testing.T) {
+		t.Parallel()
+
+		decodedEvent, err := createDecoder(t).Decode(&transaction.Event{
+			Address:    alice,
+			Identifier: []byte(core.BuiltInFunctionESDTNFTCreate),
+			Topics:     [][]byte{[]byte("NFT-abcdef"), big.NewInt(3).Bytes(), big.NewInt(1).Bytes(), []byte("token")},
+		})
+		require.Nil(t, err)
+		expectedEvent := &NFTCreateEvent{
+			Identifier:      core.BuiltInFunctionESDTNFTCreate,
+			Creator:         encode(alice),
+			TokenIdentifier: "NFT-abcdef",
+			Nonce:           3,
+			Quantity:        big.NewInt(1),
+			TokenData:       []byte("token"),
+		}
+		assert.Equal(t, expectedEvent, decodedEvent)
+	})
+	t.Run("sc deploy and upgrade", func(t *testing.T) {
+		t.Parallel()
+
+		ed := createDecoder(t)
+		decodedEvent, err := ed.Decode(&transaction.Event{
+			Address:    contract,
+			Identifier: []byte(core.SCDeployIdentifier),
+			Topics:     [][]byte{contract, alice, []byte("code hash")},
+		})
+		require.Nil(t, err)
+		expectedEvent := &SCDeploymentEvent{
+			Identifier: core.SCDeployIdentifier,
+			Contract:   encode(contract),
+			Caller:     encode(alice),
+			CodeHash:   []byte("code hash"),
+		}
+		assert.Equal(t, expectedEvent, decodedEvent)
+
+		decodedEvent, err = ed.Decode(&transaction.Event{
+			Address:    contract,
+			Identifier: []byte(core.SCUpgradeIdentifier),
+			Topics:     [][]byte{contract, alice},
+		})
+		require.Nil(t, err)
+		assert.Nil(t, decodedEvent.(*SCDeploymentEvent).CodeHash)
+
+		_, err = ed.Decode(&transaction.Event{Identifier: []byte(core.SCDeployIdentifier), Topics: [][]byte{contract}})
+		assert.True(t, errors.Is(err, ErrInvalidNumberOfTopics))
+	})
+	t.Run("write log", func(t *testing.T) {
+		t.Parallel()
+
+		decodedEvent, err := createDecoder(t).Decode(&transaction.Event{
+			Address:    contract,
+			Identifier: []byte(core.WriteLogIdentifier),
+			Topics:     [][]byte{alice},
+			Data:       []byte("@6f6b@0a"),
+		})
+		require.Nil(t, err)
+		expectedEvent := &WriteLogEvent{
+			Identifier: core.WriteLogIdentifier,
+			Address:    encode(contract),
+			Caller:     encode(alice),
+			Data:       []byte("@6f6b@0a"),
+			ReturnData: [][]byte{[]byte("ok"), {10}},
+		}
+		assert.Equal(t, expectedEvent, decodedEvent)
+	})
+	t.Run("signal error", func(t *testing.T) {
+		t.Parallel()
+
+		decodedEvent, err := createDecoder(t).Decode(&transaction.Event{
+			Address:    contract,
+			Identifier: []byte(core.SignalErrorOperation),
+			Topics:     [][]byte{alice, []byte("insufficient funds")},
+			Data:       []byte("@" + hex.EncodeToString([]byte("user error"))),
+		})
+		require.Nil(t, err)
+		expectedEvent := &SignalErrorEvent{
+			Identifier: core.SignalErrorOperation,
+			Address:    encode(contract),
+			Caller:     encode(alice),
+			Message:    "insufficient funds",
+			ReturnCode: "user error",
+		}
+		assert.Equal(t, expectedEvent, decodedEvent)
+	})
+	t.Run("address conversion error should error for decoded events", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		ed, _ := NewEventDecoder(&mock.PubkeyConverterStub{
+			EncodeCalled: func(pkBytes []byte) (string, error) {
+				return "", expectedErr
+			},
+		})
+
+		decodedEvent, err := ed.Decode(&transaction.Event{Address: alice, Identifier: []byte("customEvent")})
+		require.Nil(t, err)
+		assert.Equal(t, &RawEvent{Address: encode(alice), Identifier: "customEvent"}, decodedEvent)
+
+		_, err = ed.Decode(&transaction.Event{Address: alice, Identifier: []byte(core.WriteLogIdentifier)})
+		assert.True(t, errors.Is(err, ErrInvalidAddress))
+	})
+}
+
+func TestEventDecoder_DecodeAPIEvent(t *testing.T) {
+	t.Parallel()
+
+	ed := createDecoder(t)
+	_, err := ed.DecodeAPIEvent(nil)
+	assert.Equal(t, ErrNilEvent, err)
+
+	_, err = ed.DecodeAPIEvent(&transaction.Events{Address: "invalid", Identifier: core.WriteLogIdentifier})
+	assert.True(t, errors.Is(err, ErrInvalidAddress))
+
+	decodedEvent, err := ed.DecodeAPIEvent(&transaction.Events{
+		Address:    encode(alice),
+		Identifier: core.BuiltInFunctionESDTTransfer,
+		Topics:     [][]byte{[]byte("TKN-abcdef"), nil, big.NewInt(7).Bytes(), bob},
+	})
+	require.Nil(t, err)
+	transferEvent := decodedEvent.(*ESDTTransferEvent)
+	assert.Equal(t, encode(alice), transferEvent.Sender)
+	assert.Equal(t, encode(bob), transferEvent.Receiver)
+	assert.Equal(t, big.NewInt(7), transferEvent.Transfers[0].Amount)
+}
@@ -0,0 +1,24 @@
+package eventDecoder
+
+import "errors"
+
+// ErrNilEvent signals that a nil event has been provided
+var ErrNilEvent = errors.New("nil event")
+
+// ErrEmptyIdentifier signals that an empty event identifier has been provided
+var ErrEmptyIdentifier = errors.New("empty event identifier")
+
+// ErrNilDecoderFunc signals that a nil decoder function has been provided
+var ErrNilDecoderFunc = errors.New("nil decoder function")
+
+// ErrInvalidNumberOfTopics signals that an event does not hold the number of topics expected for its identifier
+var ErrInvalidNumberOfTopics = errors.New("invalid number of topics")
+
+// ErrEmptyTokenIdentifier signals that an event holds an empty token identifier
+var ErrEmptyTokenIdentifier = errors.New("empty token identifier")
+
+// ErrInvalidNonce signals that an event holds an invalid token nonce
+var ErrInvalidNonce = errors.New("invalid nonce")
+
+// ErrInvalidAddress signals that an event holds an address which cannot be converted
+var ErrInvalidAddress = errors.New("invalid address")
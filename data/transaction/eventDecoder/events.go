@@ -0,0 +1,102 @@
+package eventDecoder
+
+import "math/big"
+
+// DecodedEvent defines the behavior of a typed event decoded from a transaction log
+type DecodedEvent interface {
+	GetIdentifier() string
+}
+
+// RawEvent holds an event without a registered decoder, with its address encoded. An address that can not be
+// encoded by the pubkey converter is hex encoded instead
+type RawEvent struct {
+	Address        string
+	Identifier     string
+	Topics         [][]byte
+	Data           []byte
+	AdditionalData [][]byte
+}
+
+// GetIdentifier returns the event identifier
+func (event *RawEvent) GetIdentifier() string {
+	return event.Identifier
+}
+
+// TokenTransfer holds the details of a single transferred token. Nonce is 0 for fungible tokens
+type TokenTransfer struct {
+	TokenIdentifier string
+	Nonce           uint64
+	Amount          *big.Int
+}
+
+// ESDTTransferEvent holds the decoded ESDTTransfer, ESDTNFTTransfer and MultiESDTNFTTransfer events
+type ESDTTransferEvent struct {
+	Identifier string
+	Sender     string
+	Receiver   string
+	Transfers  []*TokenTransfer
+}
+
+// GetIdentifier returns the event identifier
+func (event *ESDTTransferEvent) GetIdentifier() string {
+	return event.Identifier
+}
+
+// NFTCreateEvent holds the decoded ESDTNFTCreate event. TokenData holds the marshalled created token, as it was
+// logged
+type NFTCreateEvent struct {
+	Identifier      string
+	Creator         string
+	TokenIdentifier string
+	Nonce           uint64
+	Quantity        *big.Int
+	TokenData       []byte
+}
+
+// GetIdentifier returns the event identifier
+func (event *NFTCreateEvent) GetIdentifier() string {
+	return event.Identifier
+}
+
+// SCDeploymentEvent holds the decoded SCDeploy and SCUpgrade events. The code hash is only logged by the newer
+// protocol versions
+type SCDeploymentEvent struct {
+	Identifier string
+	Contract   string
+	Caller     string
+	CodeHash   []byte
+}
+
+// GetIdentifier returns the event identifier
+func (event *SCDeploymentEvent) GetIdentifier() string {
+	return event.Identifier
+}
+
+// WriteLogEvent holds the decoded writeLog event. ReturnData holds the hex decoded values of a data field of the
+// form @arg1@arg2, and it is empty if the data field does not have this form
+type WriteLogEvent struct {
+	Identifier string
+	Address    string
+	Caller     string
+	Data       []byte
+	ReturnData [][]byte
+}
+
+// GetIdentifier returns the event identifier
+func (event *WriteLogEvent) GetIdentifier() string {
+	return event.Identifier
+}
+
+// SignalErrorEvent holds the decoded signalError event
+type SignalErrorEvent struct {
+	Identifier string
+	Address    string
+	Caller     string
+	Message    string
+	ReturnCode string
+}
+
+// GetIdentifier returns the event identifier
+func (event *SignalErrorEvent) GetIdentifier() string {
+	return event.Identifier
+}
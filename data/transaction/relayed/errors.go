@@ -0,0 +1,49 @@
+package relayed
+
+import "errors"
+
+// ErrNilMarshaller signals that a nil marshaller has been provided
+var ErrNilMarshaller = errors.New("nil marshaller")
+
+// ErrNilFeeHandler signals that a nil fee handler has been provided
+var ErrNilFeeHandler = errors.New("nil fee handler")
+
+// ErrNilTransaction signals that a nil transaction has been provided
+var ErrNilTransaction = errors.New("nil transaction")
+
+// ErrNotRelayedTransaction signals that the provided transaction is not a relayed transaction
+var ErrNotRelayedTransaction = errors.New("not a relayed transaction")
+
+// ErrMultipleRelayedTxTypes signals that a transaction uses more than one relayed format at the same time
+var ErrMultipleRelayedTxTypes = errors.New("multiple relayed transaction types are not allowed")
+
+// ErrInvalidNumberOfArguments signals that the relayed transaction data field has an invalid number of arguments
+var ErrInvalidNumberOfArguments = errors.New("invalid number of arguments")
+
+// ErrNestedRelayedTransaction signals that the inner transaction is a relayed transaction as well
+var ErrNestedRelayedTransaction = errors.New("nested relayed transactions are not allowed")
+
+// ErrInnerSenderMismatch signals that the inner transaction's sender is not the relayed transaction's receiver
+var ErrInnerSenderMismatch = errors.New("inner transaction sender does not match the relayed transaction receiver")
+
+// ErrRelayerIsInnerSender signals that the relayer is the same account as the inner transaction's sender
+var ErrRelayerIsInnerSender = errors.New("relayer is the same as the inner transaction sender")
+
+// ErrMissingSignature signals that a required signature is missing
+var ErrMissingSignature = errors.New("missing signature")
+
+// ErrValueMismatch signals that the relayed transaction's value does not match the inner transaction's value
+var ErrValueMismatch = errors.New("relayed transaction value does not match inner transaction value")
+
+// ErrGasPriceMismatch signals that the relayed transaction's gas price does not match the inner transaction's gas price
+var ErrGasPriceMismatch = errors.New("relayed transaction gas price does not match inner transaction gas price")
+
+// ErrInsufficientGasLimit signals that the relayed transaction's gas limit does not cover the required gas
+var ErrInsufficientGasLimit = errors.New("insufficient gas limit")
+
+// ErrRelayedTxGasLimitMismatch signals that the relayed v1 transaction's gas limit, without the relayer's gas,
+// is not equal to the inner transaction's gas limit
+var ErrRelayedTxGasLimitMismatch = errors.New("relayed tx gas limit mismatch with the inner tx gas limit")
+
+// ErrInvalidNonce signals that an invalid nonce argument has been provided
+var ErrInvalidNonce = errors.New("invalid nonce")
@@ -0,0 +1,10 @@
+package relayed
+
+import "github.com/multiversx/mx-chain-core-go/data"
+
+// FeeHandler defines the economics component able to compute the gas consumed by the relayed transaction itself
+type FeeHandler interface {
+	ComputeGasLimit(tx data.TransactionWithFeeHandler) uint64
+	MinGasLimit() uint64
+	IsInterfaceNil() bool
+}
@@ -0,0 +1,267 @@
+package relayed
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-core-go/data/transaction/dataField"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+)
+
+// TxVersion represents the relayed transaction format
+type TxVersion uint8
+
+const (
+	// NotRelayed signals a transaction that is not relayed
+	NotRelayed TxVersion = iota
+	// V1 signals a relayed transaction carrying the JSON encoded inner transaction in its data field
+	V1
+	// V2 signals a relayed transaction carrying the inner transaction fields as data field arguments
+	V2
+	// V3 signals a transaction having the relayer address and signature set
+	V3
+)
+
+const (
+	numArgsRelayedV1 = 1
+	numArgsRelayedV2 = 4
+	maxNonceBytes    = 8
+)
+
+// TxInfo is the normalized view of a relayed transaction, regardless of its version
+type TxInfo struct {
+	Version          TxVersion
+	RelayerAddr      []byte
+	RelayerSignature []byte
+	RelayedTx        *transaction.Transaction
+	InnerTx          *transaction.Transaction
+}
+
+// ArgsRelayedTxDecoder holds the arguments needed to create a new relayed transaction decoder
+type ArgsRelayedTxDecoder struct {
+	Marshaller marshal.Marshalizer
+	FeeHandler FeeHandler
+}
+
+type relayedTxDecoder struct {
+	marshaller marshal.Marshalizer
+	feeHandler FeeHandler
+}
+
+// NewRelayedTxDecoder creates a new relayed transaction decoder instance
+func NewRelayedTxDecoder(args ArgsRelayedTxDecoder) (*relayedTxDecoder, error) {
+	if check.IfNil(args.Marshaller) {
+		return nil, ErrNilMarshaller
+	}
+	if check.IfNil(args.FeeHandler) {
+		return nil, ErrNilFeeHandler
+	}
+
+	return &relayedTxDecoder{
+		marshaller: args.Marshaller,
+		feeHandler: args.FeeHandler,
+	}, nil
+}
+
+// GetTxVersion returns the relayed format of the provided transaction
+func GetTxVersion(tx *transaction.Transaction) TxVersion {
+	if tx == nil {
+		return NotRelayed
+	}
+	if len(tx.RelayerAddr) > 0 {
+		return V3
+	}
+
+	return getVersionFromData(tx.Data)
+}
+
+func getVersionFromData(txData []byte) TxVersion {
+	function := string(txData)
+	index := bytes.IndexByte(txData, '@')
+	if index >= 0 {
+		function = string(txData[:index])
+	}
+
+	switch function {
+	case core.RelayedTransaction:
+		return V1
+	case core.RelayedTransactionV2:
+		return V2
+	default:
+		return NotRelayed
+	}
+}
+
+// Decode extracts the inner transaction from the provided relayed transaction and validates the signer,
+// nonce and gas invariants specific to its version
+func (rtd *relayedTxDecoder) Decode(tx *transaction.Transaction) (*TxInfo, error) {
+	if tx == nil {
+		return nil, ErrNilTransaction
+	}
+
+	switch GetTxVersion(tx) {
+	case V1:
+		return rtd.decodeV1(tx)
+	case V2:
+		return rtd.decodeV2(tx)
+	case V3:
+		return rtd.decodeV3(tx)
+	default:
+		return nil, ErrNotRelayedTransaction
+	}
+}
+
+func (rtd *relayedTxDecoder) decodeV1(tx *transaction.Transaction) (*TxInfo, error) {
+	callData, err := dataField.SplitDataField(tx.Data)
+	if err != nil {
+		return nil, err
+	}
+	if len(callData.Arguments) != numArgsRelayedV1 {
+		return nil, fmt.Errorf("%w for relayed v1, got %d, expected %d",
+			ErrInvalidNumberOfArguments, len(callData.Arguments), numArgsRelayedV1)
+	}
+
+	innerTx := &transaction.Transaction{}
+	err = rtd.marshaller.Unmarshal(innerTx, callData.Arguments[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w while unmarshalling the relayed v1 inner transaction", err)
+	}
+
+	err = checkInnerTx(tx, innerTx)
+	if err != nil {
+		return nil, err
+	}
+	if innerTx.Value == nil || tx.Value == nil || innerTx.Value.Cmp(tx.Value) != 0 {
+		return nil, ErrValueMismatch
+	}
+	if innerTx.GasPrice != tx.GasPrice {
+		return nil, ErrGasPriceMismatch
+	}
+
+	relayerGasLimit := rtd.feeHandler.ComputeGasLimit(tx)
+	if tx.GasLimit < relayerGasLimit {
+		return nil, fmt.Errorf("%w for relayed v1, provided %d, relayer needs %d",
+			ErrInsufficientGasLimit, tx.GasLimit, relayerGasLimit)
+	}
+	if tx.GasLimit-relayerGasLimit != innerTx.GasLimit {
+		return nil, fmt.Errorf("%w, provided %d, relayer needs %d, inner transaction has %d",
+			ErrRelayedTxGasLimitMismatch, tx.GasLimit, relayerGasLimit, innerTx.GasLimit)
+	}
+
+	return createTxInfo(V1, tx, innerTx), nil
+}
+
+func (rtd *relayedTxDecoder) decodeV2(tx *transaction.Transaction) (*TxInfo, error) {
+	callData, err := dataField.SplitDataField(tx.Data)
+	if err != nil {
+		return nil, err
+	}
+	args := callData.Arguments
+	if len(args) != numArgsRelayedV2 {
+		return nil, fmt.Errorf("%w for relayed v2, got %d, expected %d",
+			ErrInvalidNumberOfArguments, len(args), numArgsRelayedV2)
+	}
+	if len(args[1]) > maxNonceBytes {
+		return nil, fmt.Errorf("%w, nonce has %d bytes", ErrInvalidNonce, len(args[1]))
+	}
+	if tx.Value == nil || tx.Value.Sign() != 0 {
+		return nil, ErrValueMismatch
+	}
+
+	relayerGasLimit := rtd.feeHandler.ComputeGasLimit(tx)
+	if tx.GasLimit < relayerGasLimit {
+		return nil, fmt.Errorf("%w for relayed v2, provided %d, relayer needs %d",
+			ErrInsufficientGasLimit, tx.GasLimit, relayerGasLimit)
+	}
+
+	innerTx := &transaction.Transaction{
+		Nonce:     big.NewInt(0).SetBytes(args[1]).Uint64(),
+		Value:     big.NewInt(0),
+		RcvAddr:   args[0],
+		SndAddr:   tx.RcvAddr,
+		GasPrice:  tx.GasPrice,
+		GasLimit:  tx.GasLimit - relayerGasLimit,
+		Data:      args[2],
+		ChainID:   tx.ChainID,
+		Version:   tx.Version,
+		Signature: args[3],
+	}
+
+	err = checkInnerTx(tx, innerTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return createTxInfo(V2, tx, innerTx), nil
+}
+
+func (rtd *relayedTxDecoder) decodeV3(tx *transaction.Transaction) (*TxInfo, error) {
+	if getVersionFromData(tx.Data) != NotRelayed {
+		return nil, ErrMultipleRelayedTxTypes
+	}
+	if len(tx.Signature) == 0 {
+		return nil, fmt.Errorf("%w for sender", ErrMissingSignature)
+	}
+	if len(tx.RelayerSignature) == 0 {
+		return nil, fmt.Errorf("%w for relayer", ErrMissingSignature)
+	}
+	if bytes.Equal(tx.RelayerAddr, tx.SndAddr) {
+		return nil, ErrRelayerIsInnerSender
+	}
+
+	requiredGasLimit := rtd.feeHandler.ComputeGasLimit(tx) + rtd.feeHandler.MinGasLimit()
+	if tx.GasLimit < requiredGasLimit {
+		return nil, fmt.Errorf("%w for relayed v3, provided %d, required %d",
+			ErrInsufficientGasLimit, tx.GasLimit, requiredGasLimit)
+	}
+
+	return &TxInfo{
+		Version:          V3,
+		RelayerAddr:      tx.RelayerAddr,
+		RelayerSignature: tx.RelayerSignature,
+		RelayedTx:        tx,
+		InnerTx:          tx,
+	}, nil
+}
+
+// checkInnerTx verifies the invariants shared by the relayed v1 and v2 formats
+func checkInnerTx(tx *transaction.Transaction, innerTx *transaction.Transaction) error {
+	if GetTxVersion(innerTx) != NotRelayed {
+		return ErrNestedRelayedTransaction
+	}
+	if !bytes.Equal(innerTx.SndAddr, tx.RcvAddr) {
+		return ErrInnerSenderMismatch
+	}
+	// relayer and inner sender share the same nonce if they are the same account, making the relayed
+	// transaction impossible to execute
+	if bytes.Equal(tx.SndAddr, innerTx.SndAddr) {
+		return ErrRelayerIsInnerSender
+	}
+	if len(tx.Signature) == 0 {
+		return fmt.Errorf("%w for relayer", ErrMissingSignature)
+	}
+	if len(innerTx.Signature) == 0 {
+		return fmt.Errorf("%w for inner transaction", ErrMissingSignature)
+	}
+
+	return nil
+}
+
+func createTxInfo(version TxVersion, tx *transaction.Transaction, innerTx *transaction.Transaction) *TxInfo {
+	return &TxInfo{
+		Version:          version,
+		RelayerAddr:      tx.SndAddr,
+		RelayerSignature: tx.Signature,
+		RelayedTx:        tx,
+		InnerTx:          innerTx,
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (rtd *relayedTxDecoder) IsInterfaceNil() bool {
+	return rtd == nil
+}
@@ -0,0 +1,396 @@
+package relayed
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data"
+	"github.com/multiversx/mx-chain-core-go/data/mock"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const relayerGasLimit = uint64(50_000)
+
+func createMockArgsRelayedTxDecoder() ArgsRelayedTxDecoder {
+	return ArgsRelayedTxDecoder{
+		Marshaller: &mock.MarshalizerMock{},
+		FeeHandler: &mock.FeeHandlerStub{
+			ComputeGasLimitCalled: func(tx data.TransactionWithFeeHandler) uint64 {
+				return relayerGasLimit
+			},
+			MinGasLimitCalled: func() uint64 {
+				return relayerGasLimit
+			},
+		},
+	}
+}
+
+func createInnerTx() *transaction.Transaction {
+	return &transaction.Transaction{
+		Nonce:     3,
+		Value:     big.NewInt(10),
+		RcvAddr:   []byte("receiver"),
+		SndAddr:   []byte("user"),
+		GasPrice:  1000,
+		GasLimit:  100_000,
+		Data:      []byte("call"),
+		ChainID:   []byte("T"),
+		Version:   1,
+		Signature: []byte("user signature"),
+	}
+}
+
+func createRelayedV1Tx(t *testing.T, innerTx *transaction.Transaction) *transaction.Transaction {
+	innerTxBytes, err := (&mock.MarshalizerMock{}).Marshal(innerTx)
+	require.Nil(t, err)
+
+	return &transaction.Transaction{
+		Nonce:     7,
+		Value:     big.NewInt(10),
+		RcvAddr:   []byte("user"),
+		SndAddr:   []byte("relayer"),
+		GasPrice:  1000,
+		GasLimit:  relayerGasLimit + innerTx.GasLimit,
+		Data:      []byte(core.RelayedTransaction + "@" + hex.EncodeToString(innerTxBytes)),
+		ChainID:   []byte("T"),
+		Version:   1,
+		Signature: []byte("relayer signature"),
+	}
+}
+
+func createRelayedV2Tx() *transaction.Transaction {
+	txData := core.RelayedTransactionV2 +
+		"@" + hex.EncodeToString([]byte("receiver")) +
+		"@03" +
+		"@" + hex.EncodeToString([]byte("call")) +
+		"@" + hex.EncodeToString([]byte("user signature"))
+
+	return &transaction.Transaction{
+		Nonce:     7,
+		Value:     big.NewInt(0),
+		RcvAddr:   []byte("user"),
+		SndAddr:   []byte("relayer"),
+		GasPrice:  1000,
+		GasLimit:  relayerGasLimit + 100_000,
+		Data:      []byte(txData),
+		ChainID:   []byte("T"),
+		Version:   1,
+		Signature: []byte("relayer signature"),
+	}
+}
+
+func createRelayedV3Tx() *transaction.Transaction {
+	tx := createInnerTx()
+	tx.Version = 2
+	tx.RelayerAddr = []byte("relayer")
+	tx.RelayerSignature = []byte("relayer signature")
+	tx.GasLimit = 2 * relayerGasLimit
+
+	return tx
+}
+
+func TestNewRelayedTxDecoder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil marshaller should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsRelayedTxDecoder()
+		args.Marshaller = nil
+		rtd, err := NewRelayedTxDecoder(args)
+		assert.Equal(t, ErrNilMarshaller, err)
+		assert.True(t, check.IfNil(rtd))
+	})
+	t.Run("nil fee handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsRelayedTxDecoder()
+		args.FeeHandler = nil
+		rtd, err := NewRelayedTxDecoder(args)
+		assert.Equal(t, ErrNilFeeHandler, err)
+		assert.True(t, check.IfNil(rtd))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		rtd, err := NewRelayedTxDecoder(createMockArgsRelayedTxDecoder())
+		assert.Nil(t, err)
+		assert.False(t, check.IfNil(rtd))
+	})
+}
+
+func TestGetTxVersion(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, NotRelayed, GetTxVersion(nil))
+	assert.Equal(t, NotRelayed, GetTxVersion(createInnerTx()))
+	assert.Equal(t, NotRelayed, GetTxVersion(&transaction.Transaction{Data: []byte("relayedTxV3@01")}))
+	assert.Equal(t, V1, GetTxVersion(createRelayedV1Tx(t, createInnerTx())))
+	assert.Equal(t, V2, GetTxVersion(createRelayedV2Tx()))
+	assert.Equal(t, V3, GetTxVersion(createRelayedV3Tx()))
+}
+
+func TestRelayedTxDecoder_Decode(t *testing.T) {
+	t.Parallel()
+
+	rtd, _ := NewRelayedTxDecoder(createMockArgsRelayedTxDecoder())
+
+	t.Run("nil transaction should error", func(t *testing.T) {
+		t.Parallel()
+
+		info, err := rtd.Decode(nil)
+		assert.Nil(t, info)
+		assert.Equal(t, ErrNilTransaction, err)
+	})
+	t.Run("not relayed should error", func(t *testing.T) {
+		t.Parallel()
+
+		info, err := rtd.Decode(createInnerTx())
+		assert.Nil(t, info)
+		assert.Equal(t, ErrNotRelayedTransaction, err)
+	})
+}
+
+func TestRelayedTxDecoder_DecodeV1(t *testing.T) {
+	t.Parallel()
+
+	rtd, _ := NewRelayedTxDecoder(createMockArgsRelayedTxDecoder())
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		innerTx := createInnerTx()
+		tx := createRelayedV1Tx(t, innerTx)
+		info, err := rtd.Decode(tx)
+		require.Nil(t, err)
+		assert.Equal(t, V1, info.Version)
+		assert.Equal(t, tx.SndAddr, info.RelayerAddr)
+		assert.Equal(t, tx.Signature, info.RelayerSignature)
+		assert.Equal(t, tx, info.RelayedTx)
+		assert.Equal(t, innerTx, info.InnerTx)
+	})
+	t.Run("invalid number of arguments should error", func(t *testing.T) {
+		t.Parallel()
+
+		tx := createRelayedV1Tx(t, createInnerTx())
+		tx.Data = append(tx.Data, []byte("@00")...)
+		info, err := rtd.Decode(tx)
+		assert.Nil(t, info)
+		assert.True(t, errors.Is(err, ErrInvalidNumberOfArguments))
+	})
+	t.Run("unmarshal error should error", func(t *testing.T) {
+		t.Parallel()
+
+		tx := createRelayedV1Tx(t, createInnerTx())
+		tx.Data = []byte(core.RelayedTransaction + "@0102")
+		info, err := rtd.Decode(tx)
+		assert.Nil(t, info)
+		assert.NotNil(t, err)
+	})
+	t.Run("inner sender mismatch should error", func(t *testing.T) {
+		t.Parallel()
+
+		tx := createRelayedV1Tx(t, createInnerTx())
+		tx.RcvAddr = []byte("other")
+		info, err := rtd.Decode(tx)
+		assert.Nil(t, info)
+		assert.Equal(t, ErrInnerSenderMismatch, err)
+	})
+	t.Run("self relayed should error", func(t *testing.T) {
+		t.Parallel()
+
+		tx := createRelayedV1Tx(t, createInnerTx())
+		tx.SndAddr = []byte("user")
+		info, err := rtd.Decode(tx)
+		assert.Nil(t, info)
+		assert.Equal(t, ErrRelayerIsInnerSender, err)
+	})
+	t.Run("nested relayed should error", func(t *testing.T) {
+		t.Parallel()
+
+		innerTx := createInnerTx()
+		innerTx.RelayerAddr = []byte("another relayer")
+		info, err := rtd.Decode(createRelayedV1Tx(t, innerTx))
+		assert.Nil(t, info)
+		assert.Equal(t, ErrNestedRelayedTransaction, err)
+	})
+	t.Run("missing inner signature should error", func(t *testing.T) {
+		t.Parallel()
+
+		innerTx := createInnerTx()
+		innerTx.Signature = nil
+		info, err := rtd.Decode(createRelayedV1Tx(t, innerTx))
+		assert.Nil(t, info)
+		assert.True(t, errors.Is(err, ErrMissingSignature))
+	})
+	t.Run("value mismatch should error", func(t *testing.T) {
+		t.Parallel()
+
+		tx := createRelayedV1Tx(t, createInnerTx())
+		tx.Value = big.NewInt(11)
+		info, err := rtd.Decode(tx)
+		assert.Nil(t, info)
+		assert.Equal(t, ErrValueMismatch, err)
+	})
+	t.Run("gas price mismatch should error", func(t *testing.T) {
+		t.Parallel()
+
+		tx := createRelayedV1Tx(t, createInnerTx())
+		tx.GasPrice++
+		info, err := rtd.Decode(tx)
+		assert.Nil(t, info)
+		assert.Equal(t, ErrGasPriceMismatch, err)
+	})
+	t.Run("insufficient gas limit should error", func(t *testing.T) {
+		t.Parallel()
+
+		tx := createRelayedV1Tx(t, createInnerTx())
+		tx.GasLimit = relayerGasLimit - 1
+		info, err := rtd.Decode(tx)
+		assert.Nil(t, info)
+		assert.True(t, errors.Is(err, ErrInsufficientGasLimit))
+	})
+	t.Run("inner gas limit above the remaining gas limit should error", func(t *testing.T) {
+		t.Parallel()
+
+		tx := createRelayedV1Tx(t, createInnerTx())
+		tx.GasLimit--
+		info, err := rtd.Decode(tx)
+		assert.Nil(t, info)
+		assert.True(t, errors.Is(err, ErrRelayedTxGasLimitMismatch))
+	})
+	t.Run("inner gas limit below the remaining gas limit should error", func(t *testing.T) {
+		t.Parallel()
+
+		tx := createRelayedV1Tx(t, createInnerTx())
+		tx.GasLimit++
+		info, err := rtd.Decode(tx)
+		assert.Nil(t, info)
+		assert.True(t, errors.Is(err, ErrRelayedTxGasLimitMismatch))
+	})
+}
+
+func TestRelayedTxDecoder_DecodeV2(t *testing.T) {
+	t.Parallel()
+
+	rtd, _ := NewRelayedTxDecoder(createMockArgsRelayedTxDecoder())
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		tx := createRelayedV2Tx()
+		info, err := rtd.Decode(tx)
+		require.Nil(t, err)
+		assert.Equal(t, V2, info.Version)
+		assert.Equal(t, tx.SndAddr, info.RelayerAddr)
+
+		expectedInnerTx := createInnerTx()
+		expectedInnerTx.Value = big.NewInt(0)
+		assert.Equal(t, expectedInnerTx, info.InnerTx)
+	})
+	t.Run("invalid number of arguments should error", func(t *testing.T) {
+		t.Parallel()
+
+		tx := createRelayedV2Tx()
+		tx.Data = []byte(core.RelayedTransactionV2 + "@01@02")
+		info, err := rtd.Decode(tx)
+		assert.Nil(t, info)
+		assert.True(t, errors.Is(err, ErrInvalidNumberOfArguments))
+	})
+	t.Run("invalid nonce should error", func(t *testing.T) {
+		t.Parallel()
+
+		tx := createRelayedV2Tx()
+		tx.Data = []byte(core.RelayedTransactionV2 + "@01@010203040506070809@02@03")
+		info, err := rtd.Decode(tx)
+		assert.Nil(t, info)
+		assert.True(t, errors.Is(err, ErrInvalidNonce))
+	})
+	t.Run("non zero value should error", func(t *testing.T) {
+		t.Parallel()
+
+		tx := createRelayedV2Tx()
+		tx.Value = big.NewInt(1)
+		info, err := rtd.Decode(tx)
+		assert.Nil(t, info)
+		assert.Equal(t, ErrValueMismatch, err)
+	})
+	t.Run("insufficient gas limit should error", func(t *testing.T) {
+		t.Parallel()
+
+		tx := createRelayedV2Tx()
+		tx.GasLimit = relayerGasLimit - 1
+		info, err := rtd.Decode(tx)
+		assert.Nil(t, info)
+		assert.True(t, errors.Is(err, ErrInsufficientGasLimit))
+	})
+}
+
+func TestRelayedTxDecoder_DecodeV3(t *testing.T) {
+	t.Parallel()
+
+	rtd, _ := NewRelayedTxDecoder(createMockArgsRelayedTxDecoder())
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		tx := createRelayedV3Tx()
+		info, err := rtd.Decode(tx)
+		require.Nil(t, err)
+		assert.Equal(t, &TxInfo{
+			Version:          V3,
+			RelayerAddr:      tx.RelayerAddr,
+			RelayerSignature: tx.RelayerSignature,
+			RelayedTx:        tx,
+			InnerTx:          tx,
+		}, info)
+	})
+	t.Run("relayed data field should error", func(t *testing.T) {
+		t.Parallel()
+
+		tx := createRelayedV3Tx()
+		tx.Data = []byte(core.RelayedTransactionV2 + "@01")
+		info, err := rtd.Decode(tx)
+		assert.Nil(t, info)
+		assert.Equal(t, ErrMultipleRelayedTxTypes, err)
+	})
+	t.Run("missing signatures should error", func(t *testing.T) {
+		t.Parallel()
+
+		tx := createRelayedV3Tx()
+		tx.RelayerSignature = nil
+		info, err := rtd.Decode(tx)
+		assert.Nil(t, info)
+		assert.True(t, errors.Is(err, ErrMissingSignature))
+
+		tx = createRelayedV3Tx()
+		tx.Signature = nil
+		info, err = rtd.Decode(tx)
+		assert.Nil(t, info)
+		assert.True(t, errors.Is(err, ErrMissingSignature))
+	})
+	t.Run("relayer is sender should error", func(t *testing.T) {
+		t.Parallel()
+
+		tx := createRelayedV3Tx()
+		tx.RelayerAddr = tx.SndAddr
+		info, err := rtd.Decode(tx)
+		assert.Nil(t, info)
+		assert.Equal(t, ErrRelayerIsInnerSender, err)
+	})
+	t.Run("insufficient gas limit should error", func(t *testing.T) {
+		t.Parallel()
+
+		tx := createRelayedV3Tx()
+		tx.GasLimit--
+		info, err := rtd.Decode(tx)
+		assert.Nil(t, info)
+		assert.True(t, errors.Is(err, ErrInsufficientGasLimit))
+	})
+}
@@ -0,0 +1,112 @@
+package transaction
+
+import (
+	"fmt"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/crypto"
+	"github.com/multiversx/mx-chain-core-go/data"
+)
+
+// ArgsTxSignaturesVerifier holds the arguments needed to create a new transaction signatures verifier
+type ArgsTxSignaturesVerifier struct {
+	Verifier   crypto.Verifier
+	Encoder    data.Encoder
+	Marshaller data.Marshaller
+	Hasher     data.Hasher
+}
+
+type txSignaturesVerifier struct {
+	verifier   crypto.Verifier
+	encoder    data.Encoder
+	marshaller data.Marshaller
+	hasher     data.Hasher
+}
+
+// NewTxSignaturesVerifier creates a new component able to verify all the signatures of a transaction
+func NewTxSignaturesVerifier(args ArgsTxSignaturesVerifier) (*txSignaturesVerifier, error) {
+	if check.IfNil(args.Verifier) {
+		return nil, ErrNilSignatureVerifier
+	}
+	if check.IfNil(args.Encoder) {
+		return nil, ErrNilEncoder
+	}
+	if check.IfNil(args.Marshaller) {
+		return nil, ErrNilMarshalizer
+	}
+	if check.IfNil(args.Hasher) {
+		return nil, ErrNilHasher
+	}
+
+	return &txSignaturesVerifier{
+		verifier:   args.Verifier,
+		encoder:    args.Encoder,
+		marshaller: args.Marshaller,
+		hasher:     args.Hasher,
+	}, nil
+}
+
+// VerifyTransaction verifies the sender signature and, depending on the transaction's version and options,
+// the guardian and relayer signatures. All signatures are applied on the same signing payload
+func (tsv *txSignaturesVerifier) VerifyTransaction(tx *Transaction) error {
+	if tx == nil {
+		return ErrNilTransaction
+	}
+	if tx.Version == core.InitialVersionOfTransaction && tx.Options != 0 {
+		return fmt.Errorf("%w, version %d, options %d", ErrOptionsNotAllowedForVersion, tx.Version, tx.Options)
+	}
+
+	isGuardedTx := tx.Version > core.InitialVersionOfTransaction && tx.HasOptionGuardianSet()
+	if !isGuardedTx && (len(tx.GuardianAddr) > 0 || len(tx.GuardianSignature) > 0) {
+		return ErrGuardianSignatureNotExpected
+	}
+	if len(tx.RelayerAddr) == 0 && len(tx.RelayerSignature) > 0 {
+		return ErrRelayerSignatureNotExpected
+	}
+
+	payload, err := tx.GetDataForSigning(tsv.encoder, tsv.marshaller, tsv.hasher)
+	if err != nil {
+		return err
+	}
+
+	err = tsv.verifySignature("sender", tx.SndAddr, payload, tx.Signature)
+	if err != nil {
+		return err
+	}
+
+	if isGuardedTx {
+		if len(tx.GuardianAddr) == 0 {
+			return ErrEmptyGuardianAddress
+		}
+
+		err = tsv.verifySignature("guardian", tx.GuardianAddr, payload, tx.GuardianSignature)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(tx.RelayerAddr) > 0 {
+		return tsv.verifySignature("relayer", tx.RelayerAddr, payload, tx.RelayerSignature)
+	}
+
+	return nil
+}
+
+func (tsv *txSignaturesVerifier) verifySignature(signer string, publicKey []byte, payload []byte, signature []byte) error {
+	if len(signature) == 0 {
+		return fmt.Errorf("%w for %s", ErrMissingSignature, signer)
+	}
+
+	err := tsv.verifier.Verify(publicKey, payload, signature)
+	if err != nil {
+		return fmt.Errorf("%w for %s", err, signer)
+	}
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (tsv *txSignaturesVerifier) IsInterfaceNil() bool {
+	return tsv == nil
+}
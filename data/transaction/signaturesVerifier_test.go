@@ -0,0 +1,190 @@
+package transaction_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/crypto"
+	"github.com/multiversx/mx-chain-core-go/crypto/ed25519"
+	"github.com/multiversx/mx-chain-core-go/data/mock"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMockArgsTxSignaturesVerifier() transaction.ArgsTxSignaturesVerifier {
+	builderArgs := createMockArgsTxBuilder()
+
+	return transaction.ArgsTxSignaturesVerifier{
+		Verifier:   ed25519.NewVerifier(),
+		Encoder:    builderArgs.Encoder,
+		Marshaller: builderArgs.Marshaller,
+		Hasher:     builderArgs.Hasher,
+	}
+}
+
+func createEd25519Signer(t *testing.T, seedByte byte) crypto.Signer {
+	seed := make([]byte, 32)
+	seed[0] = seedByte
+	signer, err := ed25519.NewSigner(seed)
+	require.Nil(t, err)
+
+	return signer
+}
+
+func buildSignedTx(t *testing.T, guardian crypto.Signer, relayer crypto.Signer) *transaction.Transaction {
+	sender := createEd25519Signer(t, 1)
+	tb, _ := transaction.NewTxBuilder(createMockArgsTxBuilder())
+	tb.WithSender(sender.PublicKey(), sender).
+		WithReceiver([]byte("receiver")).
+		WithValue(big.NewInt(10)).
+		WithChainID([]byte("T")).
+		WithVersion(2)
+	if guardian != nil {
+		tb.WithGuardian(guardian.PublicKey(), guardian)
+	}
+	if relayer != nil {
+		tb.WithRelayer(relayer.PublicKey(), relayer)
+	}
+
+	tx, err := tb.Build()
+	require.Nil(t, err)
+
+	return tx
+}
+
+func TestNewTxSignaturesVerifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil verifier should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsTxSignaturesVerifier()
+		args.Verifier = nil
+		tsv, err := transaction.NewTxSignaturesVerifier(args)
+		assert.Equal(t, transaction.ErrNilSignatureVerifier, err)
+		assert.True(t, check.IfNil(tsv))
+	})
+	t.Run("nil encoder should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsTxSignaturesVerifier()
+		args.Encoder = nil
+		tsv, err := transaction.NewTxSignaturesVerifier(args)
+		assert.Equal(t, transaction.ErrNilEncoder, err)
+		assert.True(t, check.IfNil(tsv))
+	})
+	t.Run("nil marshaller should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsTxSignaturesVerifier()
+		args.Marshaller = nil
+		tsv, err := transaction.NewTxSignaturesVerifier(args)
+		assert.Equal(t, transaction.ErrNilMarshalizer, err)
+		assert.True(t, check.IfNil(tsv))
+	})
+	t.Run("nil hasher should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsTxSignaturesVerifier()
+		args.Hasher = nil
+		tsv, err := transaction.NewTxSignaturesVerifier(args)
+		assert.Equal(t, transaction.ErrNilHasher, err)
+		assert.True(t, check.IfNil(tsv))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		tsv, err := transaction.NewTxSignaturesVerifier(createMockArgsTxSignaturesVerifier())
+		assert.Nil(t, err)
+		assert.False(t, check.IfNil(tsv))
+	})
+}
+
+func TestTxSignaturesVerifier_VerifyTransaction(t *testing.T) {
+	t.Parallel()
+
+	tsv, _ := transaction.NewTxSignaturesVerifier(createMockArgsTxSignaturesVerifier())
+	guardian := createEd25519Signer(t, 2)
+	relayer := createEd25519Signer(t, 3)
+
+	t.Run("nil transaction should error", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, transaction.ErrNilTransaction, tsv.VerifyTransaction(nil))
+	})
+	t.Run("options on initial version should error", func(t *testing.T) {
+		t.Parallel()
+
+		tx := buildSignedTx(t, nil, nil)
+		tx.Version = 1
+		tx.Options = transaction.MaskSignedWithHash
+		err := tsv.VerifyTransaction(tx)
+		assert.True(t, errors.Is(err, transaction.ErrOptionsNotAllowedForVersion))
+	})
+	t.Run("unexpected guardian signature should error", func(t *testing.T) {
+		t.Parallel()
+
+		tx := buildSignedTx(t, nil, nil)
+		tx.GuardianSignature = []byte("signature")
+		assert.Equal(t, transaction.ErrGuardianSignatureNotExpected, tsv.VerifyTransaction(tx))
+	})
+	t.Run("unexpected relayer signature should error", func(t *testing.T) {
+		t.Parallel()
+
+		tx := buildSignedTx(t, nil, nil)
+		tx.RelayerSignature = []byte("signature")
+		assert.Equal(t, transaction.ErrRelayerSignatureNotExpected, tsv.VerifyTransaction(tx))
+	})
+	t.Run("missing sender signature should error", func(t *testing.T) {
+		t.Parallel()
+
+		tx := buildSignedTx(t, nil, nil)
+		tx.Signature = nil
+		err := tsv.VerifyTransaction(tx)
+		assert.True(t, errors.Is(err, transaction.ErrMissingSignature))
+	})
+	t.Run("tampered transaction should error", func(t *testing.T) {
+		t.Parallel()
+
+		tx := buildSignedTx(t, nil, nil)
+		tx.Value = big.NewInt(11)
+		err := tsv.VerifyTransaction(tx)
+		assert.True(t, errors.Is(err, crypto.ErrInvalidSignature))
+	})
+	t.Run("invalid guardian signature should error", func(t *testing.T) {
+		t.Parallel()
+
+		tx := buildSignedTx(t, guardian, nil)
+		tx.GuardianSignature = tx.Signature
+		err := tsv.VerifyTransaction(tx)
+		assert.True(t, errors.Is(err, crypto.ErrInvalidSignature))
+	})
+	t.Run("missing relayer signature should error", func(t *testing.T) {
+		t.Parallel()
+
+		tx := buildSignedTx(t, nil, relayer)
+		tx.RelayerSignature = nil
+		err := tsv.VerifyTransaction(tx)
+		assert.True(t, errors.Is(err, transaction.ErrMissingSignature))
+	})
+	t.Run("marshaller error should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsTxSignaturesVerifier()
+		args.Marshaller = &mock.MarshalizerMock{Fail: true}
+		failingVerifier, _ := transaction.NewTxSignaturesVerifier(args)
+		err := failingVerifier.VerifyTransaction(buildSignedTx(t, nil, nil))
+		assert.NotNil(t, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Nil(t, tsv.VerifyTransaction(buildSignedTx(t, nil, nil)))
+		assert.Nil(t, tsv.VerifyTransaction(buildSignedTx(t, guardian, nil)))
+		assert.Nil(t, tsv.VerifyTransaction(buildSignedTx(t, nil, relayer)))
+		assert.Nil(t, tsv.VerifyTransaction(buildSignedTx(t, guardian, relayer)))
+	})
+}